@@ -0,0 +1,348 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/config"
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+	"github.com/felipevillarrealdaza/go-service-template/internal/transfer"
+	"github.com/google/uuid"
+)
+
+const usage = `usage:
+  api [--<config-key> <value>...]                 run the HTTP API
+  api config print [--<config-key> <value>...]    print the effective config, secrets redacted
+  api orders recalculate [--dry-run] [--status <status>] [--from <time>] [--to <time>] [--batch-size <n>]
+                         [--profile <name>] [--tenant <id>] [--<config-key> <value>...]
+                                                  re-plan open orders with the current pack set
+  api packs simulate --sizes <size,...> [--costs <size=cost,...>] [--profile <name>] [--tenant <id>]
+                     [--<config-key> <value>...]
+                                                  compare the order history packed with the proposed sizes
+  api export packs|orders [--format csv|json] [--output <file>] [--profile <name>] [--tenant <id>]
+                          [--<config-key> <value>...]
+                                                  export the pack sizes or the orders with their packs
+  api import orders [--input <file>] [--format csv|json] [--profile <name>] [--tenant <id>]
+                    [--<config-key> <value>...]
+                                                  create and calculate an order per quantity of a CSV
+`
+
+func runCommand(name string, args []string) {
+	switch {
+	case name == "config" && len(args) > 0 && args[0] == "print":
+		printConfig(args[1:])
+	case name == "orders" && len(args) > 0 && args[0] == "recalculate":
+		recalculateOrders(args[1:])
+	case name == "packs" && len(args) > 0 && args[0] == "simulate":
+		simulatePackSet(args[1:])
+	case name == "export" && len(args) > 0 && (args[0] == "packs" || args[0] == "orders"):
+		exportTable(args[0], args[1:])
+	case name == "import" && len(args) > 0 && args[0] == "orders":
+		importOrders(args[1:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func printConfig(args []string) {
+	apiConfig, configErr := config.Load(context.Background(), args)
+	if configErr != nil {
+		fmt.Fprintf(os.Stderr, "could not parse API config: %+v\n", configErr)
+		os.Exit(1)
+	}
+	fmt.Print(config.Print(apiConfig))
+
+	if validationErr := apiConfig.Validate(); validationErr != nil {
+		fmt.Fprintf(os.Stderr, "\ninvalid API config:\n%v\n", validationErr)
+	}
+	if validationErr := apiConfig.DbConfig.Validate(); validationErr != nil {
+		fmt.Fprintf(os.Stderr, "\ninvalid DB config:\n%v\n", validationErr)
+	}
+}
+
+func recalculateOrders(args []string) {
+	flagSet := flag.NewFlagSet("orders recalculate", flag.ContinueOnError)
+	dryRun := flagSet.Bool("dry-run", false, "report the changes without writing them")
+	status := flagSet.String("status", "", "only recalculate orders in this status, created or calculated")
+	from := flagSet.String("from", "", "only recalculate orders created at or after this RFC 3339 time")
+	to := flagSet.String("to", "", "only recalculate orders created before this RFC 3339 time")
+	batchSize := flagSet.Int("batch-size", 0, "orders recalculated per transaction")
+	profile := flagSet.String("profile", "", "pack profile of the orders, the default profile when empty")
+	tenant := flagSet.String("tenant", "", "tenant acted on, the default tenant when empty")
+	configArgs, flagsErr := parseCommandFlags(flagSet, args)
+	if flagsErr != nil {
+		exitWithError("invalid flags", flagsErr)
+	}
+	ctx := tenantContext(*tenant)
+
+	filter := domain_model.RecalculationFilter{BatchSize: *batchSize, DryRun: *dryRun, Profile: *profile}
+	if *status != "" {
+		filter.Statuses = []domain_model.OrderStatus{domain_model.OrderStatus(*status)}
+	}
+	var timeErr error
+	if filter.From, timeErr = parseOptionalTime(*from); timeErr != nil {
+		exitWithError("invalid --from", timeErr)
+	}
+	if filter.To, timeErr = parseOptionalTime(*to); timeErr != nil {
+		exitWithError("invalid --to", timeErr)
+	}
+
+	dbCtx, appConfig := openCommandDatabase(configArgs)
+	defer dbCtx.Close()
+	orderMediator := newOrderMediator(repository.New(dbCtx), repository.NewTransactor(dbCtx), appConfig, createSolverCache(appConfig))
+
+	report, recalculateErr := orderMediator.RecalculateOrders(ctx, filter)
+	printJson(report.ToViewModel())
+	if recalculateErr != nil {
+		exitWithError("could not recalculate orders", recalculateErr)
+	}
+}
+
+func simulatePackSet(args []string) {
+	flagSet := flag.NewFlagSet("packs simulate", flag.ContinueOnError)
+	sizes := flagSet.String("sizes", "", "comma separated pack sizes to simulate")
+	costs := flagSet.String("costs", "", "comma separated size=cost of a pack of each current and proposed size")
+	profile := flagSet.String("profile", "", "pack profile simulated, the default profile when empty")
+	tenant := flagSet.String("tenant", "", "tenant acted on, the default tenant when empty")
+	configArgs, flagsErr := parseCommandFlags(flagSet, args)
+	if flagsErr != nil {
+		exitWithError("invalid flags", flagsErr)
+	}
+	ctx := tenantContext(*tenant)
+
+	simulation := domain_model.PackSetSimulation{Profile: *profile}
+	for _, size := range strings.Split(*sizes, ",") {
+		packSize, sizeErr := strconv.Atoi(strings.TrimSpace(size))
+		if sizeErr != nil || packSize <= 0 || slices.Contains(simulation.PackSizes, packSize) {
+			exitWithError("invalid --sizes", fmt.Errorf("[%v] is not a new pack size bigger than 0", size))
+		}
+		simulation.PackSizes = append(simulation.PackSizes, packSize)
+	}
+	if *costs != "" {
+		simulation.PackCosts = make(map[int]float64)
+		for _, sizeCost := range strings.Split(*costs, ",") {
+			size, cost, _ := strings.Cut(sizeCost, "=")
+			packSize, sizeErr := strconv.Atoi(strings.TrimSpace(size))
+			packCost, costErr := strconv.ParseFloat(strings.TrimSpace(cost), 64)
+			if sizeErr != nil || costErr != nil || packCost < 0 {
+				exitWithError("invalid --costs", fmt.Errorf("[%v] is not a size=cost pair", sizeCost))
+			}
+			simulation.PackCosts[packSize] = packCost
+		}
+	}
+
+	dbCtx, appConfig := openCommandDatabase(configArgs)
+	defer dbCtx.Close()
+	packMediator := newPackMediator(repository.New(dbCtx), repository.NewTransactor(dbCtx), appConfig, createSolverCache(appConfig))
+
+	report, simulateErr := packMediator.SimulatePackSet(ctx, simulation)
+	if simulateErr != nil {
+		exitWithError("could not simulate pack set", simulateErr)
+	}
+	printJson(report.ToViewModel())
+}
+
+func exportTable(table string, args []string) {
+	flagSet := flag.NewFlagSet("export "+table, flag.ContinueOnError)
+	formatName := flagSet.String("format", "json", "csv or json")
+	outputPath := flagSet.String("output", "", "file to write to, stdout by default")
+	profile := flagSet.String("profile", "", "pack profile of the packs exported, the default profile when empty")
+	tenant := flagSet.String("tenant", "", "tenant acted on, the default tenant when empty")
+	configArgs, flagsErr := parseCommandFlags(flagSet, args)
+	if flagsErr != nil {
+		exitWithError("invalid flags", flagsErr)
+	}
+	ctx := tenantContext(*tenant)
+	format, formatErr := transfer.ParseFormat(*formatName)
+	if formatErr != nil {
+		exitWithError("invalid --format", formatErr)
+	}
+
+	dbCtx, appConfig := openCommandDatabase(configArgs)
+	defer dbCtx.Close()
+	output, closeOutput := openOutput(*outputPath)
+	defer closeOutput()
+
+	var exportErr error
+	if table == "packs" {
+		exportErr = exportPacks(ctx, dbCtx, appConfig, output, format, *profile)
+	} else {
+		exportErr = exportOrders(ctx, dbCtx, appConfig, output, format)
+	}
+	if exportErr != nil {
+		exitWithError("could not export "+table, exportErr)
+	}
+}
+
+func exportPacks(ctx context.Context, dbCtx *sql.DB, appConfig config.AppConfig, output io.Writer, format transfer.Format, profile string) error {
+	packMediator := newPackMediator(repository.New(dbCtx), repository.NewTransactor(dbCtx), appConfig, nil)
+	packs, retrieveErr := packMediator.RetrievePacks(ctx, profile)
+	if retrieveErr != nil {
+		return retrieveErr
+	}
+	packExports := make([]viewmodel.PackExport, 0, len(packs))
+	for _, pack := range packs {
+		packExports = append(packExports, viewmodel.PackExport{Size: pack})
+	}
+	return transfer.WritePacks(output, format, packExports)
+}
+
+func exportOrders(ctx context.Context, dbCtx *sql.DB, appConfig config.AppConfig, output io.Writer, format transfer.Format) error {
+	orderMediator := newOrderMediator(repository.New(dbCtx), repository.NewTransactor(dbCtx), appConfig, nil)
+	orderWriter := transfer.NewOrderWriter(output, format)
+	exportErr := orderMediator.ExportOrders(ctx, func(order domain_model.OrderExport) error {
+		return orderWriter.Write(order.ToViewModel())
+	})
+	if exportErr != nil {
+		return exportErr
+	}
+	return orderWriter.Close()
+}
+
+func importOrders(args []string) {
+	flagSet := flag.NewFlagSet("import orders", flag.ContinueOnError)
+	inputPath := flagSet.String("input", "", "CSV of quantities to read, stdin by default")
+	formatName := flagSet.String("format", "csv", "format of the results, csv or json")
+	profile := flagSet.String("profile", "", "pack profile of the orders, the default profile when empty")
+	tenant := flagSet.String("tenant", "", "tenant acted on, the default tenant when empty")
+	configArgs, flagsErr := parseCommandFlags(flagSet, args)
+	if flagsErr != nil {
+		exitWithError("invalid flags", flagsErr)
+	}
+	ctx := tenantContext(*tenant)
+	format, formatErr := transfer.ParseFormat(*formatName)
+	if formatErr != nil {
+		exitWithError("invalid --format", formatErr)
+	}
+
+	input := io.Reader(os.Stdin)
+	if *inputPath != "" {
+		inputFile, openErr := os.Open(*inputPath)
+		if openErr != nil {
+			exitWithError("could not open --input", openErr)
+		}
+		defer inputFile.Close()
+		input = inputFile
+	}
+
+	dbCtx, appConfig := openCommandDatabase(configArgs)
+	defer dbCtx.Close()
+	orderMediator := newOrderMediator(repository.New(dbCtx), repository.NewTransactor(dbCtx), appConfig, createSolverCache(appConfig))
+
+	failedRows, readErr := importOrderRows(ctx, orderMediator, input, os.Stdout, format, *profile)
+	if readErr != nil {
+		exitWithError("could not read --input", readErr)
+	}
+	if failedRows > 0 {
+		fmt.Fprintf(os.Stderr, "%v rows could not be imported\n", failedRows)
+		os.Exit(1)
+	}
+}
+
+// Create and calculate an order per quantity of the input, writing a result per row, and return how many rows failed
+func importOrderRows(ctx context.Context, orderMediator mediator.OrderMediator, input io.Reader, output io.Writer, format transfer.Format, profile string) (int, error) {
+	resultWriter := transfer.NewImportResultWriter(output, format)
+	defer resultWriter.Close()
+	return transfer.ImportOrders(input, resultWriter, func(quantity int) (uuid.UUID, []viewmodel.OrderPack, error) {
+		orderPacks, importErr := orderMediator.ImportOrder(ctx, profile, quantity)
+		return orderPacks.OrderId, orderPacks.OptimalOrderPack.ToViewModel(), importErr
+	})
+}
+
+// Act on the given tenant, or the default tenant when none is given
+func tenantContext(tenant string) context.Context {
+	if tenant == "" {
+		return context.Background()
+	}
+	if !domain_model.IsValidTenant(tenant) {
+		exitWithError("invalid --tenant", fmt.Errorf("[%v] is not a tenant id", tenant))
+	}
+	return domain_model.ContextWithTenant(context.Background(), tenant)
+}
+
+// Open the file to write to, or stdout when no path is given
+func openOutput(path string) (io.Writer, func()) {
+	if path == "" {
+		return os.Stdout, func() {}
+	}
+	outputFile, createErr := os.Create(path)
+	if createErr != nil {
+		exitWithError("could not create --output", createErr)
+	}
+	return outputFile, func() { outputFile.Close() }
+}
+
+// Load the config from the remaining args, then open and migrate the DB. The app config is returned for the mediators.
+func openCommandDatabase(configArgs []string) (*sql.DB, config.AppConfig) {
+	apiConfig, configErr := config.Load(context.Background(), configArgs)
+	if configErr != nil {
+		exitWithError("could not parse API config", configErr)
+	}
+	if validationErr := apiConfig.Validate(); validationErr != nil {
+		exitWithError("invalid API config", validationErr)
+	}
+	if validationErr := apiConfig.DbConfig.Validate(); validationErr != nil {
+		exitWithError("invalid DB config", validationErr)
+	}
+
+	dbCtx, dbErr := sql.Open("postgres", apiConfig.DbConfig.RetrieveDBConnectionString())
+	if dbErr != nil {
+		exitWithError("could not create db connection", dbErr)
+	}
+	configureConnectionPool(dbCtx, apiConfig.DbConfig)
+	migrationSettings := repository.MigrationSettings{Currency: apiConfig.AppConfig.Currency}
+	if migrateErr := repository.Migrate(context.Background(), dbCtx, migrationSettings); migrateErr != nil {
+		exitWithError("could not migrate db", migrateErr)
+	}
+	return dbCtx, apiConfig.AppConfig
+}
+
+// Parse the flags defined in the flag set, wherever they are, and return the rest of the args for the config
+func parseCommandFlags(flagSet *flag.FlagSet, args []string) ([]string, error) {
+	var commandArgs, configArgs []string
+	for i := 0; i < len(args); i++ {
+		name, _, hasValue := strings.Cut(strings.TrimLeft(args[i], "-"), "=")
+		commandFlag := flagSet.Lookup(name)
+		if !strings.HasPrefix(args[i], "-") || commandFlag == nil {
+			configArgs = append(configArgs, args[i])
+			continue
+		}
+		commandArgs = append(commandArgs, args[i])
+		if _, isBool := commandFlag.Value.(interface{ IsBoolFlag() bool }); !isBool && !hasValue && i+1 < len(args) {
+			i++
+			commandArgs = append(commandArgs, args[i])
+		}
+	}
+	return configArgs, flagSet.Parse(commandArgs)
+}
+
+func parseOptionalTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+func printJson(body any) {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(body)
+}
+
+func exitWithError(message string, err error) {
+	fmt.Fprintf(os.Stderr, "%v: %+v\n", message, err)
+	os.Exit(1)
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/config"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+	repository_mocks "github.com/felipevillarrealdaza/go-service-template/internal/repository/mocks"
+	"github.com/felipevillarrealdaza/go-service-template/internal/transfer"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ImportOrderRows(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	transactorMock := repository_mocks.NewTransactor(t)
+	transactorMock.On("WithinTransaction", mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, fn func(repository.Querier) error) error { return fn(repositoryMock) },
+	)
+	orderMediator := newOrderMediator(repositoryMock, transactorMock, config.AppConfig{}, nil)
+
+	// Arrange
+	var addedOrder repository.AddOrderParams
+	repositoryMock.On("AddOrder", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		addedOrder = args.Get(1).(repository.AddOrderParams)
+	}).Return(nil).Once()
+	repositoryMock.On("RetrieveOrderById", mock.Anything, mock.Anything).Return(func(ctx context.Context, params repository.RetrieveOrderByIdParams) (repository.Order, error) {
+		return repository.Order{OrderID: params.OrderID, OrderQuantity: addedOrder.OrderQuantity, Status: "created", Profile: addedOrder.Profile}, nil
+	}).Once()
+	repositoryMock.On("RetrievePacks", mock.Anything, mock.Anything).Return([]int32{500, 250}, nil).Once()
+	repositoryMock.On("RetrieveContainerTypes", mock.Anything, "default").Return(nil, nil).Once()
+	repositoryMock.On("UpdateOrderStatus", mock.Anything, mock.Anything).Return(repository.Order{Status: "calculated"}, nil).Once()
+	repositoryMock.On("RetrievePackPrices", mock.Anything, mock.Anything).Return(nil, nil).Once()
+	repositoryMock.On("AddOrderPack", mock.Anything, mock.Anything).Return(nil).Twice()
+	repositoryMock.On("UpdateOrderPackSet", mock.Anything, mock.Anything).Return(nil).Once()
+	var output bytes.Buffer
+
+	// Act
+	failedRows, readErr := importOrderRows(context.Background(), orderMediator, strings.NewReader("quantity\n750\n"), &output, transfer.FormatCsv, "")
+
+	// Assert
+	require.NoError(t, readErr)
+	require.Equal(t, 0, failedRows)
+	transactorMock.AssertExpectations(t)
+	require.Equal(t, "row,quantity,id,packs,error\n1,750,"+addedOrder.OrderID.String()+",250x1 500x1,\n", output.String())
+}
+
+func Test_ImportOrderRows_AppConfig(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	transactorMock := repository_mocks.NewTransactor(t)
+	transactorMock.On("WithinTransaction", mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, fn func(repository.Querier) error) error { return fn(repositoryMock) },
+	)
+	appConfig := config.AppConfig{DefaultPackProfile: "retail", ItemWeightGrams: 10, MaxParcelWeightGrams: 3000, Currency: "EUR"}
+	orderMediator := newOrderMediator(repositoryMock, transactorMock, appConfig, nil)
+
+	// Arrange
+	var addedOrder repository.AddOrderParams
+	repositoryMock.On("AddOrder", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		addedOrder = args.Get(1).(repository.AddOrderParams)
+	}).Return(nil).Once()
+	repositoryMock.On("RetrieveOrderById", mock.Anything, mock.Anything).Return(func(ctx context.Context, params repository.RetrieveOrderByIdParams) (repository.Order, error) {
+		return repository.Order{OrderID: params.OrderID, OrderQuantity: addedOrder.OrderQuantity, Status: "created", Profile: addedOrder.Profile}, nil
+	}).Once()
+	repositoryMock.On("RetrievePackSpecifications", mock.Anything, mock.MatchedBy(func(params repository.RetrievePackSpecificationsParams) bool { return params.Profile == "retail" })).
+		Return([]repository.Pack{{PackSize: 500, Profile: "retail"}, {PackSize: 250, Profile: "retail"}}, nil).Once()
+	repositoryMock.On("RetrieveContainerTypes", mock.Anything, "default").Return(nil, nil).Once()
+	repositoryMock.On("UpdateOrderStatus", mock.Anything, mock.Anything).Return(repository.Order{Status: "calculated"}, nil).Once()
+	repositoryMock.On("RetrievePackPrices", mock.Anything, mock.Anything).Return(nil, nil).Once()
+	repositoryMock.On("AddOrderPack", mock.Anything, mock.Anything).Return(nil).Once()
+	repositoryMock.On("AddOrderShipment", mock.Anything, mock.MatchedBy(func(params repository.AddOrderShipmentParams) bool { return params.PackSize == 250 })).Return(nil).Once()
+	repositoryMock.On("UpdateOrderPackSet", mock.Anything, mock.Anything).Return(nil).Once()
+	var output bytes.Buffer
+
+	// Act
+	failedRows, readErr := importOrderRows(context.Background(), orderMediator, strings.NewReader("750\n"), &output, transfer.FormatCsv, "")
+
+	// Assert
+	require.NoError(t, readErr)
+	require.Equal(t, 0, failedRows)
+	require.Equal(t, "retail", addedOrder.Profile)
+	require.Contains(t, output.String(), ",250x3,")
+}
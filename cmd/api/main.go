@@ -2,30 +2,44 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"database/sql"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	api "github.com/felipevillarrealdaza/go-service-template/internal/api/http"
 	"github.com/felipevillarrealdaza/go-service-template/internal/config"
 	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
 	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+	"github.com/felipevillarrealdaza/go-service-template/internal/worker"
 	_ "github.com/lib/pq"
-	"github.com/sethvargo/go-envconfig"
 )
 
 func main() {
-	// Parse env variables into config
-	apiConfig := config.ApiConfig{}
-	if configErr := envconfig.Process(context.Background(), &apiConfig); configErr != nil {
+	// Subcommands are dispatched by name, anything else is a flag for the HTTP API
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		runCommand(os.Args[1], os.Args[2:])
+		return
+	}
+
+	// Parse defaults, config file, env variables and flags into config
+	apiConfig, configErr := config.Load(context.Background(), os.Args[1:])
+	if configErr != nil {
 		panic(fmt.Sprintf("could not parse API config: %+v\n", configErr))
 	}
-	dbConfig := config.DbConfig{}
-	if configErr := envconfig.Process(context.Background(), &dbConfig); configErr != nil {
-		panic(fmt.Sprintf("could not parse DB config: %+v\n", configErr))
+	dbConfig := apiConfig.DbConfig
+	if validationErr := apiConfig.Validate(); validationErr != nil {
+		panic(fmt.Sprintf("invalid API config:\n%v\n", validationErr))
+	}
+	if validationErr := dbConfig.Validate(); validationErr != nil {
+		panic(fmt.Sprintf("invalid DB config:\n%v\n", validationErr))
 	}
 
 	// Create DB connection
@@ -34,45 +48,226 @@ func main() {
 		panic("could not create db connection!")
 	}
 	defer dbCtx.Close()
+	configureConnectionPool(dbCtx, dbConfig)
 
-	// Create channel to listen for SIGTERM event
+	// Bring the DB schema up to date before serving traffic
+	migrationSettings := repository.MigrationSettings{Currency: apiConfig.AppConfig.Currency}
+	if migrateErr := repository.Migrate(context.Background(), dbCtx, migrationSettings); migrateErr != nil {
+		panic(fmt.Sprintf("could not migrate db: %+v\n", migrateErr))
+	}
+
+	// Create channel to listen for SIGTERM and SIGINT events
 	shutdown := make(chan os.Signal, 1)
-	signal.Notify(shutdown, syscall.SIGTERM)
+	signal.Notify(shutdown, syscall.SIGTERM, syscall.SIGINT)
+
+	// Background workers are stopped during the graceful shutdown
+	workers := worker.NewGroup()
+
+	// Share solved grids between calculations, dropping them whenever an instance changes the pack set
+	solverCache := createSolverCache(apiConfig.AppConfig)
+	if solverCache != nil {
+		workers.Go(listenForPackSetChanges(dbConfig, solverCache))
+	}
+
+	// Purge the expired quotes every so often, every instance doing so is harmless
+	workers.Go(purgeExpiredQuotes(dbCtx, apiConfig.AppConfig.QuotePurgeInterval))
 
 	// Spawn goroutine to run the HTTP API
+	healthMediator := createHealthMediator(dbCtx, apiConfig)
+	authMediator := createAuthMediator(dbCtx, apiConfig.AuthConfig)
+	server := createHttpServer(apiConfig, createHttpApiHandler(dbCtx, apiConfig.AppConfig, healthMediator, authMediator, solverCache))
 	serverErr := make(chan error, 1)
-	go createAndStartHttpServer(dbCtx, apiConfig, serverErr)
+	go startHttpServer(server, apiConfig, serverErr)
 
 	// Hold execution and listen for errors on both channels
-	listenForErrorsAndHandleGracefully(serverErr, shutdown)
+	listenForErrorsAndHandleGracefully(serverErr, shutdown, func() {
+		shutdownGracefully(apiConfig, server, healthMediator, workers)
+	})
+}
+
+func startHttpServer(server *http.Server, apiConfig config.ApiConfig, serverErr chan error) {
+	var listenErr error
+	if apiConfig.TLSEnabled() {
+		listenErr = server.ListenAndServeTLS(apiConfig.TLSCertFile, apiConfig.TLSKeyFile)
+	} else {
+		listenErr = server.ListenAndServe()
+	}
+	if !errors.Is(listenErr, http.ErrServerClosed) {
+		serverErr <- listenErr
+	}
+}
+
+func configureConnectionPool(dbCtx *sql.DB, dbConfig config.DbConfig) {
+	dbCtx.SetMaxOpenConns(dbConfig.MaxOpenConns)
+	dbCtx.SetMaxIdleConns(dbConfig.MaxIdleConns)
+	dbCtx.SetConnMaxLifetime(dbConfig.ConnMaxLifetime)
+	dbCtx.SetConnMaxIdleTime(dbConfig.ConnMaxIdleTime)
+}
+
+func createHealthMediator(dbCtx *sql.DB, apiConfig config.ApiConfig) mediator.HealthMediator {
+	return mediator.NewHealthMediator(
+		mediator.WithHealthRepository(repository.New(dbCtx)),
+		mediator.WithDatabasePinger(dbCtx),
+		mediator.WithHealthCheckTimeout(apiConfig.AppConfig.HealthCheckTimeout),
+		mediator.WithExpectedSchemaVersion(repository.LatestSchemaVersion()),
+	)
+}
+
+// Create the auth mediator and store the bootstrap admin key, if configured
+func createAuthMediator(dbCtx *sql.DB, authConfig config.AuthConfig) mediator.AuthMediator {
+	deps := []mediator.AuthMediatorDeps{
+		mediator.WithAuthRepository(repository.New(dbCtx)),
+		mediator.WithJwtIssuerAndAudience(authConfig.JwtIssuer, authConfig.JwtAudience),
+	}
+	if authConfig.JwtHmacSecret != "" {
+		deps = append(deps, mediator.WithJwtHmacSecret([]byte(authConfig.JwtHmacSecret)))
+	}
+	if rsaPublicKey, _ := authConfig.ParseJwtRsaPublicKey(); rsaPublicKey != nil {
+		deps = append(deps, mediator.WithJwtRsaPublicKey(rsaPublicKey))
+	}
+	authMediator := mediator.NewAuthMediator(deps...)
+
+	if authConfig.BootstrapAdminKey != "" {
+		if ensureErr := authMediator.EnsureApiKey(context.Background(), "bootstrap", authConfig.BootstrapAdminKey, domain_model.RoleAdmin); ensureErr != nil {
+			panic(fmt.Sprintf("could not store bootstrap admin key: %+v\n", ensureErr))
+		}
+	}
+	return authMediator
 }
 
-func createAndStartHttpServer(dbCtx *sql.DB, apiConfig config.ApiConfig, serverErr chan error) {
-	handler := createHttpApiHandler(dbCtx)
-	server := createHttpServer(apiConfig, handler)
-	serverErr <- server.ListenAndServe()
+func createSolverCache(appConfig config.AppConfig) *mediator.SolverCache {
+	if appConfig.SolverCacheMemoryMb == 0 {
+		return nil
+	}
+	return mediator.NewSolverCache(appConfig.SolverCacheMemoryMb << 20)
 }
 
-func createHttpApiHandler(dbCtx *sql.DB) http.Handler {
-	// Create repository, which is a dependency for mediators
+// Drop the cached grids when notified of a pack set change, the notifications come from every instance
+func listenForPackSetChanges(dbConfig config.DbConfig, solverCache *mediator.SolverCache) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		listenErr := repository.ListenForNotifications(ctx, dbConfig.RetrieveDBConnectionString(), repository.PackSetChangedChannel, solverCache.Invalidate)
+		if listenErr != nil {
+			fmt.Printf("could not listen for pack set changes: %+v\n", listenErr)
+		}
+	}
+}
+
+// Remove the expired quotes every interval until the workers are stopped
+func purgeExpiredQuotes(dbCtx *sql.DB, interval time.Duration) func(ctx context.Context) {
+	orderMediator := mediator.NewOrderMediator(mediator.WithOrderRepository(repository.New(dbCtx)))
+	return func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, purgeErr := orderMediator.PurgeExpiredQuotes(ctx); purgeErr != nil {
+					fmt.Printf("could not purge expired quotes: %+v\n", purgeErr)
+				}
+			}
+		}
+	}
+}
+
+func createHttpApiHandler(dbCtx *sql.DB, appConfig config.AppConfig, healthMediator mediator.HealthMediator, authMediator mediator.AuthMediator, solverCache *mediator.SolverCache) http.Handler {
+	// Create repository and transactor, which are dependencies for mediators
+	transactor := repository.NewTransactor(dbCtx)
 	repository := repository.New(dbCtx)
 
 	// Create mediators, which are dependencies for controllers
-	packMediator := mediator.NewPackMediator(mediator.WithPackRepository(repository))
-	orderMediator := mediator.NewOrderMediator(mediator.WithOrderRepository(repository))
+	packMediator := newPackMediator(repository, transactor, appConfig, solverCache)
+	orderMediator := newOrderMediator(repository, transactor, appConfig, solverCache)
+	auditMediator := mediator.NewAuditMediator(mediator.WithAuditRepository(repository))
+	analyticsMediator := mediator.NewAnalyticsMediator(mediator.WithAnalyticsRepository(repository))
+	containerMediator := mediator.NewContainerMediator(mediator.WithContainerRepository(repository))
+	pricingMediator := mediator.NewPricingMediator(
+		mediator.WithPricingRepository(repository),
+		mediator.WithPricingDefaultProfile(appConfig.DefaultPackProfile),
+		mediator.WithPricingCurrency(appConfig.Currency),
+	)
 
-	return api.NewRouter(packMediator, orderMediator, repository)
+	return api.NewRouter(
+		api.WithPackMediator(packMediator),
+		api.WithOrderMediator(orderMediator),
+		api.WithHealthMediator(healthMediator),
+		api.WithAuthMediator(authMediator),
+		api.WithAuditMediator(auditMediator),
+		api.WithAnalyticsMediator(analyticsMediator),
+		api.WithContainerMediator(containerMediator),
+		api.WithPricingMediator(pricingMediator),
+		api.WithRateLimit(appConfig.RateLimitRequestsPerSecond, appConfig.RateLimitBurst),
+		api.WithConcurrencyLimit(appConfig.MaxConcurrentCalculations, appConfig.CalculationQueueTimeout),
+	)
 }
 
-func createHttpServer(apiConfig config.ApiConfig, handler http.Handler) http.Server {
-	return http.Server{
-		Addr:    apiConfig.RetrieveApiAddress(),
-		Handler: handler,
+// Pack mediator configured from the app config, shared by the API and the commands
+func newPackMediator(querier repository.Querier, transactor repository.Transactor, appConfig config.AppConfig, solverCache *mediator.SolverCache) mediator.PackMediator {
+	return mediator.NewPackMediator(
+		mediator.WithPackRepository(querier),
+		mediator.WithPackTransactor(transactor),
+		mediator.WithPackSolverCache(solverCache),
+		mediator.WithPackDefaultProfile(appConfig.DefaultPackProfile),
+	)
+}
+
+// Order mediator configured from the app config, shared by the API and the commands
+func newOrderMediator(querier repository.Querier, transactor repository.Transactor, appConfig config.AppConfig, solverCache *mediator.SolverCache) mediator.OrderMediator {
+	return mediator.NewOrderMediator(
+		mediator.WithOrderRepository(querier),
+		mediator.WithOrderTransactor(transactor),
+		mediator.WithOrderSolverCache(solverCache),
+		mediator.WithShippingConstraints(domain_model.ShippingConstraints{
+			ItemWeightGrams:       appConfig.ItemWeightGrams,
+			MaxParcelWeightGrams:  appConfig.MaxParcelWeightGrams,
+			MaxParcelsPerShipment: appConfig.MaxParcelsPerShipment,
+			MaxItemsPerShipment:   appConfig.MaxItemsPerShipment,
+			BalanceShipments:      appConfig.BalanceShipments,
+		}),
+		mediator.WithOrderCurrency(appConfig.Currency),
+		mediator.WithQuoteTtl(appConfig.QuoteTtl),
+		mediator.WithOrderDefaultProfile(appConfig.DefaultPackProfile),
+	)
+}
+
+func createHttpServer(apiConfig config.ApiConfig, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              apiConfig.RetrieveApiAddress(),
+		Handler:           handler,
+		ReadTimeout:       apiConfig.ReadTimeout,
+		ReadHeaderTimeout: apiConfig.ReadHeaderTimeout,
+		WriteTimeout:      apiConfig.WriteTimeout,
+		IdleTimeout:       apiConfig.IdleTimeout,
+		MaxHeaderBytes:    apiConfig.MaxHeaderBytes,
+		TLSConfig:         &tls.Config{MinVersion: tls.VersionTLS12},
+	}
+}
+
+func listenForErrorsAndHandleGracefully(serverErr chan error, shutdown chan os.Signal, shutdownGracefully func()) {
+	select {
+	case err := <-serverErr:
+		fmt.Print(err.Error())
+		os.Exit(1)
+	case sig := <-shutdown:
+		fmt.Printf("received [%v], shutting down gracefully\n", sig)
+		shutdownGracefully()
 	}
 }
 
-func listenForErrorsAndHandleGracefully(serverErr chan error, shutdown chan os.Signal) {
-	err := <-serverErr
-	fmt.Print(err.Error())
-	os.Exit(1)
+// Stop accepting traffic, let in-flight requests finish and stop background workers. The DB connection is closed
+// once main returns.
+func shutdownGracefully(apiConfig config.ApiConfig, server *http.Server, healthMediator mediator.HealthMediator, workers *worker.Group) {
+	healthMediator.StartDraining()
+	time.Sleep(apiConfig.ShutdownDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), apiConfig.ShutdownTimeout)
+	defer cancel()
+
+	if shutdownErr := server.Shutdown(ctx); shutdownErr != nil {
+		fmt.Printf("could not drain in-flight requests: %+v\n", shutdownErr)
+	}
+	if stopErr := workers.Stop(ctx); stopErr != nil {
+		fmt.Printf("could not stop background workers: %+v\n", stopErr)
+	}
 }
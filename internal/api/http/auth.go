@@ -0,0 +1,68 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+)
+
+const apiKeyHeader = "X-API-Key"
+
+// Authenticate the credentials of the request, if any, and store the principal in the request context.
+// Requests without credentials go through anonymously, it is up to each route to require a role.
+func authenticationMiddleware(authMediator mediator.AuthMediator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			credentials := credentialsFromRequest(r)
+			if credentials.IsEmpty() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			principal, authErr := authMediator.Authenticate(r.Context(), credentials)
+			if errors.Is(authErr, mediator.ErrUnauthenticated) {
+				writeUnauthorized(w, authErr.Error())
+				return
+			}
+			if authErr != nil {
+				http.Error(w, authErr.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(domain_model.ContextWithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+// Only let requests through when the authenticated principal has the role, or one granting it
+func requireRole(role domain_model.Role, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal, authenticated := domain_model.PrincipalFromContext(r.Context())
+		if !authenticated {
+			writeUnauthorized(w, "authentication required")
+			return
+		}
+		if !principal.Role.Grants(role) {
+			http.Error(w, "role ["+string(role)+"] required", http.StatusForbidden)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// API keys are sent in the X-API-Key header, JWTs as bearer tokens in the Authorization header
+func credentialsFromRequest(r *http.Request) domain_model.Credentials {
+	credentials := domain_model.Credentials{ApiKey: r.Header.Get(apiKeyHeader)}
+	if scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " "); found && strings.EqualFold(scheme, "Bearer") {
+		credentials.BearerToken = strings.TrimSpace(token)
+	}
+	return credentials
+}
+
+func writeUnauthorized(w http.ResponseWriter, message string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="pack-calculator"`)
+	http.Error(w, message, http.StatusUnauthorized)
+}
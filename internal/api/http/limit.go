@@ -0,0 +1,122 @@
+package api
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"golang.org/x/time/rate"
+)
+
+// Clients idle for longer than this are forgotten, so the limiter map does not grow forever
+const clientLimiterIdleTtl = 10 * time.Minute
+
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// Token bucket rate limiter per client. Authenticated clients are keyed by their principal, anonymous ones by IP.
+type rateLimiter struct {
+	requestsPerSecond rate.Limit
+	burst             int
+
+	mu        sync.Mutex
+	clients   map[string]*clientLimiter
+	lastSweep time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64, burst int) *rateLimiter {
+	return &rateLimiter{
+		requestsPerSecond: rate.Limit(requestsPerSecond),
+		burst:             burst,
+		clients:           make(map[string]*clientLimiter),
+		lastSweep:         time.Now(),
+	}
+}
+
+func (rl *rateLimiter) middleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		reservation := rl.limiterFor(clientKey(r)).Reserve()
+		if delay := reservation.Delay(); delay > 0 {
+			// Give back the token, the request is rejected instead of delayed
+			reservation.Cancel()
+			w.Header().Set("Retry-After", retryAfterSeconds(delay))
+			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (rl *rateLimiter) limiterFor(key string) *rate.Limiter {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(rl.lastSweep) > clientLimiterIdleTtl {
+		for clientKey, client := range rl.clients {
+			if now.Sub(client.lastSeen) > clientLimiterIdleTtl {
+				delete(rl.clients, clientKey)
+			}
+		}
+		rl.lastSweep = now
+	}
+
+	client, found := rl.clients[key]
+	if !found {
+		client = &clientLimiter{limiter: rate.NewLimiter(rl.requestsPerSecond, rl.burst)}
+		rl.clients[key] = client
+	}
+	client.lastSeen = now
+	return client.limiter
+}
+
+func clientKey(r *http.Request) string {
+	if principal, authenticated := domain_model.PrincipalFromContext(r.Context()); authenticated {
+		return string(principal.Method) + ":" + principal.Subject
+	}
+	host, _, splitErr := net.SplitHostPort(r.RemoteAddr)
+	if splitErr != nil {
+		return "ip:" + r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// Caps how many requests run at the same time across all clients. Excess requests wait for a slot up to the queue
+// timeout, and are rejected afterwards.
+type concurrencyLimiter struct {
+	slots        chan struct{}
+	queueTimeout time.Duration
+}
+
+func newConcurrencyLimiter(maxConcurrent int, queueTimeout time.Duration) *concurrencyLimiter {
+	return &concurrencyLimiter{slots: make(chan struct{}, maxConcurrent), queueTimeout: queueTimeout}
+}
+
+func (cl *concurrencyLimiter) middleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		timer := time.NewTimer(cl.queueTimeout)
+		defer timer.Stop()
+
+		select {
+		case cl.slots <- struct{}{}:
+			defer func() { <-cl.slots }()
+			next(w, r)
+		case <-timer.C:
+			w.Header().Set("Retry-After", retryAfterSeconds(cl.queueTimeout))
+			http.Error(w, "too many calculations in progress", http.StatusServiceUnavailable)
+		case <-r.Context().Done():
+			// The client went away while queued, nobody reads the response
+		}
+	}
+}
+
+// Retry-After is expressed in whole seconds, rounded up so clients do not retry too early
+func retryAfterSeconds(delay time.Duration) string {
+	return strconv.Itoa(int(math.Max(1, math.Ceil(delay.Seconds()))))
+}
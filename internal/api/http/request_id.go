@@ -0,0 +1,26 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/google/uuid"
+)
+
+const (
+	requestIdHeader = "X-Request-ID"
+	// Longer ids sent by clients are replaced, so they cannot bloat logs and the audit table
+	maxRequestIdLength = 128
+)
+
+// Take the request id sent by the client, or generate one, store it in the request context and echo it in the response
+func requestIdMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestId := r.Header.Get(requestIdHeader)
+		if requestId == "" || len(requestId) > maxRequestIdLength {
+			requestId = uuid.NewString()
+		}
+		w.Header().Set(requestIdHeader, requestId)
+		next.ServeHTTP(w, r.WithContext(domain_model.ContextWithRequestId(r.Context(), requestId)))
+	})
+}
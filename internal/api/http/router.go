@@ -2,28 +2,165 @@ package api
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/felipevillarrealdaza/go-service-template/internal/controller"
 	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
-	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
 	"github.com/gorilla/mux"
 )
 
-func NewRouter(packMediator mediator.PackMediator, orderMediator mediator.OrderMediator, repository repository.Querier) http.Handler {
+// Dependency injection using optional pattern
+type RouterDeps func(deps *routerDeps)
+
+func WithPackMediator(mediator mediator.PackMediator) RouterDeps {
+	return func(deps *routerDeps) {
+		deps.packMediator = mediator
+	}
+}
+
+func WithOrderMediator(mediator mediator.OrderMediator) RouterDeps {
+	return func(deps *routerDeps) {
+		deps.orderMediator = mediator
+	}
+}
+
+func WithHealthMediator(mediator mediator.HealthMediator) RouterDeps {
+	return func(deps *routerDeps) {
+		deps.healthMediator = mediator
+	}
+}
+
+func WithAuthMediator(mediator mediator.AuthMediator) RouterDeps {
+	return func(deps *routerDeps) {
+		deps.authMediator = mediator
+	}
+}
+
+func WithAuditMediator(mediator mediator.AuditMediator) RouterDeps {
+	return func(deps *routerDeps) {
+		deps.auditMediator = mediator
+	}
+}
+
+func WithAnalyticsMediator(mediator mediator.AnalyticsMediator) RouterDeps {
+	return func(deps *routerDeps) {
+		deps.analyticsMediator = mediator
+	}
+}
+
+func WithContainerMediator(mediator mediator.ContainerMediator) RouterDeps {
+	return func(deps *routerDeps) {
+		deps.containerMediator = mediator
+	}
+}
+
+func WithPricingMediator(mediator mediator.PricingMediator) RouterDeps {
+	return func(deps *routerDeps) {
+		deps.pricingMediator = mediator
+	}
+}
+
+// Limit each client to requestsPerSecond calculations, allowing bursts of up to burst requests
+func WithRateLimit(requestsPerSecond float64, burst int) RouterDeps {
+	return func(deps *routerDeps) {
+		deps.rateLimiter = newRateLimiter(requestsPerSecond, burst)
+	}
+}
+
+// Limit how many calculations run at once, queueing the excess for up to queueTimeout
+func WithConcurrencyLimit(maxConcurrent int, queueTimeout time.Duration) RouterDeps {
+	return func(deps *routerDeps) {
+		deps.concurrencyLimiter = newConcurrencyLimiter(maxConcurrent, queueTimeout)
+	}
+}
+
+type routerDeps struct {
+	packMediator      mediator.PackMediator
+	orderMediator     mediator.OrderMediator
+	healthMediator    mediator.HealthMediator
+	authMediator      mediator.AuthMediator
+	auditMediator     mediator.AuditMediator
+	analyticsMediator mediator.AnalyticsMediator
+	containerMediator mediator.ContainerMediator
+	pricingMediator   mediator.PricingMediator
+
+	rateLimiter        *rateLimiter
+	concurrencyLimiter *concurrencyLimiter
+}
+
+func NewRouter(opts ...RouterDeps) http.Handler {
+	deps := routerDeps{}
+	for _, opt := range opts {
+		opt(&deps)
+	}
+
 	router := mux.NewRouter().PathPrefix("/api/v1").Subrouter()
 
 	// Add middlewares for the router
+	router.Use(requestIdMiddleware)
+	router.Use(authenticationMiddleware(deps.authMediator))
+	router.Use(tenantMiddleware)
 
 	// Create controllers
-	healthController := controller.NewHttpHealthController()
-	orderController := controller.NewHttpOrderController(controller.WithOrderMediator(orderMediator))
-	packController := controller.NewHttpPackController(controller.WithPackMediator(packMediator))
+	healthController := controller.NewHttpHealthController(controller.WithHealthMediator(deps.healthMediator))
+	orderController := controller.NewHttpOrderController(controller.WithOrderMediator(deps.orderMediator))
+	packController := controller.NewHttpPackController(controller.WithPackMediator(deps.packMediator))
+	apiKeyController := controller.NewHttpApiKeyController(controller.WithAuthMediator(deps.authMediator))
+	auditController := controller.NewHttpAuditController(controller.WithAuditMediator(deps.auditMediator))
+	analyticsController := controller.NewHttpAnalyticsController(controller.WithAnalyticsMediator(deps.analyticsMediator))
+	containerController := controller.NewHttpContainerController(controller.WithContainerMediator(deps.containerMediator))
+	pricingController := controller.NewHttpPricingController(controller.WithPricingMediator(deps.pricingMediator))
 
 	// Match routes to controller's methods
-	router.Path("/health").Methods(http.MethodGet).HandlerFunc(healthController.Health)
-	router.Path("/pack").Methods(http.MethodPost).HandlerFunc(packController.AddPack)
-	router.Path("/pack").Methods(http.MethodDelete).HandlerFunc(packController.RemovePack)
-	router.Path("/order").Methods(http.MethodPost).HandlerFunc(orderController.AddOrder)
+	router.Path("/health").Methods(http.MethodGet).HandlerFunc(healthController.Live)
+	router.Path("/health/live").Methods(http.MethodGet).HandlerFunc(healthController.Live)
+	router.Path("/health/ready").Methods(http.MethodGet).HandlerFunc(healthController.Ready)
+	router.Path("/api-key").Methods(http.MethodPost).HandlerFunc(requireRole(domain_model.RoleAdmin, apiKeyController.CreateApiKey))
+	router.Path("/api-key").Methods(http.MethodGet).HandlerFunc(requireRole(domain_model.RoleAdmin, apiKeyController.RetrieveApiKeys))
+	router.Path("/api-key/{id}").Methods(http.MethodDelete).HandlerFunc(requireRole(domain_model.RoleAdmin, apiKeyController.RevokeApiKey))
+	router.Path("/audit").Methods(http.MethodGet).HandlerFunc(requireRole(domain_model.RoleAdmin, auditController.RetrievePackAudits))
+	router.Path("/analytics/orders").Methods(http.MethodGet).HandlerFunc(requireRole(domain_model.RoleAdmin, analyticsController.RetrieveOrderSummary))
+	router.Path("/analytics/packs").Methods(http.MethodGet).HandlerFunc(requireRole(domain_model.RoleAdmin, analyticsController.RetrievePackUsage))
+	router.Path("/analytics/quantities").Methods(http.MethodGet).HandlerFunc(requireRole(domain_model.RoleAdmin, analyticsController.RetrieveQuantityHistogram))
+	router.Path("/container").Methods(http.MethodPost).HandlerFunc(requireRole(domain_model.RoleAdmin, containerController.AddContainerType))
+	router.Path("/container").Methods(http.MethodGet).HandlerFunc(requireRole(domain_model.RoleAdmin, containerController.RetrieveContainerTypes))
+	router.Path("/container/{name}").Methods(http.MethodDelete).HandlerFunc(requireRole(domain_model.RoleAdmin, containerController.RemoveContainerType))
+	router.Path("/discount").Methods(http.MethodPost).HandlerFunc(requireRole(domain_model.RoleAdmin, pricingController.AddDiscountTier))
+	router.Path("/discount").Methods(http.MethodGet).HandlerFunc(requireRole(domain_model.RoleAdmin, pricingController.RetrieveDiscountTiers))
+	router.Path("/discount/{min_quantity}").Methods(http.MethodDelete).HandlerFunc(requireRole(domain_model.RoleAdmin, pricingController.RemoveDiscountTier))
+	router.Path("/pack/schedule").Methods(http.MethodGet).HandlerFunc(requireRole(domain_model.RoleAdmin, packController.RetrievePackSchedule))
+	router.Path("/pack/export").Methods(http.MethodGet).HandlerFunc(requireRole(domain_model.RoleAdmin, packController.ExportPacks))
+	router.Path("/pack/simulate").Methods(http.MethodPost).HandlerFunc(requireRole(domain_model.RoleAdmin, deps.limitCalculation(packController.SimulatePackSet)))
+	router.Path("/pack/recommend").Methods(http.MethodPost).HandlerFunc(requireRole(domain_model.RoleAdmin, deps.limitCalculation(packController.RecommendPackSet)))
+	router.Path("/pack/analysis").Methods(http.MethodGet).HandlerFunc(requireRole(domain_model.RoleAdmin, deps.limitCalculation(packController.AnalyzePackSet)))
+	router.Path("/pack/chart").Methods(http.MethodGet).HandlerFunc(requireRole(domain_model.RoleClient, deps.limitCalculation(packController.ChartPacks)))
+	router.Path("/pack/{size}").Methods(http.MethodPut).HandlerFunc(requireRole(domain_model.RoleAdmin, packController.UpdatePackSpecification))
+	router.Path("/pack/{size}/validity").Methods(http.MethodPut).HandlerFunc(requireRole(domain_model.RoleAdmin, packController.SchedulePack))
+	router.Path("/pack/{size}/price").Methods(http.MethodPut).HandlerFunc(requireRole(domain_model.RoleAdmin, pricingController.UpdatePackPrice))
+	router.Path("/order/export").Methods(http.MethodGet).HandlerFunc(requireRole(domain_model.RoleAdmin, orderController.ExportOrders))
+	router.Path("/order/import").Methods(http.MethodPost).HandlerFunc(requireRole(domain_model.RoleClient, deps.limitCalculation(orderController.ImportOrders)))
+	router.Path("/order/recalculate").Methods(http.MethodPost).HandlerFunc(requireRole(domain_model.RoleAdmin, deps.limitCalculation(orderController.RecalculateOrders)))
+	router.Path("/order/{id}").Methods(http.MethodPatch).HandlerFunc(requireRole(domain_model.RoleClient, deps.limitCalculation(orderController.AmendOrder)))
+	router.Path("/order/{id}/status").Methods(http.MethodPatch).HandlerFunc(requireRole(domain_model.RoleClient, orderController.UpdateOrderStatus))
+	router.Path("/quote").Methods(http.MethodPost).HandlerFunc(requireRole(domain_model.RoleClient, deps.limitCalculation(orderController.AddQuote)))
+	router.Path("/quote/{id}/accept").Methods(http.MethodPost).HandlerFunc(requireRole(domain_model.RoleClient, deps.limitCalculation(orderController.AcceptQuote)))
+	router.Path("/profile").Methods(http.MethodPost).HandlerFunc(requireRole(domain_model.RoleAdmin, packController.AddPackProfile))
+	router.Path("/profile").Methods(http.MethodGet).HandlerFunc(requireRole(domain_model.RoleAdmin, packController.RetrievePackProfiles))
+	router.Path("/pack").Methods(http.MethodPost).HandlerFunc(requireRole(domain_model.RoleAdmin, packController.AddPack))
+	router.Path("/pack").Methods(http.MethodDelete).HandlerFunc(requireRole(domain_model.RoleAdmin, packController.RemovePack))
+	router.Path("/order").Methods(http.MethodPost).HandlerFunc(requireRole(domain_model.RoleClient, deps.limitCalculation(orderController.AddOrder)))
 
 	return router
 }
+
+// Calculations are CPU bound, so they go through the rate and concurrency limiters when configured
+func (deps routerDeps) limitCalculation(next http.HandlerFunc) http.HandlerFunc {
+	if deps.concurrencyLimiter != nil {
+		next = deps.concurrencyLimiter.middleware(next)
+	}
+	if deps.rateLimiter != nil {
+		next = deps.rateLimiter.middleware(next)
+	}
+	return next
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+)
+
+const tenantHeader = "X-Tenant-ID"
+
+// Store the tenant the request acts on in the request context. Credentials bound to a tenant always act on it, unbound
+// admins pick it with the X-Tenant-ID header and unbound clients are pinned to the default tenant.
+func tenantMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant := r.Header.Get(tenantHeader)
+		if tenant != "" && !domain_model.IsValidTenant(tenant) {
+			http.Error(w, "invalid tenant ["+tenant+"]", http.StatusBadRequest)
+			return
+		}
+
+		if principal, authenticated := domain_model.PrincipalFromContext(r.Context()); authenticated {
+			boundTenant := principal.Tenant
+			if boundTenant == "" && principal.Role != domain_model.RoleAdmin {
+				boundTenant = domain_model.DefaultTenant
+			}
+			if boundTenant != "" {
+				if tenant != "" && tenant != boundTenant {
+					http.Error(w, "credentials not valid for tenant ["+tenant+"]", http.StatusForbidden)
+					return
+				}
+				tenant = boundTenant
+			}
+		}
+		if tenant == "" {
+			tenant = domain_model.DefaultTenant
+		}
+
+		next.ServeHTTP(w, r.WithContext(domain_model.ContextWithTenant(r.Context(), tenant)))
+	})
+}
@@ -1,36 +1,292 @@
 package config
 
-import "fmt"
+import (
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
 
 type ApiConfig struct {
-	AppConfig AppConfig
-	DbConfig  DbConfig
-	Host      string `env:"API_HOST, required"`
-	Port      string `env:"API_PORT, required"`
+	AppConfig  AppConfig
+	DbConfig   DbConfig
+	AuthConfig AuthConfig
+	Host       string `env:"API_HOST, required"`
+	Port       string `env:"API_PORT, required"`
+
+	ReadTimeout       time.Duration `env:"API_READ_TIMEOUT, default=15s"`
+	ReadHeaderTimeout time.Duration `env:"API_READ_HEADER_TIMEOUT, default=5s"`
+	WriteTimeout      time.Duration `env:"API_WRITE_TIMEOUT, default=30s"`
+	IdleTimeout       time.Duration `env:"API_IDLE_TIMEOUT, default=120s"`
+	MaxHeaderBytes    int           `env:"API_MAX_HEADER_BYTES, default=1048576"`
+
+	// HTTPS is served when both files are set
+	TLSCertFile string `env:"API_TLS_CERT_FILE"`
+	TLSKeyFile  string `env:"API_TLS_KEY_FILE"`
+
+	// Time to keep serving after reporting not-ready, so load balancers stop routing before the listener closes
+	ShutdownDelay time.Duration `env:"API_SHUTDOWN_DELAY, default=0s"`
+	// Maximum time to wait for in-flight requests and background workers when shutting down
+	ShutdownTimeout time.Duration `env:"API_SHUTDOWN_TIMEOUT, default=30s"`
 }
 
 type AppConfig struct {
-	Name     string `env:"APP_NAME, required"`
-	Env      string `env:"APP_ENV, required"`
-	LogLevel string `env:"APP_LOG_LEVEL, default=info"`
+	Name               string        `env:"APP_NAME, required"`
+	Env                string        `env:"APP_ENV, required"`
+	LogLevel           string        `env:"APP_LOG_LEVEL, default=info"`
+	HealthCheckTimeout time.Duration `env:"APP_HEALTH_CHECK_TIMEOUT, default=2s"`
+
+	// Order calculations allowed per client and second, and how many can be sent at once on top of it
+	RateLimitRequestsPerSecond float64 `env:"APP_RATE_LIMIT_RPS, default=10"`
+	RateLimitBurst             int     `env:"APP_RATE_LIMIT_BURST, default=20"`
+	// Order calculations running at once across all clients, the rest wait for a slot up to the queue timeout
+	MaxConcurrentCalculations int           `env:"APP_MAX_CONCURRENT_CALCULATIONS, default=8"`
+	CalculationQueueTimeout   time.Duration `env:"APP_CALCULATION_QUEUE_TIMEOUT, default=5s"`
+	// Memory kept for the solved grids of the pack sets in use, 0 disables the cache
+	SolverCacheMemoryMb int `env:"APP_SOLVER_CACHE_MEMORY_MB, default=256"`
+
+	// Weight of each item, and the carrier limits packs and shipments must keep to, 0 meaning unknown or no limit
+	ItemWeightGrams       int `env:"APP_ITEM_WEIGHT_GRAMS, default=0"`
+	MaxParcelWeightGrams  int `env:"APP_MAX_PARCEL_WEIGHT_GRAMS, default=0"`
+	MaxParcelsPerShipment int `env:"APP_MAX_PARCELS_PER_SHIPMENT, default=0"`
+	MaxItemsPerShipment   int `env:"APP_MAX_ITEMS_PER_SHIPMENT, default=0"`
+	// Spread the packs evenly across the shipments instead of filling each one before the next
+	BalanceShipments bool `env:"APP_BALANCE_SHIPMENTS, default=false"`
+
+	// ISO 4217 code of the currency pack prices are set in
+	Currency string `env:"APP_CURRENCY, default=EUR"`
+
+	// Time a quote can be accepted for, and how often the expired ones are purged
+	QuoteTtl           time.Duration `env:"APP_QUOTE_TTL, default=24h"`
+	QuotePurgeInterval time.Duration `env:"APP_QUOTE_PURGE_INTERVAL, default=1h"`
+
+	// Pack profile used by the requests naming none
+	DefaultPackProfile string `env:"APP_DEFAULT_PACK_PROFILE, default=default"`
 }
 
 type DbConfig struct {
 	Host    string `env:"DB_HOST, required"`
 	Port    string `env:"DB_PORT, required"`
 	User    string `env:"DB_USER, required"`
-	Pass    string `env:"DB_PASS, required"`
+	Pass    string `env:"DB_PASS, required" secret:"true"`
 	DbName  string `env:"DB_NAME, required"`
 	SslMode string `env:"DB_SSLMODE, required"`
+
+	// Certificates used by the verify-ca and verify-full SSL modes, and for client certificate authentication
+	SslRootCert string `env:"DB_SSLROOTCERT"`
+	SslCert     string `env:"DB_SSLCERT"`
+	SslKey      string `env:"DB_SSLKEY"`
+
+	MaxOpenConns    int           `env:"DB_MAX_OPEN_CONNS, default=25"`
+	MaxIdleConns    int           `env:"DB_MAX_IDLE_CONNS, default=25"`
+	ConnMaxLifetime time.Duration `env:"DB_CONN_MAX_LIFETIME, default=5m"`
+	ConnMaxIdleTime time.Duration `env:"DB_CONN_MAX_IDLE_TIME, default=5m"`
+}
+
+type AuthConfig struct {
+	// API key stored as admin on startup, so the first API keys can be created through the API
+	BootstrapAdminKey string `env:"AUTH_BOOTSTRAP_ADMIN_KEY" secret:"true"`
+	// Secret of the HS256 JWTs, usually read from AUTH_JWT_HS256_SECRET_FILE
+	JwtHmacSecret string `env:"AUTH_JWT_HS256_SECRET" secret:"true"`
+	// PEM public key of the RS256 JWTs, usually read from AUTH_JWT_RS256_PUBLIC_KEY_FILE
+	JwtRsaPublicKey string `env:"AUTH_JWT_RS256_PUBLIC_KEY"`
+	JwtIssuer       string `env:"AUTH_JWT_ISSUER"`
+	JwtAudience     string `env:"AUTH_JWT_AUDIENCE"`
 }
 
+// The SSL modes lib/pq connects with, it rejects allow and prefer
+var sslModes = []string{"disable", "require", "verify-ca", "verify-full"}
+
 func (ac ApiConfig) RetrieveApiAddress() string {
 	return fmt.Sprintf("%v:%v", ac.Host, ac.Port)
 }
 
+func (ac ApiConfig) TLSEnabled() bool {
+	return ac.TLSCertFile != "" && ac.TLSKeyFile != ""
+}
+
+// Validate the server settings, returning every problem found at once
+func (ac ApiConfig) Validate() error {
+	var errs []error
+	if port, portErr := strconv.Atoi(ac.Port); portErr != nil || port < 1 || port > 65535 {
+		errs = append(errs, fmt.Errorf("API_PORT [%v] must be a number between 1 and 65535", ac.Port))
+	}
+	for name, timeout := range map[string]time.Duration{
+		"API_READ_TIMEOUT":        ac.ReadTimeout,
+		"API_READ_HEADER_TIMEOUT": ac.ReadHeaderTimeout,
+		"API_WRITE_TIMEOUT":       ac.WriteTimeout,
+		"API_IDLE_TIMEOUT":        ac.IdleTimeout,
+		"API_SHUTDOWN_TIMEOUT":    ac.ShutdownTimeout,
+	} {
+		if timeout <= 0 {
+			errs = append(errs, fmt.Errorf("%v [%v] must be greater than 0", name, timeout))
+		}
+	}
+	if ac.MaxHeaderBytes <= 0 {
+		errs = append(errs, fmt.Errorf("API_MAX_HEADER_BYTES [%v] must be greater than 0", ac.MaxHeaderBytes))
+	}
+	if (ac.TLSCertFile == "") != (ac.TLSKeyFile == "") {
+		errs = append(errs, errors.New("API_TLS_CERT_FILE and API_TLS_KEY_FILE must be set together"))
+	}
+	errs = append(errs, validateReadableFile("API_TLS_CERT_FILE", ac.TLSCertFile))
+	errs = append(errs, validateReadableFile("API_TLS_KEY_FILE", ac.TLSKeyFile))
+	errs = append(errs, ac.AuthConfig.validate())
+	errs = append(errs, ac.AppConfig.validate())
+	return errors.Join(errs...)
+}
+
+func (ac AppConfig) validate() error {
+	var errs []error
+	if ac.HealthCheckTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("APP_HEALTH_CHECK_TIMEOUT [%v] must be greater than 0", ac.HealthCheckTimeout))
+	}
+	if ac.RateLimitRequestsPerSecond <= 0 {
+		errs = append(errs, fmt.Errorf("APP_RATE_LIMIT_RPS [%v] must be greater than 0", ac.RateLimitRequestsPerSecond))
+	}
+	if ac.RateLimitBurst < 1 {
+		errs = append(errs, fmt.Errorf("APP_RATE_LIMIT_BURST [%v] must be at least 1", ac.RateLimitBurst))
+	}
+	if ac.MaxConcurrentCalculations < 1 {
+		errs = append(errs, fmt.Errorf("APP_MAX_CONCURRENT_CALCULATIONS [%v] must be at least 1", ac.MaxConcurrentCalculations))
+	}
+	if ac.CalculationQueueTimeout < 0 {
+		errs = append(errs, fmt.Errorf("APP_CALCULATION_QUEUE_TIMEOUT [%v] must not be negative", ac.CalculationQueueTimeout))
+	}
+	if ac.SolverCacheMemoryMb < 0 {
+		errs = append(errs, fmt.Errorf("APP_SOLVER_CACHE_MEMORY_MB [%v] must not be negative", ac.SolverCacheMemoryMb))
+	}
+	if ac.ItemWeightGrams < 0 {
+		errs = append(errs, fmt.Errorf("APP_ITEM_WEIGHT_GRAMS [%v] must not be negative", ac.ItemWeightGrams))
+	}
+	if ac.MaxParcelWeightGrams < 0 {
+		errs = append(errs, fmt.Errorf("APP_MAX_PARCEL_WEIGHT_GRAMS [%v] must not be negative", ac.MaxParcelWeightGrams))
+	}
+	if ac.MaxParcelWeightGrams > 0 && ac.ItemWeightGrams == 0 {
+		errs = append(errs, errors.New("APP_MAX_PARCEL_WEIGHT_GRAMS needs APP_ITEM_WEIGHT_GRAMS to weigh the parcels"))
+	}
+	if ac.MaxParcelsPerShipment < 0 {
+		errs = append(errs, fmt.Errorf("APP_MAX_PARCELS_PER_SHIPMENT [%v] must not be negative", ac.MaxParcelsPerShipment))
+	}
+	if ac.MaxItemsPerShipment < 0 {
+		errs = append(errs, fmt.Errorf("APP_MAX_ITEMS_PER_SHIPMENT [%v] must not be negative", ac.MaxItemsPerShipment))
+	}
+	if !isCurrencyCode(ac.Currency) {
+		errs = append(errs, fmt.Errorf("APP_CURRENCY [%v] must be a three letter uppercase currency code", ac.Currency))
+	}
+	if ac.QuoteTtl <= 0 {
+		errs = append(errs, fmt.Errorf("APP_QUOTE_TTL [%v] must be greater than 0", ac.QuoteTtl))
+	}
+	if ac.QuotePurgeInterval <= 0 {
+		errs = append(errs, fmt.Errorf("APP_QUOTE_PURGE_INTERVAL [%v] must be greater than 0", ac.QuotePurgeInterval))
+	}
+	if ac.DefaultPackProfile == "" {
+		errs = append(errs, errors.New("APP_DEFAULT_PACK_PROFILE must not be empty"))
+	}
+	return errors.Join(errs...)
+}
+
+func (ac AuthConfig) validate() error {
+	var errs []error
+	if ac.BootstrapAdminKey != "" && len(ac.BootstrapAdminKey) < 32 {
+		errs = append(errs, errors.New("AUTH_BOOTSTRAP_ADMIN_KEY must be at least 32 characters long"))
+	}
+	if ac.JwtHmacSecret != "" && len(ac.JwtHmacSecret) < 32 {
+		errs = append(errs, errors.New("AUTH_JWT_HS256_SECRET must be at least 32 bytes long"))
+	}
+	if ac.JwtRsaPublicKey != "" {
+		if _, parseErr := ac.ParseJwtRsaPublicKey(); parseErr != nil {
+			errs = append(errs, fmt.Errorf("AUTH_JWT_RS256_PUBLIC_KEY is not a valid PEM RSA public key: %w", parseErr))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (ac AuthConfig) ParseJwtRsaPublicKey() (*rsa.PublicKey, error) {
+	if ac.JwtRsaPublicKey == "" {
+		return nil, nil
+	}
+	return jwt.ParseRSAPublicKeyFromPEM([]byte(ac.JwtRsaPublicKey))
+}
+
+// Validate the DB settings, returning every problem found at once
+func (dc DbConfig) Validate() error {
+	var errs []error
+	if !slices.Contains(sslModes, dc.SslMode) {
+		errs = append(errs, fmt.Errorf("DB_SSLMODE [%v] must be one of [%v]", dc.SslMode, strings.Join(sslModes, ", ")))
+	}
+	if (dc.SslMode == "verify-ca" || dc.SslMode == "verify-full") && dc.SslRootCert == "" {
+		errs = append(errs, fmt.Errorf("DB_SSLROOTCERT is required when DB_SSLMODE is [%v]", dc.SslMode))
+	}
+	if (dc.SslCert == "") != (dc.SslKey == "") {
+		errs = append(errs, errors.New("DB_SSLCERT and DB_SSLKEY must be set together"))
+	}
+	errs = append(errs, validateReadableFile("DB_SSLROOTCERT", dc.SslRootCert))
+	errs = append(errs, validateReadableFile("DB_SSLCERT", dc.SslCert))
+	errs = append(errs, validateReadableFile("DB_SSLKEY", dc.SslKey))
+	if dc.MaxOpenConns < 0 {
+		errs = append(errs, fmt.Errorf("DB_MAX_OPEN_CONNS [%v] must not be negative, use 0 for unlimited", dc.MaxOpenConns))
+	}
+	if dc.MaxIdleConns < 0 {
+		errs = append(errs, fmt.Errorf("DB_MAX_IDLE_CONNS [%v] must not be negative", dc.MaxIdleConns))
+	}
+	if dc.MaxOpenConns > 0 && dc.MaxIdleConns > dc.MaxOpenConns {
+		errs = append(errs, fmt.Errorf("DB_MAX_IDLE_CONNS [%v] must not exceed DB_MAX_OPEN_CONNS [%v]", dc.MaxIdleConns, dc.MaxOpenConns))
+	}
+	if dc.ConnMaxLifetime < 0 || dc.ConnMaxIdleTime < 0 {
+		errs = append(errs, errors.New("DB_CONN_MAX_LIFETIME and DB_CONN_MAX_IDLE_TIME must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
 func (dc DbConfig) RetrieveDBConnectionString() string {
-	return fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		dc.Host, dc.Port, dc.User, dc.Pass, dc.DbName,
-	)
+	params := []string{
+		"host=" + quoteConnectionValue(dc.Host),
+		"port=" + quoteConnectionValue(dc.Port),
+		"user=" + quoteConnectionValue(dc.User),
+		"password=" + quoteConnectionValue(dc.Pass),
+		"dbname=" + quoteConnectionValue(dc.DbName),
+		"sslmode=" + quoteConnectionValue(dc.SslMode),
+	}
+	if dc.SslRootCert != "" {
+		params = append(params, "sslrootcert="+quoteConnectionValue(dc.SslRootCert))
+	}
+	if dc.SslCert != "" {
+		params = append(params, "sslcert="+quoteConnectionValue(dc.SslCert), "sslkey="+quoteConnectionValue(dc.SslKey))
+	}
+	return strings.Join(params, " ")
+}
+
+// Values in a libpq connection string are single quoted, with backslashes and quotes escaped
+func quoteConnectionValue(value string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value)
+	return "'" + escaped + "'"
+}
+
+func validateReadableFile(name string, path string) error {
+	if path == "" {
+		return nil
+	}
+	file, openErr := os.Open(path)
+	if openErr != nil {
+		return fmt.Errorf("%v [%v] is not readable: %w", name, path, openErr)
+	}
+	return file.Close()
+}
+
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, letter := range code {
+		if letter < 'A' || letter > 'Z' {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,208 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func validApiConfig() config.ApiConfig {
+	return config.ApiConfig{
+		AppConfig: config.AppConfig{
+			Name:                       "api",
+			Env:                        "test",
+			HealthCheckTimeout:         time.Second,
+			RateLimitRequestsPerSecond: 10,
+			RateLimitBurst:             20,
+			MaxConcurrentCalculations:  8,
+			CalculationQueueTimeout:    time.Second,
+			SolverCacheMemoryMb:        256,
+			Currency:                   "EUR",
+			QuoteTtl:                   24 * time.Hour,
+			QuotePurgeInterval:         time.Hour,
+			DefaultPackProfile:         "default",
+		},
+		Host:              "0.0.0.0",
+		Port:              "8000",
+		ReadTimeout:       time.Second,
+		ReadHeaderTimeout: time.Second,
+		WriteTimeout:      time.Second,
+		IdleTimeout:       time.Second,
+		MaxHeaderBytes:    1 << 20,
+		ShutdownTimeout:   time.Second,
+	}
+}
+
+func validDbConfig() config.DbConfig {
+	return config.DbConfig{
+		Host:         "db",
+		Port:         "5432",
+		User:         "postgres",
+		Pass:         "password",
+		DbName:       "pack_calculator",
+		SslMode:      "disable",
+		MaxOpenConns: 10,
+		MaxIdleConns: 5,
+	}
+}
+
+func Test_ApiConfigValidate(t *testing.T) {
+	t.Run("Valid config", func(t *testing.T) {
+		require.NoError(t, validApiConfig().Validate())
+	})
+
+	t.Run("Invalid port and timeouts", func(t *testing.T) {
+		// Arrange
+		apiConfig := validApiConfig()
+		apiConfig.Port = "http"
+		apiConfig.WriteTimeout = 0
+
+		// Act
+		validationErr := apiConfig.Validate()
+
+		// Assert
+		require.ErrorContains(t, validationErr, "API_PORT")
+		require.ErrorContains(t, validationErr, "API_WRITE_TIMEOUT")
+	})
+
+	t.Run("Parcel weight limit without item weight", func(t *testing.T) {
+		// Arrange
+		apiConfig := validApiConfig()
+		apiConfig.AppConfig.MaxParcelWeightGrams = 20000
+		apiConfig.AppConfig.MaxParcelsPerShipment = -1
+		apiConfig.AppConfig.MaxItemsPerShipment = -1
+
+		// Act
+		validationErr := apiConfig.Validate()
+
+		// Assert
+		require.ErrorContains(t, validationErr, "needs APP_ITEM_WEIGHT_GRAMS")
+		require.ErrorContains(t, validationErr, "APP_MAX_PARCELS_PER_SHIPMENT")
+		require.ErrorContains(t, validationErr, "APP_MAX_ITEMS_PER_SHIPMENT")
+	})
+
+	t.Run("Negative solver cache memory", func(t *testing.T) {
+		// Arrange
+		apiConfig := validApiConfig()
+		apiConfig.AppConfig.SolverCacheMemoryMb = -1
+
+		// Act
+		validationErr := apiConfig.Validate()
+
+		// Assert
+		require.ErrorContains(t, validationErr, "APP_SOLVER_CACHE_MEMORY_MB")
+	})
+
+	t.Run("Invalid currency", func(t *testing.T) {
+		// Arrange
+		apiConfig := validApiConfig()
+		apiConfig.AppConfig.Currency = "eur"
+
+		// Act
+		validationErr := apiConfig.Validate()
+
+		// Assert
+		require.ErrorContains(t, validationErr, "APP_CURRENCY")
+	})
+
+	t.Run("Quotes without expiry", func(t *testing.T) {
+		// Arrange
+		apiConfig := validApiConfig()
+		apiConfig.AppConfig.QuoteTtl = 0
+		apiConfig.AppConfig.QuotePurgeInterval = -time.Minute
+
+		// Act
+		validationErr := apiConfig.Validate()
+
+		// Assert
+		require.ErrorContains(t, validationErr, "APP_QUOTE_TTL")
+		require.ErrorContains(t, validationErr, "APP_QUOTE_PURGE_INTERVAL")
+	})
+
+	t.Run("Empty default pack profile", func(t *testing.T) {
+		// Arrange
+		apiConfig := validApiConfig()
+		apiConfig.AppConfig.DefaultPackProfile = ""
+
+		// Act
+		validationErr := apiConfig.Validate()
+
+		// Assert
+		require.ErrorContains(t, validationErr, "APP_DEFAULT_PACK_PROFILE")
+	})
+
+	t.Run("TLS cert without key", func(t *testing.T) {
+		// Arrange
+		apiConfig := validApiConfig()
+		apiConfig.TLSCertFile = "/does/not/exist.pem"
+
+		// Act
+		validationErr := apiConfig.Validate()
+
+		// Assert
+		require.ErrorContains(t, validationErr, "must be set together")
+		require.ErrorContains(t, validationErr, "is not readable")
+		require.False(t, apiConfig.TLSEnabled())
+	})
+}
+
+func Test_DbConfigValidate(t *testing.T) {
+	t.Run("Valid config", func(t *testing.T) {
+		require.NoError(t, validDbConfig().Validate())
+	})
+
+	t.Run("Unknown SSL mode", func(t *testing.T) {
+		// Arrange
+		dbConfig := validDbConfig()
+		dbConfig.SslMode = "enabled"
+
+		// Act & Assert
+		require.ErrorContains(t, dbConfig.Validate(), "DB_SSLMODE")
+	})
+
+	t.Run("SSL mode lib/pq does not support", func(t *testing.T) {
+		// Arrange
+		dbConfig := validDbConfig()
+		dbConfig.SslMode = "prefer"
+
+		// Act & Assert
+		require.ErrorContains(t, dbConfig.Validate(), "must be one of [disable, require, verify-ca, verify-full]")
+	})
+
+	t.Run("Verify mode without root certificate", func(t *testing.T) {
+		// Arrange
+		dbConfig := validDbConfig()
+		dbConfig.SslMode = "verify-full"
+
+		// Act & Assert
+		require.ErrorContains(t, dbConfig.Validate(), "DB_SSLROOTCERT is required")
+	})
+
+	t.Run("More idle than open connections", func(t *testing.T) {
+		// Arrange
+		dbConfig := validDbConfig()
+		dbConfig.MaxIdleConns = 20
+
+		// Act & Assert
+		require.ErrorContains(t, dbConfig.Validate(), "DB_MAX_IDLE_CONNS")
+	})
+}
+
+func Test_RetrieveDBConnectionString(t *testing.T) {
+	// Arrange
+	dbConfig := validDbConfig()
+	dbConfig.Pass = `p@ss word'\`
+	dbConfig.SslMode = "verify-full"
+	dbConfig.SslRootCert = "/certs/root.crt"
+
+	// Act
+	connectionString := dbConfig.RetrieveDBConnectionString()
+
+	// Assert
+	require.Contains(t, connectionString, `password='p@ss word\'\\'`)
+	require.Contains(t, connectionString, "sslmode='verify-full'")
+	require.Contains(t, connectionString, "sslrootcert='/certs/root.crt'")
+	require.NotContains(t, connectionString, "sslcert=")
+}
@@ -0,0 +1,216 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/sethvargo/go-envconfig"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// Suffix of the keys whose value is the path of a file holding the actual value, e.g. DB_PASS_FILE
+	fileKeySuffix = "_FILE"
+	// Key, flag or env variable pointing to the optional YAML config file
+	configFileKey = "CONFIG_FILE"
+	redactedValue = "********"
+)
+
+// Load the config from, in increasing order of precedence: the defaults in the struct tags, the YAML config file,
+// the environment and the command line flags. Every key also accepts a <KEY>_FILE variant holding the path of a
+// file with the value, which is how Docker and Kubernetes secrets are mounted.
+func Load(ctx context.Context, args []string) (ApiConfig, error) {
+	apiConfig := ApiConfig{}
+
+	flagLayer, flagErr := parseFlags(args)
+	if flagErr != nil {
+		return apiConfig, flagErr
+	}
+	envLayer := environmentLayer()
+
+	fileLayer := map[string]string{}
+	if configFile := lookupFirst(configFileKey, flagLayer, envLayer); configFile != "" {
+		var fileErr error
+		if fileLayer, fileErr = readConfigFile(configFile); fileErr != nil {
+			return apiConfig, fileErr
+		}
+	}
+
+	for _, layer := range []map[string]string{flagLayer, envLayer, fileLayer} {
+		if secretErr := resolveFileKeys(layer); secretErr != nil {
+			return apiConfig, secretErr
+		}
+	}
+
+	lookuper := envconfig.MultiLookuper(
+		envconfig.MapLookuper(flagLayer),
+		envconfig.MapLookuper(envLayer),
+		envconfig.MapLookuper(fileLayer),
+	)
+	if processErr := envconfig.ProcessWith(ctx, &envconfig.Config{Target: &apiConfig, Lookuper: lookuper}); processErr != nil {
+		return apiConfig, processErr
+	}
+	return apiConfig, nil
+}
+
+// Print the effective config as YAML, one key per line, with the values of secret fields redacted.
+// The output can be used as config file.
+func Print(apiConfig ApiConfig) string {
+	fields := collectFields(reflect.ValueOf(apiConfig))
+	sort.Slice(fields, func(i, j int) bool { return fields[i].key < fields[j].key })
+
+	var builder strings.Builder
+	for _, field := range fields {
+		value := fmt.Sprint(field.value.Interface())
+		if field.secret && value != "" {
+			value = redactedValue
+		}
+		quotedValue, _ := yaml.Marshal(value)
+		builder.WriteString(fmt.Sprintf("%v: %s", field.key, quotedValue))
+	}
+	return builder.String()
+}
+
+type configField struct {
+	key    string
+	value  reflect.Value
+	secret bool
+}
+
+// Walk the config structs collecting the fields tagged with an env key. Fields tagged with secret:"true" are redacted
+// when printed.
+func collectFields(value reflect.Value) []configField {
+	var fields []configField
+	for i := 0; i < value.NumField(); i++ {
+		structField := value.Type().Field(i)
+		envTag, tagged := structField.Tag.Lookup("env")
+		if !tagged {
+			if structField.Type.Kind() == reflect.Struct {
+				fields = append(fields, collectFields(value.Field(i))...)
+			}
+			continue
+		}
+		key, _, _ := strings.Cut(envTag, ",")
+		fields = append(fields, configField{
+			key:    strings.TrimSpace(key),
+			value:  value.Field(i),
+			secret: structField.Tag.Get("secret") == "true",
+		})
+	}
+	return fields
+}
+
+func knownKeys() map[string]bool {
+	keys := map[string]bool{configFileKey: true}
+	for _, field := range collectFields(reflect.ValueOf(ApiConfig{})) {
+		keys[field.key] = true
+		keys[field.key+fileKeySuffix] = true
+	}
+	return keys
+}
+
+// Flags are the keys in lower case with dashes, e.g. --api-port=8000 or --db-pass-file /run/secrets/db_pass
+func parseFlags(args []string) (map[string]string, error) {
+	keys := knownKeys()
+	layer := map[string]string{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "--") {
+			return nil, fmt.Errorf("unexpected argument [%v], flags must look like --api-port=8000", arg)
+		}
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+		if !hasValue {
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("flag [%v] is missing a value", arg)
+			}
+			i++
+			value = args[i]
+		}
+		key := strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+		if !keys[key] {
+			return nil, fmt.Errorf("unknown flag [--%v]", name)
+		}
+		layer[key] = value
+	}
+	return layer, nil
+}
+
+func environmentLayer() map[string]string {
+	layer := map[string]string{}
+	for _, entry := range os.Environ() {
+		if key, value, found := strings.Cut(entry, "="); found {
+			layer[key] = value
+		}
+	}
+	return layer
+}
+
+// The YAML file may use the keys as they are (DB_HOST: db) or nested in lower case (db: {host: db}).
+func readConfigFile(path string) (map[string]string, error) {
+	content, readErr := os.ReadFile(path)
+	if readErr != nil {
+		return nil, fmt.Errorf("could not read config file [%v]: %w", path, readErr)
+	}
+	var document map[string]any
+	if parseErr := yaml.Unmarshal(content, &document); parseErr != nil {
+		return nil, fmt.Errorf("could not parse config file [%v]: %w", path, parseErr)
+	}
+
+	layer := map[string]string{}
+	flattenDocument("", document, layer)
+
+	keys := knownKeys()
+	for key := range layer {
+		if !keys[key] {
+			return nil, fmt.Errorf("unknown key [%v] in config file [%v]", key, path)
+		}
+	}
+	return layer, nil
+}
+
+func flattenDocument(prefix string, document map[string]any, layer map[string]string) {
+	for name, value := range document {
+		key := strings.ToUpper(name)
+		if prefix != "" {
+			key = prefix + "_" + key
+		}
+		if nested, isMap := value.(map[string]any); isMap {
+			flattenDocument(key, nested, layer)
+			continue
+		}
+		layer[key] = fmt.Sprint(value)
+	}
+}
+
+// Replace every <KEY>_FILE entry of the layer by <KEY> holding the file content, unless <KEY> is set in the same layer
+func resolveFileKeys(layer map[string]string) error {
+	keys := knownKeys()
+	for fileKey, path := range layer {
+		key, isFileKey := strings.CutSuffix(fileKey, fileKeySuffix)
+		if !isFileKey || !keys[key] {
+			continue
+		}
+		if _, alreadySet := layer[key]; alreadySet {
+			continue
+		}
+		content, readErr := os.ReadFile(path)
+		if readErr != nil {
+			return fmt.Errorf("could not read [%v] from [%v]: %w", key, path, readErr)
+		}
+		layer[key] = strings.TrimRight(string(content), "\r\n")
+	}
+	return nil
+}
+
+func lookupFirst(key string, layers ...map[string]string) string {
+	for _, layer := range layers {
+		if value, found := layer[key]; found {
+			return value
+		}
+	}
+	return ""
+}
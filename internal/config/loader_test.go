@@ -0,0 +1,81 @@
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func setRequiredEnv(t *testing.T) {
+	for key, value := range map[string]string{
+		"API_HOST": "0.0.0.0", "API_PORT": "8000", "APP_NAME": "api", "APP_ENV": "test",
+		"DB_HOST": "db", "DB_PORT": "5432", "DB_USER": "postgres", "DB_NAME": "pack_calculator", "DB_SSLMODE": "disable",
+	} {
+		t.Setenv(key, value)
+	}
+}
+
+func Test_Load_Precedence(t *testing.T) {
+	// Arrange
+	setRequiredEnv(t)
+	t.Setenv("DB_PASS", "from-env")
+	t.Setenv("API_PORT", "8001")
+	configFile := writeFile(t, "config.yaml", "api:\n  port: 8002\n  read_timeout: 1m\nDB_HOST: file-db\n")
+	t.Setenv("CONFIG_FILE", configFile)
+
+	// Act
+	apiConfig, loadErr := config.Load(context.Background(), []string{"--db-host", "flag-db"})
+
+	// Assert
+	require.NoError(t, loadErr)
+	require.Equal(t, "8001", apiConfig.Port, "env overrides file")
+	require.Equal(t, "flag-db", apiConfig.DbConfig.Host, "flags override file")
+	require.Equal(t, "1m0s", apiConfig.ReadTimeout.String(), "file overrides defaults")
+	require.Equal(t, "5s", apiConfig.ReadHeaderTimeout.String(), "defaults apply when unset")
+	require.Equal(t, "from-env", apiConfig.DbConfig.Pass)
+}
+
+func Test_Load_SecretFiles(t *testing.T) {
+	// Arrange
+	setRequiredEnv(t)
+	t.Setenv("DB_PASS_FILE", writeFile(t, "db_pass", "s3cret\n"))
+
+	// Act
+	apiConfig, loadErr := config.Load(context.Background(), nil)
+
+	// Assert
+	require.NoError(t, loadErr)
+	require.Equal(t, "s3cret", apiConfig.DbConfig.Pass)
+	require.Contains(t, config.Print(apiConfig), "DB_PASS: '********'")
+	require.NotContains(t, config.Print(apiConfig), "s3cret")
+}
+
+func Test_Load_Errors(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("DB_PASS", "password")
+
+	t.Run("Unknown flag", func(t *testing.T) {
+		_, loadErr := config.Load(context.Background(), []string{"--db-password=x"})
+		require.ErrorContains(t, loadErr, "unknown flag")
+	})
+
+	t.Run("Unknown key in config file", func(t *testing.T) {
+		_, loadErr := config.Load(context.Background(), []string{"--config-file", writeFile(t, "config.yaml", "db:\n  password: x\n")})
+		require.ErrorContains(t, loadErr, "unknown key [DB_PASSWORD]")
+	})
+
+	t.Run("Missing secret file", func(t *testing.T) {
+		_, loadErr := config.Load(context.Background(), []string{"--db-pass-file", "/does/not/exist"})
+		require.Error(t, loadErr)
+	})
+}
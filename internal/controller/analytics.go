@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+)
+
+const defaultQuantityBucketSize = 100
+
+// Dependency injection using optional pattern
+type AnalyticsControllerDeps func(controller *analyticsController)
+
+func WithAnalyticsMediator(mediator mediator.AnalyticsMediator) AnalyticsControllerDeps {
+	return func(controller *analyticsController) {
+		controller.analyticsMediator = mediator
+	}
+}
+
+type AnalyticsController interface {
+	RetrieveOrderSummary(w http.ResponseWriter, r *http.Request)
+	RetrievePackUsage(w http.ResponseWriter, r *http.Request)
+	RetrieveQuantityHistogram(w http.ResponseWriter, r *http.Request)
+}
+
+type analyticsController struct {
+	analyticsMediator mediator.AnalyticsMediator
+}
+
+func NewHttpAnalyticsController(deps ...AnalyticsControllerDeps) AnalyticsController {
+	analyticsController := analyticsController{}
+	for _, opt := range deps {
+		opt(&analyticsController)
+	}
+	return analyticsController
+}
+
+func (ac analyticsController) RetrieveOrderSummary(w http.ResponseWriter, r *http.Request) {
+	analyticsRange, parseErr := parseAnalyticsRange(r)
+	if parseErr != nil {
+		http.Error(w, parseErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	summary, retrieveErr := ac.analyticsMediator.RetrieveOrderSummary(r.Context(), analyticsRange)
+	if retrieveErr != nil {
+		http.Error(w, retrieveErr.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJson(w, http.StatusOK, summary.ToViewModel())
+}
+
+func (ac analyticsController) RetrievePackUsage(w http.ResponseWriter, r *http.Request) {
+	analyticsRange, parseErr := parseAnalyticsRange(r)
+	if parseErr != nil {
+		http.Error(w, parseErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, retrieveErr := ac.analyticsMediator.RetrievePackUsage(r.Context(), analyticsRange)
+	if retrieveErr != nil {
+		http.Error(w, retrieveErr.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJson(w, http.StatusOK, report.ToViewModel())
+}
+
+func (ac analyticsController) RetrieveQuantityHistogram(w http.ResponseWriter, r *http.Request) {
+	analyticsRange, parseErr := parseAnalyticsRange(r)
+	if parseErr != nil {
+		http.Error(w, parseErr.Error(), http.StatusBadRequest)
+		return
+	}
+	bucketSize, bucketSizeErr := parseIntParam(r.URL.Query().Get("bucket_size"), defaultQuantityBucketSize, 1, 0)
+	if bucketSizeErr != nil {
+		http.Error(w, fmt.Sprintf("invalid bucket_size: %v", bucketSizeErr), http.StatusBadRequest)
+		return
+	}
+
+	histogram, retrieveErr := ac.analyticsMediator.RetrieveQuantityHistogram(r.Context(), analyticsRange, bucketSize)
+	if retrieveErr != nil {
+		http.Error(w, retrieveErr.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJson(w, http.StatusOK, histogram.ToViewModel())
+}
+
+// Parse the optional from and to query parameters, the range must not be empty when both are given
+func parseAnalyticsRange(r *http.Request) (domain_model.AnalyticsRange, error) {
+	var analyticsRange domain_model.AnalyticsRange
+	var parseErr error
+	if analyticsRange.From, parseErr = parseTimeParam(r.URL.Query().Get("from")); parseErr != nil {
+		return analyticsRange, fmt.Errorf("invalid from: %w", parseErr)
+	}
+	if analyticsRange.To, parseErr = parseTimeParam(r.URL.Query().Get("to")); parseErr != nil {
+		return analyticsRange, fmt.Errorf("invalid to: %w", parseErr)
+	}
+	if !analyticsRange.From.IsZero() && !analyticsRange.To.IsZero() && !analyticsRange.To.After(analyticsRange.From) {
+		return analyticsRange, fmt.Errorf("to [%v] must be after from [%v]", analyticsRange.To, analyticsRange.From)
+	}
+	return analyticsRange, nil
+}
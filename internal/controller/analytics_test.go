@@ -0,0 +1,120 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	api "github.com/felipevillarrealdaza/go-service-template/internal/api/http"
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	mediator_mocks "github.com/felipevillarrealdaza/go-service-template/internal/mediator/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Analytics(t *testing.T) {
+	// Set Up
+	analyticsMediatorMock := mediator_mocks.NewAnalyticsMediator(t)
+	router := api.NewRouter(
+		api.WithAnalyticsMediator(analyticsMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleAdmin)),
+	)
+	newRequest := func(target string) *http.Request {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/analytics/"+target, nil)
+		req.Header.Set("X-API-Key", testApiKey)
+		return req
+	}
+	analyticsRange := domain_model.AnalyticsRange{
+		From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("Order summary of a month", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		analyticsMediatorMock.
+			On("RetrieveOrderSummary", mock.Anything, analyticsRange).
+			Return(domain_model.OrderSummary{Range: analyticsRange, Orders: 2, ItemsOrdered: 800, ItemsShipped: 1000, Packs: 3}, nil).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest("orders?from=2024-01-01T00:00:00Z&to=2024-02-01T00:00:00Z"))
+
+		// Assert
+		require.Equal(t, http.StatusOK, httpRecorder.Code)
+		var response viewmodel.OrderSummaryResponse
+		require.NoError(t, json.Unmarshal(httpRecorder.Body.Bytes(), &response))
+		require.Equal(t, analyticsRange.From, *response.From)
+		require.Equal(t, 200, response.OverageItems)
+		require.Equal(t, 25.0, response.OveragePercentage)
+		require.Equal(t, 1.5, response.AveragePacksPerOrder)
+	})
+
+	t.Run("Pack usage", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		analyticsMediatorMock.
+			On("RetrievePackUsage", mock.Anything, domain_model.AnalyticsRange{}).
+			Return(domain_model.PackUsageReport{Usage: []domain_model.PackUsage{{Size: 250, Packs: 2, Orders: 1}}}, nil).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest("packs"))
+
+		// Assert
+		require.Equal(t, http.StatusOK, httpRecorder.Code)
+		var response viewmodel.PackUsageResponse
+		require.NoError(t, json.Unmarshal(httpRecorder.Body.Bytes(), &response))
+		require.Nil(t, response.From)
+		require.Equal(t, []viewmodel.PackUsage{{Size: 250, Packs: 2, Orders: 1, Items: 500, SharePercentage: 100}}, response.Packs)
+	})
+
+	t.Run("Quantity histogram", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		analyticsMediatorMock.
+			On("RetrieveQuantityHistogram", mock.Anything, domain_model.AnalyticsRange{}, 500).
+			Return(domain_model.QuantityHistogram{BucketSize: 500, Buckets: []domain_model.QuantityBucket{{From: 500, Orders: 3}}}, nil).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest("quantities?bucket_size=500"))
+
+		// Assert
+		require.Equal(t, http.StatusOK, httpRecorder.Code)
+		var response viewmodel.QuantityHistogramResponse
+		require.NoError(t, json.Unmarshal(httpRecorder.Body.Bytes(), &response))
+		require.Equal(t, []viewmodel.QuantityBucket{{From: 500, To: 1000, Orders: 3}}, response.Buckets)
+	})
+
+	t.Run("Invalid parameters", func(t *testing.T) {
+		for _, target := range []string{
+			"orders?from=yesterday",
+			"packs?from=2024-02-01T00:00:00Z&to=2024-01-01T00:00:00Z",
+			"quantities?bucket_size=0",
+		} {
+			// Arrange
+			httpRecorder := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(httpRecorder, newRequest(target))
+
+			// Assert
+			require.Equal(t, http.StatusBadRequest, httpRecorder.Code, target)
+		}
+	})
+
+	t.Run("Mediator error", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		analyticsMediatorMock.On("RetrieveOrderSummary", mock.Anything, mock.Anything).Return(domain_model.OrderSummary{}, errors.New("connection refused")).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest("orders"))
+
+		// Assert
+		require.Equal(t, http.StatusInternalServerError, httpRecorder.Code)
+	})
+}
@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// Dependency injection using optional pattern
+type ApiKeyControllerDeps func(controller *apiKeyController)
+
+func WithAuthMediator(mediator mediator.AuthMediator) ApiKeyControllerDeps {
+	return func(controller *apiKeyController) {
+		controller.authMediator = mediator
+	}
+}
+
+type ApiKeyController interface {
+	CreateApiKey(w http.ResponseWriter, r *http.Request)
+	RetrieveApiKeys(w http.ResponseWriter, r *http.Request)
+	RevokeApiKey(w http.ResponseWriter, r *http.Request)
+}
+
+type apiKeyController struct {
+	authMediator mediator.AuthMediator
+	validate     *validator.Validate
+}
+
+func NewHttpApiKeyController(deps ...ApiKeyControllerDeps) ApiKeyController {
+	apiKeyController := apiKeyController{validate: validator.New(validator.WithRequiredStructEnabled())}
+	for _, opt := range deps {
+		opt(&apiKeyController)
+	}
+	return apiKeyController
+}
+
+func (akc apiKeyController) CreateApiKey(w http.ResponseWriter, r *http.Request) {
+	// Parse request to viewmodel
+	var requestBody viewmodel.ApiKeyRequest
+	jsonErr := json.NewDecoder(r.Body).Decode(&requestBody)
+	if jsonErr != nil {
+		http.Error(w, jsonErr.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	validationErr := akc.validate.Struct(&requestBody)
+	if validationErr != nil {
+		http.Error(w, validationErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	apiKey, key, createErr := akc.authMediator.CreateApiKey(r.Context(), requestBody.Name, domain_model.Role(requestBody.Role))
+	if createErr != nil {
+		http.Error(w, createErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJson(w, http.StatusCreated, viewmodel.CreatedApiKeyResponse{ApiKeyResponse: apiKey.ToViewModel(), Key: key})
+}
+
+func (akc apiKeyController) RetrieveApiKeys(w http.ResponseWriter, r *http.Request) {
+	apiKeys, retrieveErr := akc.authMediator.RetrieveApiKeys(r.Context())
+	if retrieveErr != nil {
+		http.Error(w, retrieveErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]viewmodel.ApiKeyResponse, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		response = append(response, apiKey.ToViewModel())
+	}
+	writeJson(w, http.StatusOK, response)
+}
+
+func (akc apiKeyController) RevokeApiKey(w http.ResponseWriter, r *http.Request) {
+	apiKeyId, parseErr := uuid.Parse(mux.Vars(r)["id"])
+	if parseErr != nil {
+		http.Error(w, parseErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if revokeErr := akc.authMediator.RevokeApiKey(r.Context(), apiKeyId); revokeErr != nil {
+		if errors.Is(revokeErr, mediator.ErrApiKeyNotFound) {
+			http.Error(w, revokeErr.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, revokeErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,180 @@
+package controller_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	api "github.com/felipevillarrealdaza/go-service-template/internal/api/http"
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	mediator_mocks "github.com/felipevillarrealdaza/go-service-template/internal/mediator/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testApiKey = "test-api-key"
+
+// Auth mediator mock authenticating testApiKey with the given role
+func newAuthMediatorMock(t *testing.T, role domain_model.Role) *mediator_mocks.AuthMediator {
+	authMediatorMock := mediator_mocks.NewAuthMediator(t)
+	authMediatorMock.
+		On("Authenticate", mock.Anything, domain_model.Credentials{ApiKey: testApiKey}).
+		Return(domain_model.Principal{Subject: "test", Role: role, Method: domain_model.AuthMethodApiKey}, nil).
+		Maybe()
+	return authMediatorMock
+}
+
+func Test_Authorization(t *testing.T) {
+	// Set Up
+	packMediatorMock := mediator_mocks.NewPackMediator(t)
+	orderMediatorMock := mediator_mocks.NewOrderMediator(t)
+	authMediatorMock := newAuthMediatorMock(t, domain_model.RoleClient)
+	router := api.NewRouter(
+		api.WithPackMediator(packMediatorMock),
+		api.WithOrderMediator(orderMediatorMock),
+		api.WithAuthMediator(authMediatorMock),
+	)
+	requestBytes, _ := json.Marshal(viewmodel.PackRequest{Size: 2})
+
+	t.Run("Missing credentials", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/pack", bytes.NewBuffer(requestBytes))
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusUnauthorized, httpRecorder.Code)
+		require.NotEmpty(t, httpRecorder.Header().Get("WWW-Authenticate"))
+	})
+
+	t.Run("Invalid credentials", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/pack", bytes.NewBuffer(requestBytes))
+		req.Header.Set("Authorization", "Bearer not-a-jwt")
+		authMediatorMock.
+			On("Authenticate", mock.Anything, domain_model.Credentials{BearerToken: "not-a-jwt"}).
+			Return(domain_model.Principal{}, mediator.ErrUnauthenticated)
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusUnauthorized, httpRecorder.Code)
+	})
+
+	t.Run("Client cannot administer packs", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/pack", bytes.NewBuffer(requestBytes))
+		req.Header.Set("X-API-Key", testApiKey)
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusForbidden, httpRecorder.Code)
+		packMediatorMock.AssertExpectations(t)
+	})
+
+	t.Run("Client can calculate orders", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		orderBytes, _ := json.Marshal(viewmodel.OrderRequest{OrderQuantity: 10})
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/order", bytes.NewBuffer(orderBytes))
+		req.Header.Set("X-API-Key", testApiKey)
+		orderMediatorMock.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
+		orderMediatorMock.On("CalculateOrderPacks", mock.Anything, mock.Anything).Return(domain_model.OrderPacks{}, nil)
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusCreated, httpRecorder.Code)
+		orderMediatorMock.AssertExpectations(t)
+	})
+}
+
+func Test_CreateApiKey(t *testing.T) {
+	// Set Up
+	authMediatorMock := newAuthMediatorMock(t, domain_model.RoleAdmin)
+	router := api.NewRouter(api.WithAuthMediator(authMediatorMock))
+
+	t.Run("OK", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		requestBytes, _ := json.Marshal(viewmodel.ApiKeyRequest{Name: "warehouse", Role: "client"})
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/api-key", bytes.NewBuffer(requestBytes))
+		req.Header.Set("X-API-Key", testApiKey)
+		apiKey := domain_model.ApiKey{ApiKeyId: uuid.New(), Name: "warehouse", Role: domain_model.RoleClient, CreatedAt: time.Now()}
+		authMediatorMock.On("CreateApiKey", mock.Anything, "warehouse", domain_model.RoleClient).Return(apiKey, "pck_secret", nil)
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusCreated, httpRecorder.Code)
+		var response viewmodel.CreatedApiKeyResponse
+		require.NoError(t, json.Unmarshal(httpRecorder.Body.Bytes(), &response))
+		require.Equal(t, "pck_secret", response.Key)
+		require.Equal(t, apiKey.ApiKeyId, response.ApiKeyId)
+	})
+
+	t.Run("Unknown role", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		requestBytes, _ := json.Marshal(viewmodel.ApiKeyRequest{Name: "warehouse", Role: "root"})
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/api-key", bytes.NewBuffer(requestBytes))
+		req.Header.Set("X-API-Key", testApiKey)
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusBadRequest, httpRecorder.Code)
+	})
+}
+
+func Test_RevokeApiKey(t *testing.T) {
+	// Set Up
+	authMediatorMock := newAuthMediatorMock(t, domain_model.RoleAdmin)
+	router := api.NewRouter(api.WithAuthMediator(authMediatorMock))
+
+	useCases := []struct {
+		name       string
+		revokeErr  error
+		statusCode int
+	}{
+		{name: "OK", revokeErr: nil, statusCode: http.StatusNoContent},
+		{name: "Not found", revokeErr: fmt.Errorf("wrapped: %w", mediator.ErrApiKeyNotFound), statusCode: http.StatusNotFound},
+		{name: "Unknown error", revokeErr: errors.New("db is down"), statusCode: http.StatusInternalServerError},
+	}
+
+	for _, useCase := range useCases {
+		t.Run(useCase.name, func(t *testing.T) {
+			// Arrange
+			apiKeyId := uuid.New()
+			httpRecorder := httptest.NewRecorder()
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/api/v1/api-key/"+apiKeyId.String(), nil)
+			req.Header.Set("X-API-Key", testApiKey)
+			authMediatorMock.On("RevokeApiKey", mock.Anything, apiKeyId).Return(useCase.revokeErr)
+
+			// Act
+			router.ServeHTTP(httpRecorder, req)
+
+			// Assert
+			require.Equal(t, useCase.statusCode, httpRecorder.Code)
+		})
+	}
+}
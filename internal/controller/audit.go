@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/transfer"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 10000
+)
+
+// Dependency injection using optional pattern
+type AuditControllerDeps func(controller *auditController)
+
+func WithAuditMediator(mediator mediator.AuditMediator) AuditControllerDeps {
+	return func(controller *auditController) {
+		controller.auditMediator = mediator
+	}
+}
+
+type AuditController interface {
+	RetrievePackAudits(w http.ResponseWriter, r *http.Request)
+}
+
+type auditController struct {
+	auditMediator mediator.AuditMediator
+}
+
+func NewHttpAuditController(deps ...AuditControllerDeps) AuditController {
+	auditController := auditController{}
+	for _, opt := range deps {
+		opt(&auditController)
+	}
+	return auditController
+}
+
+// Retrieve the audit log of the pack set, as a JSON page or as a CSV of every matching entry when asked with format=csv
+// or an Accept of text/csv
+func (ac auditController) RetrievePackAudits(w http.ResponseWriter, r *http.Request) {
+	filter, parseErr := parsePackAuditFilter(r)
+	if parseErr != nil {
+		http.Error(w, parseErr.Error(), http.StatusBadRequest)
+		return
+	}
+	format, formatErr := responseFormat(r)
+	if formatErr != nil {
+		http.Error(w, formatErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if format == transfer.FormatCsv {
+		ac.exportPackAuditsCsv(w, r, filter)
+		return
+	}
+
+	entries, retrieveErr := ac.auditMediator.RetrievePackAudits(r.Context(), filter)
+	if retrieveErr != nil {
+		http.Error(w, retrieveErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]viewmodel.PackAuditResponse, 0, len(entries))
+	for _, entry := range entries {
+		response = append(response, entry.ToViewModel())
+	}
+	writeJson(w, http.StatusOK, response)
+}
+
+func parsePackAuditFilter(r *http.Request) (domain_model.PackAuditFilter, error) {
+	query := r.URL.Query()
+	filter := domain_model.PackAuditFilter{
+		Actor:  query.Get("actor"),
+		Action: domain_model.PackAuditAction(query.Get("action")),
+		Limit:  defaultAuditLimit,
+	}
+
+	switch filter.Action {
+	case "", domain_model.PackAuditActionAdd, domain_model.PackAuditActionRemove, domain_model.PackAuditActionSchedule:
+	default:
+		return filter, fmt.Errorf("action [%v] must be one of [add remove schedule]", filter.Action)
+	}
+
+	var parseErr error
+	if filter.PackSize, parseErr = parseIntParam(query.Get("pack_size"), 0, 1, 0); parseErr != nil {
+		return filter, fmt.Errorf("invalid pack_size: %w", parseErr)
+	}
+	if filter.Limit, parseErr = parseIntParam(query.Get("limit"), defaultAuditLimit, 1, maxAuditLimit); parseErr != nil {
+		return filter, fmt.Errorf("invalid limit: %w", parseErr)
+	}
+	if filter.Offset, parseErr = parseIntParam(query.Get("offset"), 0, 0, 0); parseErr != nil {
+		return filter, fmt.Errorf("invalid offset: %w", parseErr)
+	}
+	if filter.From, parseErr = parseTimeParam(query.Get("from")); parseErr != nil {
+		return filter, fmt.Errorf("invalid from: %w", parseErr)
+	}
+	if filter.To, parseErr = parseTimeParam(query.Get("to")); parseErr != nil {
+		return filter, fmt.Errorf("invalid to: %w", parseErr)
+	}
+	return filter, nil
+}
+
+// Parse an optional integer query parameter, a max of 0 means unbounded
+func parseIntParam(value string, fallback int, min int, max int) (int, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	parsed, parseErr := strconv.Atoi(value)
+	if parseErr != nil {
+		return 0, parseErr
+	}
+	if parsed < min || (max > 0 && parsed > max) {
+		return 0, fmt.Errorf("[%v] is out of range", parsed)
+	}
+	return parsed, nil
+}
+
+// Parse an optional RFC 3339 timestamp query parameter
+func parseTimeParam(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// Stream every entry matching the filter as CSV rows, a page at a time, so large exports are not buffered. The limit
+// only applies to JSON pages.
+func (ac auditController) exportPackAuditsCsv(w http.ResponseWriter, r *http.Request, filter domain_model.PackAuditFilter) {
+	var csvWriter *csv.Writer
+	startWriting := func() {
+		writeAttachmentHeaders(w, transfer.FormatCsv, "pack_audit")
+		csvWriter = csv.NewWriter(w)
+		csvWriter.Write([]string{"id", "actor", "action", "pack_size", "request_id", "created_at"})
+	}
+	exportErr := ac.auditMediator.ExportPackAudits(r.Context(), filter, func(entry domain_model.PackAuditEntry) error {
+		if csvWriter == nil {
+			startWriting()
+		}
+		return csvWriter.Write([]string{
+			entry.AuditId.String(),
+			entry.Actor,
+			string(entry.Action),
+			strconv.Itoa(entry.PackSize),
+			entry.RequestId,
+			entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+		})
+	})
+	if exportErr != nil && csvWriter == nil {
+		http.Error(w, exportErr.Error(), http.StatusInternalServerError)
+		return
+	}
+	if exportErr != nil {
+		panic(http.ErrAbortHandler)
+	}
+
+	if csvWriter == nil {
+		startWriting()
+	}
+	csvWriter.Flush()
+}
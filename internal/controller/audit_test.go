@@ -0,0 +1,119 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	api "github.com/felipevillarrealdaza/go-service-template/internal/api/http"
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	mediator_mocks "github.com/felipevillarrealdaza/go-service-template/internal/mediator/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RetrievePackAudits(t *testing.T) {
+	// Set Up
+	auditMediatorMock := mediator_mocks.NewAuditMediator(t)
+	router := api.NewRouter(
+		api.WithAuditMediator(auditMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleAdmin)),
+	)
+	entry := domain_model.PackAuditEntry{
+		AuditId:   uuid.New(),
+		Actor:     "api_key:ops",
+		Action:    domain_model.PackAuditActionRemove,
+		PackSize:  500,
+		RequestId: "request-1",
+		CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	t.Run("Filtered as JSON", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/audit?actor=api_key:ops&pack_size=500&to=2024-02-01T00:00:00Z&limit=10&offset=20", nil)
+		req.Header.Set("X-API-Key", testApiKey)
+		auditMediatorMock.On("RetrievePackAudits", mock.Anything, domain_model.PackAuditFilter{
+			Actor:    "api_key:ops",
+			PackSize: 500,
+			To:       time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			Limit:    10,
+			Offset:   20,
+		}).Return([]domain_model.PackAuditEntry{entry}, nil)
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusOK, httpRecorder.Code)
+		var response []viewmodel.PackAuditResponse
+		require.NoError(t, json.Unmarshal(httpRecorder.Body.Bytes(), &response))
+		require.Equal(t, []viewmodel.PackAuditResponse{entry.ToViewModel()}, response)
+
+		// Clean up
+		auditMediatorMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Exported as CSV", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/audit?format=csv", nil)
+		req.Header.Set("X-API-Key", testApiKey)
+		auditMediatorMock.
+			On("ExportPackAudits", mock.Anything, domain_model.PackAuditFilter{Limit: 100}, mock.Anything).
+			Return(func(ctx context.Context, filter domain_model.PackAuditFilter, fn func(domain_model.PackAuditEntry) error) error {
+				return fn(entry)
+			})
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusOK, httpRecorder.Code)
+		require.Equal(t, "text/csv", httpRecorder.Header().Get("Content-Type"))
+		records, csvErr := csv.NewReader(httpRecorder.Body).ReadAll()
+		require.NoError(t, csvErr)
+		require.Equal(t, [][]string{
+			{"id", "actor", "action", "pack_size", "request_id", "created_at"},
+			{entry.AuditId.String(), "api_key:ops", "remove", "500", "request-1", "2024-01-02T03:04:05Z"},
+		}, records)
+
+		// Clean up
+		auditMediatorMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Invalid filters", func(t *testing.T) {
+		for _, query := range []string{"action=update", "pack_size=0", "limit=10001", "offset=-1", "from=yesterday"} {
+			// Arrange
+			httpRecorder := httptest.NewRecorder()
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/audit?"+query, nil)
+			req.Header.Set("X-API-Key", testApiKey)
+
+			// Act
+			router.ServeHTTP(httpRecorder, req)
+
+			// Assert
+			require.Equal(t, http.StatusBadRequest, httpRecorder.Code, query)
+		}
+	})
+
+	t.Run("Request id is echoed", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/audit?action=update", nil)
+		req.Header.Set("X-API-Key", testApiKey)
+		req.Header.Set("X-Request-ID", "request-2")
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, "request-2", httpRecorder.Header().Get("X-Request-ID"))
+	})
+}
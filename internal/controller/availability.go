@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/gorilla/mux"
+)
+
+// Set when the pack size in the path is used, adding it to the profile when missing
+func (pc packController) SchedulePack(w http.ResponseWriter, r *http.Request) {
+	size, sizeErr := parseIntParam(mux.Vars(r)["size"], 0, 1, 0)
+	if sizeErr != nil {
+		http.Error(w, sizeErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Parse request to viewmodel
+	var requestBody viewmodel.PackValidityRequest
+	jsonErr := json.NewDecoder(r.Body).Decode(&requestBody)
+	if jsonErr != nil {
+		http.Error(w, jsonErr.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	validity := domain_model.PackValidity{
+		PackSize:  size,
+		ValidFrom: requestBody.ValidFrom,
+		ValidTo:   requestBody.ValidTo,
+		Profile:   packProfile(r),
+	}
+	scheduled, scheduleErr := pc.packMediator.SchedulePack(r.Context(), validity)
+	if scheduleErr != nil {
+		switch {
+		case errors.Is(scheduleErr, mediator.ErrInvalidPackValidity):
+			http.Error(w, scheduleErr.Error(), http.StatusBadRequest)
+		case errors.Is(scheduleErr, mediator.ErrPackProfileNotFound):
+			http.Error(w, scheduleErr.Error(), http.StatusNotFound)
+		default:
+			http.Error(w, scheduleErr.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	writeJson(w, http.StatusOK, scheduled.ToViewModel())
+}
+
+func (pc packController) RetrievePackSchedule(w http.ResponseWriter, r *http.Request) {
+	packs, retrieveErr := pc.packMediator.RetrievePackSchedule(r.Context(), packProfile(r))
+	if retrieveErr != nil {
+		http.Error(w, retrieveErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]viewmodel.PackValidityResponse, 0, len(packs))
+	for _, pack := range packs {
+		response = append(response, pack.ToViewModel())
+	}
+	writeJson(w, http.StatusOK, response)
+}
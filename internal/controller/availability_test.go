@@ -0,0 +1,130 @@
+package controller_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	api "github.com/felipevillarrealdaza/go-service-template/internal/api/http"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	mediator_mocks "github.com/felipevillarrealdaza/go-service-template/internal/mediator/mocks"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SchedulePack(t *testing.T) {
+	// Set Up
+	packMediatorMock := mediator_mocks.NewPackMediator(t)
+	router := api.NewRouter(
+		api.WithPackMediator(packMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleAdmin)),
+	)
+	validFrom := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+	newRequest := func(body string) *http.Request {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/api/v1/pack/750/validity?profile=retail", bytes.NewBufferString(body))
+		req.Header.Set("X-API-Key", testApiKey)
+		return req
+	}
+
+	t.Run("Scheduled", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		validity := domain_model.PackValidity{PackSize: 750, ValidFrom: &validFrom, Profile: "retail"}
+		packMediatorMock.On("SchedulePack", mock.Anything, validity).Return(validity, nil).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest(`{"valid_from":"2024-12-01T00:00:00Z"}`))
+
+		// Assert
+		require.Equal(t, http.StatusOK, httpRecorder.Code)
+		require.JSONEq(t, `{"size":750,"valid_from":"2024-12-01T00:00:00Z"}`, httpRecorder.Body.String())
+	})
+
+	useCases := []struct {
+		name           string
+		scheduleErr    error
+		expectedStatus int
+	}{
+		{name: "Invalid window", scheduleErr: errors.Wrap(mediator.ErrInvalidPackValidity, "valid from must be before valid to"), expectedStatus: http.StatusBadRequest},
+		{name: "Unknown profile", scheduleErr: errors.Wrap(mediator.ErrPackProfileNotFound, "could not schedule pack"), expectedStatus: http.StatusNotFound},
+		{name: "Internal error", scheduleErr: errors.New("connection refused"), expectedStatus: http.StatusInternalServerError},
+	}
+	for _, useCase := range useCases {
+		t.Run(useCase.name, func(t *testing.T) {
+			// Arrange
+			httpRecorder := httptest.NewRecorder()
+			packMediatorMock.On("SchedulePack", mock.Anything, mock.Anything).Return(domain_model.PackValidity{}, useCase.scheduleErr).Once()
+
+			// Act
+			router.ServeHTTP(httpRecorder, newRequest(`{"valid_from":"2024-12-01T00:00:00Z"}`))
+
+			// Assert
+			require.Equal(t, useCase.expectedStatus, httpRecorder.Code)
+		})
+	}
+
+	t.Run("Malformed date", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest(`{"valid_from":"next monday"}`))
+
+		// Assert
+		require.Equal(t, http.StatusUnprocessableEntity, httpRecorder.Code)
+	})
+}
+
+func Test_RetrievePackSchedule(t *testing.T) {
+	// Set Up
+	packMediatorMock := mediator_mocks.NewPackMediator(t)
+	router := api.NewRouter(
+		api.WithPackMediator(packMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleAdmin)),
+	)
+
+	// Arrange
+	httpRecorder := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/pack/schedule", nil)
+	req.Header.Set("X-API-Key", testApiKey)
+	validTo := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	packMediatorMock.On("RetrievePackSchedule", mock.Anything, "").Return([]domain_model.PackValidity{
+		{PackSize: 750, ValidTo: &validTo},
+		{PackSize: 250},
+	}, nil).Once()
+
+	// Act
+	router.ServeHTTP(httpRecorder, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, httpRecorder.Code)
+	require.JSONEq(t, `[{"size":750,"valid_to":"2025-01-06T00:00:00Z"},{"size":250}]`, httpRecorder.Body.String())
+}
+
+func Test_AddOrder_OrderDate(t *testing.T) {
+	// Set Up
+	orderMediatorMock := mediator_mocks.NewOrderMediator(t)
+	router := api.NewRouter(
+		api.WithOrderMediator(orderMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleClient)),
+	)
+
+	// Arrange
+	httpRecorder := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/order", bytes.NewBufferString(`{"quantity":500,"order_date":"2024-11-15T00:00:00Z"}`))
+	req.Header.Set("X-API-Key", testApiKey)
+	orderDate := time.Date(2024, 11, 15, 0, 0, 0, 0, time.UTC)
+	orderMediatorMock.On("CreateOrder", mock.Anything, mock.MatchedBy(func(order domain_model.Order) bool { return order.OrderDate.Equal(orderDate) })).Return(nil).Once()
+	orderMediatorMock.On("CalculateOrderPacks", mock.Anything, mock.Anything).Return(domain_model.OrderPacks{}, nil).Once()
+
+	// Act
+	router.ServeHTTP(httpRecorder, req)
+
+	// Assert
+	require.Equal(t, http.StatusCreated, httpRecorder.Code)
+}
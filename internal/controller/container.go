@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+)
+
+// Dependency injection using optional pattern
+type ContainerControllerDeps func(controller *containerController)
+
+func WithContainerMediator(mediator mediator.ContainerMediator) ContainerControllerDeps {
+	return func(controller *containerController) {
+		controller.containerMediator = mediator
+	}
+}
+
+type ContainerController interface {
+	AddContainerType(w http.ResponseWriter, r *http.Request)
+	RetrieveContainerTypes(w http.ResponseWriter, r *http.Request)
+	RemoveContainerType(w http.ResponseWriter, r *http.Request)
+}
+
+type containerController struct {
+	containerMediator mediator.ContainerMediator
+	validate          *validator.Validate
+}
+
+func NewHttpContainerController(deps ...ContainerControllerDeps) ContainerController {
+	containerController := containerController{validate: validator.New(validator.WithRequiredStructEnabled())}
+	for _, opt := range deps {
+		opt(&containerController)
+	}
+	return containerController
+}
+
+func (cc containerController) AddContainerType(w http.ResponseWriter, r *http.Request) {
+	// Parse request to viewmodel
+	var requestBody viewmodel.ContainerTypeRequest
+	jsonErr := json.NewDecoder(r.Body).Decode(&requestBody)
+	if jsonErr != nil {
+		http.Error(w, jsonErr.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	validationErr := cc.validate.Struct(&requestBody)
+	if validationErr != nil {
+		http.Error(w, validationErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	containerType := domain_model.ContainerType{
+		Name:       requestBody.Name,
+		PackSize:   requestBody.PackSize,
+		Container:  requestBody.Container,
+		Capacities: requestBody.Capacities,
+	}
+	added, addErr := cc.containerMediator.AddContainerType(r.Context(), containerType)
+	if addErr != nil {
+		if errors.Is(addErr, mediator.ErrContainerTypeAlreadyExists) || errors.Is(addErr, mediator.ErrContainerContentsTaken) ||
+			errors.Is(addErr, mediator.ErrContainerContentsNotFound) {
+			http.Error(w, addErr.Error(), http.StatusConflict)
+			return
+		}
+		http.Error(w, addErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJson(w, http.StatusCreated, added.ToViewModel())
+}
+
+func (cc containerController) RetrieveContainerTypes(w http.ResponseWriter, r *http.Request) {
+	containerTypes, retrieveErr := cc.containerMediator.RetrieveContainerTypes(r.Context())
+	if retrieveErr != nil {
+		http.Error(w, retrieveErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]viewmodel.ContainerTypeResponse, 0, len(containerTypes))
+	for _, containerType := range containerTypes {
+		response = append(response, containerType.ToViewModel())
+	}
+	writeJson(w, http.StatusOK, response)
+}
+
+func (cc containerController) RemoveContainerType(w http.ResponseWriter, r *http.Request) {
+	if removeErr := cc.containerMediator.RemoveContainerType(r.Context(), mux.Vars(r)["name"]); removeErr != nil {
+		if errors.Is(removeErr, mediator.ErrContainerTypeNotFound) {
+			http.Error(w, removeErr.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, removeErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,154 @@
+package controller_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	api "github.com/felipevillarrealdaza/go-service-template/internal/api/http"
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	mediator_mocks "github.com/felipevillarrealdaza/go-service-template/internal/mediator/mocks"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AddContainerType(t *testing.T) {
+	// Set Up
+	containerMediatorMock := mediator_mocks.NewContainerMediator(t)
+	router := api.NewRouter(
+		api.WithContainerMediator(containerMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleAdmin)),
+	)
+	carton := domain_model.ContainerType{Name: "carton", PackSize: 1000, Capacities: []int{10, 5}, CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+
+	t.Run("Created", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		body := []byte(`{"name":"carton","pack_size":1000,"capacities":[10,5]}`)
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/container", bytes.NewReader(body))
+		req.Header.Set("X-API-Key", testApiKey)
+		containerMediatorMock.On("AddContainerType", mock.Anything, domain_model.ContainerType{Name: "carton", PackSize: 1000, Capacities: []int{10, 5}}).Return(carton, nil).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusCreated, httpRecorder.Code)
+		var response viewmodel.ContainerTypeResponse
+		require.NoError(t, json.Unmarshal(httpRecorder.Body.Bytes(), &response))
+		require.Equal(t, carton.ToViewModel(), response)
+	})
+
+	invalidBodies := map[string]string{
+		"Packs and containers": `{"name":"box","pack_size":1000,"container":"carton","capacities":[4]}`,
+		"Neither":              `{"name":"box","capacities":[4]}`,
+		"Holding itself":       `{"name":"box","container":"box","capacities":[4]}`,
+		"No capacities":        `{"name":"box","pack_size":1000,"capacities":[]}`,
+		"Repeated capacities":  `{"name":"box","pack_size":1000,"capacities":[4,4]}`,
+		"Capacity of zero":     `{"name":"box","pack_size":1000,"capacities":[0]}`,
+	}
+	for name, body := range invalidBodies {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			httpRecorder := httptest.NewRecorder()
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/container", bytes.NewReader([]byte(body)))
+			req.Header.Set("X-API-Key", testApiKey)
+
+			// Act
+			router.ServeHTTP(httpRecorder, req)
+
+			// Assert
+			require.Equal(t, http.StatusBadRequest, httpRecorder.Code)
+		})
+	}
+
+	t.Run("Contents not found", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		body := []byte(`{"name":"pallet","container":"crate","capacities":[4]}`)
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/container", bytes.NewReader(body))
+		req.Header.Set("X-API-Key", testApiKey)
+		containerMediatorMock.On("AddContainerType", mock.Anything, mock.Anything).
+			Return(domain_model.ContainerType{}, errors.Wrap(mediator.ErrContainerContentsNotFound, "could not add container type [pallet]")).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusConflict, httpRecorder.Code)
+	})
+}
+
+func Test_RetrieveContainerTypes(t *testing.T) {
+	// Set Up
+	containerMediatorMock := mediator_mocks.NewContainerMediator(t)
+	router := api.NewRouter(
+		api.WithContainerMediator(containerMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleAdmin)),
+	)
+	containerTypes := []domain_model.ContainerType{
+		{Name: "carton", PackSize: 1000, Capacities: []int{5}},
+		{Name: "pallet", Container: "carton", Capacities: []int{4}},
+	}
+
+	// Arrange
+	httpRecorder := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/container", nil)
+	req.Header.Set("X-API-Key", testApiKey)
+	containerMediatorMock.On("RetrieveContainerTypes", mock.Anything).Return(containerTypes, nil)
+
+	// Act
+	router.ServeHTTP(httpRecorder, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, httpRecorder.Code)
+	require.JSONEq(t, `[
+		{"name":"carton","pack_size":1000,"capacities":[5],"created_at":"0001-01-01T00:00:00Z"},
+		{"name":"pallet","container":"carton","capacities":[4],"created_at":"0001-01-01T00:00:00Z"}
+	]`, httpRecorder.Body.String())
+}
+
+func Test_RemoveContainerType(t *testing.T) {
+	// Set Up
+	containerMediatorMock := mediator_mocks.NewContainerMediator(t)
+	router := api.NewRouter(
+		api.WithContainerMediator(containerMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleAdmin)),
+	)
+
+	t.Run("Removed", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/api/v1/container/carton", nil)
+		req.Header.Set("X-API-Key", testApiKey)
+		containerMediatorMock.On("RemoveContainerType", mock.Anything, "carton").Return(nil).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusNoContent, httpRecorder.Code)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/api/v1/container/crate", nil)
+		req.Header.Set("X-API-Key", testApiKey)
+		containerMediatorMock.On("RemoveContainerType", mock.Anything, "crate").
+			Return(errors.Wrap(mediator.ErrContainerTypeNotFound, "could not remove container type [crate]")).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusNotFound, httpRecorder.Code)
+	})
+}
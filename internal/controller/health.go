@@ -1,16 +1,29 @@
 package controller
 
 import (
+	"encoding/json"
 	"net/http"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
 )
 
+// Dependency injection using optional pattern
 type HealthControllerDeps func(controller *healthController)
 
+func WithHealthMediator(mediator mediator.HealthMediator) HealthControllerDeps {
+	return func(controller *healthController) {
+		controller.healthMediator = mediator
+	}
+}
+
 type HealthController interface {
-	Health(w http.ResponseWriter, r *http.Request)
+	Live(w http.ResponseWriter, r *http.Request)
+	Ready(w http.ResponseWriter, r *http.Request)
 }
 
 type healthController struct {
+	healthMediator mediator.HealthMediator
 }
 
 func NewHttpHealthController(deps ...HealthControllerDeps) HealthController {
@@ -21,7 +34,28 @@ func NewHttpHealthController(deps ...HealthControllerDeps) HealthController {
 	return controller
 }
 
-func (hc healthController) Health(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusOK)
-	w.Write([]byte("Health endpoint called!"))
+func (hc healthController) Live(w http.ResponseWriter, r *http.Request) {
+	writeHealthReport(w, hc.healthMediator.CheckLiveness(r.Context()))
+}
+
+func (hc healthController) Ready(w http.ResponseWriter, r *http.Request) {
+	writeHealthReport(w, hc.healthMediator.CheckReadiness(r.Context()))
+}
+
+// Healthy reports are answered with 200, anything else with 503 so load balancers stop routing traffic.
+func writeHealthReport(w http.ResponseWriter, report domain_model.HealthReport) {
+	response, marshalErr := json.Marshal(report.ToViewModel())
+	if marshalErr != nil {
+		http.Error(w, marshalErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	statusCode := http.StatusOK
+	if !report.IsHealthy() {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	w.Write(response)
 }
@@ -0,0 +1,107 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	api "github.com/felipevillarrealdaza/go-service-template/internal/api/http"
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	mediator_mocks "github.com/felipevillarrealdaza/go-service-template/internal/mediator/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Live_OK(t *testing.T) {
+	// Set Up
+	orderMediatorMock := mediator_mocks.NewOrderMediator(t)
+	packMediatorMock := mediator_mocks.NewPackMediator(t)
+	healthMediatorMock := mediator_mocks.NewHealthMediator(t)
+	authMediatorMock := newAuthMediatorMock(t, domain_model.RoleAdmin)
+	router := api.NewRouter(
+		api.WithPackMediator(packMediatorMock),
+		api.WithOrderMediator(orderMediatorMock),
+		api.WithHealthMediator(healthMediatorMock),
+		api.WithAuthMediator(authMediatorMock),
+	)
+
+	for _, path := range []string{"/api/v1/health", "/api/v1/health/live"} {
+		t.Run(path, func(t *testing.T) {
+			// Arrange
+			httpRecorder := httptest.NewRecorder()
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, path, nil)
+			healthMediatorMock.On("CheckLiveness", mock.Anything).Return(domain_model.HealthReport{Status: domain_model.HealthStatusUp})
+
+			// Act
+			router.ServeHTTP(httpRecorder, req)
+
+			// Assert
+			healthMediatorMock.AssertExpectations(t)
+			require.Equal(t, http.StatusOK, httpRecorder.Code)
+
+			// Clean up
+			healthMediatorMock.ExpectedCalls = make([]*mock.Call, 0)
+		})
+	}
+}
+
+func Test_Ready(t *testing.T) {
+	// Set Up
+	orderMediatorMock := mediator_mocks.NewOrderMediator(t)
+	packMediatorMock := mediator_mocks.NewPackMediator(t)
+	healthMediatorMock := mediator_mocks.NewHealthMediator(t)
+	authMediatorMock := newAuthMediatorMock(t, domain_model.RoleAdmin)
+	router := api.NewRouter(
+		api.WithPackMediator(packMediatorMock),
+		api.WithOrderMediator(orderMediatorMock),
+		api.WithHealthMediator(healthMediatorMock),
+		api.WithAuthMediator(authMediatorMock),
+	)
+
+	t.Run("Ready", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/health/ready", nil)
+		report := domain_model.HealthReport{}
+		report.AddCheck(domain_model.HealthCheck{Name: "database", Status: domain_model.HealthStatusUp})
+		healthMediatorMock.On("CheckReadiness", mock.Anything).Return(report)
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		healthMediatorMock.AssertExpectations(t)
+		require.Equal(t, http.StatusOK, httpRecorder.Code)
+
+		// Clean up
+		healthMediatorMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Not ready", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/health/ready", nil)
+		report := domain_model.HealthReport{}
+		report.AddCheck(domain_model.HealthCheck{Name: "database", Status: domain_model.HealthStatusDown, Err: errors.New("connection refused")})
+		report.AddCheck(domain_model.HealthCheck{Name: "packs", Status: domain_model.HealthStatusUp})
+		healthMediatorMock.On("CheckReadiness", mock.Anything).Return(report)
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		healthMediatorMock.AssertExpectations(t)
+		require.Equal(t, http.StatusServiceUnavailable, httpRecorder.Code)
+		var response viewmodel.HealthResponse
+		require.NoError(t, json.Unmarshal(httpRecorder.Body.Bytes(), &response))
+		require.Equal(t, "down", response.Status)
+		require.Equal(t, "connection refused", response.Checks[0].Error)
+
+		// Clean up
+		healthMediatorMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+}
@@ -2,16 +2,22 @@ package controller
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
 	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
 	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/transfer"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"github.com/gorilla/mux"
 )
 
+// Imports are read as they are calculated, this only bounds how much a single request can ask for
+const maxImportBodyBytes = 10 << 20
+
 // Dependency injection using optional pattern
 type OrderControllerDeps func(controller *orderController)
 
@@ -23,6 +29,13 @@ func WithOrderMediator(mediator mediator.OrderMediator) OrderControllerDeps {
 
 type OrderController interface {
 	AddOrder(w http.ResponseWriter, r *http.Request)
+	UpdateOrderStatus(w http.ResponseWriter, r *http.Request)
+	AmendOrder(w http.ResponseWriter, r *http.Request)
+	RecalculateOrders(w http.ResponseWriter, r *http.Request)
+	ExportOrders(w http.ResponseWriter, r *http.Request)
+	ImportOrders(w http.ResponseWriter, r *http.Request)
+	AddQuote(w http.ResponseWriter, r *http.Request)
+	AcceptQuote(w http.ResponseWriter, r *http.Request)
 }
 
 type orderController struct {
@@ -57,8 +70,16 @@ func (oc orderController) AddOrder(w http.ResponseWriter, r *http.Request) {
 	order := domain_model.Order{
 		OrderId:  uuid.New(),
 		Quantity: requestBody.OrderQuantity,
+		Profile:  requestBody.Profile,
+	}
+	if requestBody.OrderDate != nil {
+		order.OrderDate = *requestBody.OrderDate
 	}
 	if createOrderErr := oc.orderMediator.CreateOrder(r.Context(), order); createOrderErr != nil {
+		if errors.Is(createOrderErr, mediator.ErrPackProfileNotFound) {
+			http.Error(w, createOrderErr.Error(), http.StatusNotFound)
+			return
+		}
 		http.Error(w, createOrderErr.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -66,6 +87,14 @@ func (oc orderController) AddOrder(w http.ResponseWriter, r *http.Request) {
 	// Once order is created, calculate order packs needed
 	orderPacks, calculateErr := oc.orderMediator.CalculateOrderPacks(r.Context(), order.OrderId)
 	if calculateErr != nil {
+		if errors.Is(calculateErr, mediator.ErrNoShippablePacks) {
+			http.Error(w, calculateErr.Error(), http.StatusConflict)
+			return
+		}
+		if errors.Is(calculateErr, mediator.ErrOrderPriceTooHigh) {
+			http.Error(w, calculateErr.Error(), http.StatusBadRequest)
+			return
+		}
 		http.Error(w, calculateErr.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -81,3 +110,167 @@ func (oc orderController) AddOrder(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 	w.Write(response)
 }
+
+func (oc orderController) UpdateOrderStatus(w http.ResponseWriter, r *http.Request) {
+	orderId, parseErr := uuid.Parse(mux.Vars(r)["id"])
+	if parseErr != nil {
+		http.Error(w, parseErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Validate JSON and request body
+	var requestBody viewmodel.OrderStatusRequest
+	jsonErr := json.NewDecoder(r.Body).Decode(&requestBody)
+	if jsonErr != nil {
+		http.Error(w, jsonErr.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	validationErr := oc.validate.Struct(&requestBody)
+	if validationErr != nil {
+		http.Error(w, validationErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	order, transitionErr := oc.orderMediator.TransitionOrderStatus(r.Context(), orderId, domain_model.OrderStatus(requestBody.Status))
+	if transitionErr != nil {
+		switch {
+		case errors.Is(transitionErr, mediator.ErrOrderNotFound):
+			http.Error(w, transitionErr.Error(), http.StatusNotFound)
+		case errors.Is(transitionErr, mediator.ErrInvalidOrderTransition):
+			http.Error(w, transitionErr.Error(), http.StatusConflict)
+		default:
+			http.Error(w, transitionErr.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	writeJson(w, http.StatusOK, order.ToViewModel())
+}
+
+func (oc orderController) AmendOrder(w http.ResponseWriter, r *http.Request) {
+	orderId, parseErr := uuid.Parse(mux.Vars(r)["id"])
+	if parseErr != nil {
+		http.Error(w, parseErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Validate JSON and request body
+	var requestBody viewmodel.OrderAmendmentRequest
+	jsonErr := json.NewDecoder(r.Body).Decode(&requestBody)
+	if jsonErr != nil {
+		http.Error(w, jsonErr.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	validationErr := oc.validate.Struct(&requestBody)
+	if validationErr != nil {
+		http.Error(w, validationErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	amendment, amendErr := oc.orderMediator.AmendOrderQuantity(r.Context(), orderId, requestBody.OrderQuantity)
+	if amendErr != nil {
+		switch {
+		case errors.Is(amendErr, mediator.ErrOrderNotFound):
+			http.Error(w, amendErr.Error(), http.StatusNotFound)
+		case errors.Is(amendErr, mediator.ErrOrderNotAmendable):
+			http.Error(w, amendErr.Error(), http.StatusConflict)
+		case errors.Is(amendErr, mediator.ErrOrderPriceTooHigh):
+			http.Error(w, amendErr.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, amendErr.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	writeJson(w, http.StatusOK, amendment.ToViewModel())
+}
+
+func (oc orderController) RecalculateOrders(w http.ResponseWriter, r *http.Request) {
+	// Validate JSON and request body
+	var requestBody viewmodel.RecalculationRequest
+	jsonErr := json.NewDecoder(r.Body).Decode(&requestBody)
+	if jsonErr != nil {
+		http.Error(w, jsonErr.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	validationErr := oc.validate.Struct(&requestBody)
+	if validationErr != nil {
+		http.Error(w, validationErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Create domain model from viewmodel and recalculate orders
+	filter := domain_model.RecalculationFilter{BatchSize: requestBody.BatchSize, DryRun: requestBody.DryRun, Profile: requestBody.Profile}
+	for _, status := range requestBody.Statuses {
+		filter.Statuses = append(filter.Statuses, domain_model.OrderStatus(status))
+	}
+	if requestBody.From != nil {
+		filter.From = *requestBody.From
+	}
+	if requestBody.To != nil {
+		filter.To = *requestBody.To
+	}
+	report, recalculateErr := oc.orderMediator.RecalculateOrders(r.Context(), filter)
+	if recalculateErr != nil {
+		http.Error(w, recalculateErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJson(w, http.StatusOK, report.ToViewModel())
+}
+
+// Stream every order with its packs. Once the first order is written the status can no longer change, so later errors
+// abort the response and the client gets a truncated body instead of a complete looking one.
+func (oc orderController) ExportOrders(w http.ResponseWriter, r *http.Request) {
+	format, formatErr := responseFormat(r)
+	if formatErr != nil {
+		http.Error(w, formatErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var orderWriter transfer.RecordWriter[viewmodel.OrderExport]
+	startWriting := func() {
+		writeAttachmentHeaders(w, format, "orders")
+		orderWriter = transfer.NewOrderWriter(w, format)
+	}
+	exportErr := oc.orderMediator.ExportOrders(r.Context(), func(order domain_model.OrderExport) error {
+		if orderWriter == nil {
+			startWriting()
+		}
+		return orderWriter.Write(order.ToViewModel())
+	})
+	if exportErr != nil && orderWriter == nil {
+		http.Error(w, exportErr.Error(), http.StatusInternalServerError)
+		return
+	}
+	if exportErr != nil {
+		panic(http.ErrAbortHandler)
+	}
+
+	if orderWriter == nil {
+		startWriting()
+	}
+	orderWriter.Close()
+}
+
+// Create and calculate an order of the profile in the query for each quantity of the CSV body, writing back the
+// result of each row as it goes
+func (oc orderController) ImportOrders(w http.ResponseWriter, r *http.Request) {
+	format, formatErr := responseFormat(r)
+	if formatErr != nil {
+		http.Error(w, formatErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeAttachmentHeaders(w, format, "order_import")
+	resultWriter := transfer.NewImportResultWriter(w, format)
+	_, readErr := transfer.ImportOrders(http.MaxBytesReader(w, r.Body, maxImportBodyBytes), resultWriter, func(quantity int) (uuid.UUID, []viewmodel.OrderPack, error) {
+		orderPacks, importErr := oc.orderMediator.ImportOrder(r.Context(), packProfile(r), quantity)
+		return orderPacks.OrderId, orderPacks.OptimalOrderPack.ToViewModel(), importErr
+	})
+	if readErr != nil {
+		// The results written so far are valid, the unreadable rest of the body is reported last
+		resultWriter.Write(viewmodel.OrderImportResult{Error: readErr.Error()})
+	}
+	resultWriter.Close()
+}
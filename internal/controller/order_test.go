@@ -8,12 +8,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	api "github.com/felipevillarrealdaza/go-service-template/internal/api/http"
 	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
 	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
 	mediator_mocks "github.com/felipevillarrealdaza/go-service-template/internal/mediator/mocks"
-	repository_mocks "github.com/felipevillarrealdaza/go-service-template/internal/repository/mocks"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -22,8 +24,14 @@ func Test_AddOrder_OK(t *testing.T) {
 	// Set Up
 	orderMediatorMock := mediator_mocks.NewOrderMediator(t)
 	packMediatorMock := mediator_mocks.NewPackMediator(t)
-	repositoryMock := repository_mocks.NewQuerier(t)
-	router := api.NewRouter(packMediatorMock, orderMediatorMock, repositoryMock)
+	healthMediatorMock := mediator_mocks.NewHealthMediator(t)
+	authMediatorMock := newAuthMediatorMock(t, domain_model.RoleAdmin)
+	router := api.NewRouter(
+		api.WithPackMediator(packMediatorMock),
+		api.WithOrderMediator(orderMediatorMock),
+		api.WithHealthMediator(healthMediatorMock),
+		api.WithAuthMediator(authMediatorMock),
+	)
 	httpRecorder := httptest.NewRecorder()
 
 	// Arrange
@@ -32,6 +40,7 @@ func Test_AddOrder_OK(t *testing.T) {
 	}
 	requestBytes, _ := json.Marshal(reqBody)
 	req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/order", bytes.NewBuffer(requestBytes))
+	req.Header.Set("X-API-Key", testApiKey)
 	orderMediatorMock.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
 	orderMediatorMock.On("CalculateOrderPacks", mock.Anything, mock.Anything).Return(domain_model.OrderPacks{OptimalOrderPack: domain_model.OrderPack(map[int]int{2: 2})}, nil)
 
@@ -46,12 +55,54 @@ func Test_AddOrder_OK(t *testing.T) {
 	orderMediatorMock.ExpectedCalls = make([]*mock.Call, 0)
 }
 
+func Test_AddOrder_Packaging(t *testing.T) {
+	// Set Up
+	orderMediatorMock := mediator_mocks.NewOrderMediator(t)
+	router := api.NewRouter(
+		api.WithOrderMediator(orderMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleClient)),
+	)
+	httpRecorder := httptest.NewRecorder()
+
+	// Arrange
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/order", bytes.NewBufferString(`{"quantity":61000}`))
+	req.Header.Set("X-API-Key", testApiKey)
+	orderPacks := domain_model.OrderPacks{
+		OrderQuantity: 61000,
+		ResultGrid:    map[int]domain_model.OrderPack{61000: {1000: 61}},
+		Packaging: []domain_model.PackagingUnit{
+			{Container: "pallet", Quantity: 3, Contents: &domain_model.PackagingUnit{Container: "carton", Quantity: 4, Contents: &domain_model.PackagingUnit{PackSize: 1000, Quantity: 5}}},
+			{PackSize: 1000, Quantity: 1},
+		},
+	}
+	orderMediatorMock.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
+	orderMediatorMock.On("CalculateOrderPacks", mock.Anything, mock.Anything).Return(orderPacks, nil)
+
+	// Act
+	router.ServeHTTP(httpRecorder, req)
+
+	// Assert
+	require.Equal(t, http.StatusCreated, httpRecorder.Code)
+	var response map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(httpRecorder.Body.Bytes(), &response))
+	require.JSONEq(t, `[
+		{"container":"pallet","quantity":3,"contents":{"container":"carton","quantity":4,"contents":{"pack_size":1000,"quantity":5}}},
+		{"pack_size":1000,"quantity":1}
+	]`, string(response["packaging"]))
+}
+
 func Test_AddOrder_Errors(t *testing.T) {
 	// Set up
 	orderMediatorMock := mediator_mocks.NewOrderMediator(t)
 	packMediatorMock := mediator_mocks.NewPackMediator(t)
-	repositoryMock := repository_mocks.NewQuerier(t)
-	router := api.NewRouter(packMediatorMock, orderMediatorMock, repositoryMock)
+	healthMediatorMock := mediator_mocks.NewHealthMediator(t)
+	authMediatorMock := newAuthMediatorMock(t, domain_model.RoleAdmin)
+	router := api.NewRouter(
+		api.WithPackMediator(packMediatorMock),
+		api.WithOrderMediator(orderMediatorMock),
+		api.WithHealthMediator(healthMediatorMock),
+		api.WithAuthMediator(authMediatorMock),
+	)
 
 	t.Run("Wrong JSON body", func(t *testing.T) {
 		// Arrange
@@ -61,6 +112,7 @@ func Test_AddOrder_Errors(t *testing.T) {
 		}
 		requestBytes, _ := json.Marshal(reqBody)
 		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/order", bytes.NewBuffer(requestBytes))
+		req.Header.Set("X-API-Key", testApiKey)
 
 		// Act
 		router.ServeHTTP(httpRecorder, req)
@@ -78,6 +130,7 @@ func Test_AddOrder_Errors(t *testing.T) {
 		httpRecorder := httptest.NewRecorder()
 		requestBytes := []byte("{quantity 2")
 		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/order", bytes.NewBuffer(requestBytes))
+		req.Header.Set("X-API-Key", testApiKey)
 
 		// Act
 		router.ServeHTTP(httpRecorder, req)
@@ -98,6 +151,7 @@ func Test_AddOrder_Errors(t *testing.T) {
 		}
 		requestBytes, _ := json.Marshal(reqBody)
 		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/order", bytes.NewBuffer(requestBytes))
+		req.Header.Set("X-API-Key", testApiKey)
 		orderMediatorMock.On("CreateOrder", mock.Anything, mock.Anything).Return(errors.New("unknown error creating order"))
 
 		// Act
@@ -119,6 +173,7 @@ func Test_AddOrder_Errors(t *testing.T) {
 		}
 		requestBytes, _ := json.Marshal(reqBody)
 		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/order", bytes.NewBuffer(requestBytes))
+		req.Header.Set("X-API-Key", testApiKey)
 		orderMediatorMock.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
 		orderMediatorMock.On("CalculateOrderPacks", mock.Anything, mock.Anything).Return(domain_model.OrderPacks{}, errors.New("unknown error calculating packs"))
 
@@ -132,14 +187,60 @@ func Test_AddOrder_Errors(t *testing.T) {
 		// Clean up
 		orderMediatorMock.ExpectedCalls = make([]*mock.Call, 0)
 	})
+
+	t.Run("No pack can be shipped", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		requestBytes, _ := json.Marshal(viewmodel.OrderRequest{OrderQuantity: 2})
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/order", bytes.NewBuffer(requestBytes))
+		req.Header.Set("X-API-Key", testApiKey)
+		orderMediatorMock.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
+		orderMediatorMock.On("CalculateOrderPacks", mock.Anything, mock.Anything).Return(domain_model.OrderPacks{}, mediator.ErrNoShippablePacks)
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusConflict, httpRecorder.Code)
+		orderMediatorMock.AssertExpectations(t)
+
+		// Clean up
+		orderMediatorMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Price too high to be saved", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		requestBytes, _ := json.Marshal(viewmodel.OrderRequest{OrderQuantity: 200000})
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/order", bytes.NewBuffer(requestBytes))
+		req.Header.Set("X-API-Key", testApiKey)
+		orderMediatorMock.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
+		orderMediatorMock.On("CalculateOrderPacks", mock.Anything, mock.Anything).Return(domain_model.OrderPacks{}, mediator.ErrOrderPriceTooHigh)
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusBadRequest, httpRecorder.Code)
+		orderMediatorMock.AssertExpectations(t)
+
+		// Clean up
+		orderMediatorMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
 }
 
 func Test_AddOrder_MethodsNotAllowed(t *testing.T) {
 	// Arrange
 	orderMediatorMock := mediator_mocks.NewOrderMediator(t)
 	packMediatorMock := mediator_mocks.NewPackMediator(t)
-	repositoryMock := repository_mocks.NewQuerier(t)
-	router := api.NewRouter(packMediatorMock, orderMediatorMock, repositoryMock)
+	healthMediatorMock := mediator_mocks.NewHealthMediator(t)
+	authMediatorMock := newAuthMediatorMock(t, domain_model.RoleAdmin)
+	router := api.NewRouter(
+		api.WithPackMediator(packMediatorMock),
+		api.WithOrderMediator(orderMediatorMock),
+		api.WithHealthMediator(healthMediatorMock),
+		api.WithAuthMediator(authMediatorMock),
+	)
 	httpRecorder := httptest.NewRecorder()
 	reqBody := viewmodel.OrderRequest{
 		OrderQuantity: 500,
@@ -149,6 +250,7 @@ func Test_AddOrder_MethodsNotAllowed(t *testing.T) {
 		for _, httpVerb := range []string{http.MethodGet, http.MethodPut, http.MethodDelete, http.MethodHead, http.MethodPatch, http.MethodOptions} {
 			// Arrange
 			req, _ := http.NewRequestWithContext(context.Background(), httpVerb, "/api/v1/order", bytes.NewBuffer(requestBytes))
+			req.Header.Set("X-API-Key", testApiKey)
 
 			// Act
 			router.ServeHTTP(httpRecorder, req)
@@ -162,3 +264,434 @@ func Test_AddOrder_MethodsNotAllowed(t *testing.T) {
 		}
 	})
 }
+
+func Test_AddOrder_RateLimited(t *testing.T) {
+	// Set Up
+	orderMediatorMock := mediator_mocks.NewOrderMediator(t)
+	authMediatorMock := newAuthMediatorMock(t, domain_model.RoleClient)
+	router := api.NewRouter(
+		api.WithOrderMediator(orderMediatorMock),
+		api.WithAuthMediator(authMediatorMock),
+		api.WithRateLimit(0.001, 1),
+	)
+	requestBytes, _ := json.Marshal(viewmodel.OrderRequest{OrderQuantity: 500})
+	orderMediatorMock.On("CreateOrder", mock.Anything, mock.Anything).Return(nil).Once()
+	orderMediatorMock.On("CalculateOrderPacks", mock.Anything, mock.Anything).Return(domain_model.OrderPacks{}, nil).Once()
+
+	// Act
+	var statusCodes []int
+	var retryAfter string
+	for i := 0; i < 2; i++ {
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/order", bytes.NewBuffer(requestBytes))
+		req.Header.Set("X-API-Key", testApiKey)
+		router.ServeHTTP(httpRecorder, req)
+		statusCodes = append(statusCodes, httpRecorder.Code)
+		retryAfter = httpRecorder.Header().Get("Retry-After")
+	}
+
+	// Assert
+	orderMediatorMock.AssertExpectations(t)
+	require.Equal(t, []int{http.StatusCreated, http.StatusTooManyRequests}, statusCodes)
+	require.NotEmpty(t, retryAfter)
+}
+
+func Test_AddOrder_ConcurrencyLimited(t *testing.T) {
+	// Set Up
+	orderMediatorMock := mediator_mocks.NewOrderMediator(t)
+	authMediatorMock := newAuthMediatorMock(t, domain_model.RoleClient)
+	router := api.NewRouter(
+		api.WithOrderMediator(orderMediatorMock),
+		api.WithAuthMediator(authMediatorMock),
+		api.WithConcurrencyLimit(1, 10*time.Millisecond),
+	)
+	requestBytes, _ := json.Marshal(viewmodel.OrderRequest{OrderQuantity: 500})
+
+	// Arrange
+	started, release := make(chan struct{}), make(chan struct{})
+	orderMediatorMock.On("CreateOrder", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		close(started)
+		<-release
+	}).Return(nil).Once()
+	orderMediatorMock.On("CalculateOrderPacks", mock.Anything, mock.Anything).Return(domain_model.OrderPacks{}, nil).Once()
+	firstRecorder := httptest.NewRecorder()
+	firstDone := make(chan struct{})
+	go func() {
+		firstReq, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/order", bytes.NewBuffer(requestBytes))
+		firstReq.Header.Set("X-API-Key", testApiKey)
+		router.ServeHTTP(firstRecorder, firstReq)
+		close(firstDone)
+	}()
+	<-started
+
+	// Act
+	httpRecorder := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/order", bytes.NewBuffer(requestBytes))
+	req.Header.Set("X-API-Key", testApiKey)
+	router.ServeHTTP(httpRecorder, req)
+	close(release)
+	<-firstDone
+
+	// Assert
+	orderMediatorMock.AssertExpectations(t)
+	require.Equal(t, http.StatusServiceUnavailable, httpRecorder.Code)
+	require.Equal(t, "1", httpRecorder.Header().Get("Retry-After"))
+	require.Equal(t, http.StatusCreated, firstRecorder.Code)
+}
+
+func Test_BulkCalculations_RateLimited(t *testing.T) {
+	// Set Up
+	orderMediatorMock := mediator_mocks.NewOrderMediator(t)
+	router := api.NewRouter(
+		api.WithOrderMediator(orderMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleAdmin)),
+		api.WithRateLimit(0.001, 1),
+	)
+	orderMediatorMock.On("RecalculateOrders", mock.Anything, mock.Anything).Return(domain_model.RecalculationReport{}, nil).Once()
+
+	// Act
+	var statusCodes []int
+	for _, path := range []string{"/api/v1/order/recalculate", "/api/v1/quote/" + uuid.NewString() + "/accept"} {
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, path, bytes.NewBufferString(`{}`))
+		req.Header.Set("X-API-Key", testApiKey)
+		router.ServeHTTP(httpRecorder, req)
+		statusCodes = append(statusCodes, httpRecorder.Code)
+	}
+
+	// Assert
+	orderMediatorMock.AssertExpectations(t)
+	require.Equal(t, []int{http.StatusOK, http.StatusTooManyRequests}, statusCodes)
+}
+
+func Test_UpdateOrderStatus(t *testing.T) {
+	// Set Up
+	orderMediatorMock := mediator_mocks.NewOrderMediator(t)
+	router := api.NewRouter(
+		api.WithOrderMediator(orderMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleClient)),
+	)
+	orderId := uuid.New()
+	newRequest := func(path string, status string) *http.Request {
+		requestBytes, _ := json.Marshal(viewmodel.OrderStatusRequest{Status: status})
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPatch, path, bytes.NewBuffer(requestBytes))
+		req.Header.Set("X-API-Key", testApiKey)
+		return req
+	}
+
+	t.Run("Status updated", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		pickedAt := time.Now().UTC()
+		orderMediatorMock.
+			On("TransitionOrderStatus", mock.Anything, orderId, domain_model.OrderStatusPicked).
+			Return(domain_model.Order{OrderId: orderId, Quantity: 10, Status: domain_model.OrderStatusPicked, PickedAt: &pickedAt}, nil)
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest("/api/v1/order/"+orderId.String()+"/status", "picked"))
+
+		// Assert
+		require.Equal(t, http.StatusOK, httpRecorder.Code)
+		var response viewmodel.OrderDetailsResponse
+		require.NoError(t, json.Unmarshal(httpRecorder.Body.Bytes(), &response))
+		require.Equal(t, "picked", response.Status)
+		require.NotNil(t, response.PickedAt)
+
+		// Clean up
+		orderMediatorMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Invalid transition", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		orderMediatorMock.
+			On("TransitionOrderStatus", mock.Anything, orderId, domain_model.OrderStatusShipped).
+			Return(domain_model.Order{}, mediator.ErrInvalidOrderTransition)
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest("/api/v1/order/"+orderId.String()+"/status", "shipped"))
+
+		// Assert
+		require.Equal(t, http.StatusConflict, httpRecorder.Code)
+
+		// Clean up
+		orderMediatorMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Order not found", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		orderMediatorMock.
+			On("TransitionOrderStatus", mock.Anything, orderId, domain_model.OrderStatusCancelled).
+			Return(domain_model.Order{}, mediator.ErrOrderNotFound)
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest("/api/v1/order/"+orderId.String()+"/status", "cancelled"))
+
+		// Assert
+		require.Equal(t, http.StatusNotFound, httpRecorder.Code)
+
+		// Clean up
+		orderMediatorMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Status cannot be set by clients", func(t *testing.T) {
+		for _, status := range []string{"created", "calculated", "lost"} {
+			// Arrange
+			httpRecorder := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(httpRecorder, newRequest("/api/v1/order/"+orderId.String()+"/status", status))
+
+			// Assert
+			require.Equal(t, http.StatusBadRequest, httpRecorder.Code, status)
+		}
+	})
+
+	t.Run("Invalid order id", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest("/api/v1/order/not-a-uuid/status", "picked"))
+
+		// Assert
+		require.Equal(t, http.StatusBadRequest, httpRecorder.Code)
+	})
+}
+
+func Test_AmendOrder(t *testing.T) {
+	// Set Up
+	orderMediatorMock := mediator_mocks.NewOrderMediator(t)
+	router := api.NewRouter(
+		api.WithOrderMediator(orderMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleClient)),
+	)
+	orderId := uuid.New()
+	newRequest := func(quantity int) *http.Request {
+		requestBytes, _ := json.Marshal(viewmodel.OrderAmendmentRequest{OrderQuantity: quantity})
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPatch, "/api/v1/order/"+orderId.String(), bytes.NewBuffer(requestBytes))
+		req.Header.Set("X-API-Key", testApiKey)
+		return req
+	}
+
+	t.Run("Quantity amended", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		orderMediatorMock.On("AmendOrderQuantity", mock.Anything, orderId, 8).Return(domain_model.OrderAmendment{
+			Order:   domain_model.Order{OrderId: orderId, Quantity: 8, Status: domain_model.OrderStatusCalculated},
+			Packs:   domain_model.OrderPack{2: 4, 5: 0},
+			Added:   domain_model.OrderPack{2: 3},
+			Removed: domain_model.OrderPack{5: 2},
+		}, nil)
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest(8))
+
+		// Assert
+		require.Equal(t, http.StatusOK, httpRecorder.Code)
+		var response viewmodel.OrderAmendmentResponse
+		require.NoError(t, json.Unmarshal(httpRecorder.Body.Bytes(), &response))
+		require.Equal(t, 8, response.Order.Quantity)
+		require.Equal(t, []viewmodel.OrderPack{{Size: 2, Quantity: 4}}, response.Packs)
+		require.Equal(t, []viewmodel.OrderPack{{Size: 2, Quantity: 3}}, response.Added)
+		require.Equal(t, []viewmodel.OrderPack{{Size: 5, Quantity: 2}}, response.Removed)
+
+		// Clean up
+		orderMediatorMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Order not amendable", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		orderMediatorMock.On("AmendOrderQuantity", mock.Anything, orderId, 9).Return(domain_model.OrderAmendment{}, mediator.ErrOrderNotAmendable)
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest(9))
+
+		// Assert
+		require.Equal(t, http.StatusConflict, httpRecorder.Code)
+
+		// Clean up
+		orderMediatorMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Invalid quantity", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest(-3))
+
+		// Assert
+		require.Equal(t, http.StatusBadRequest, httpRecorder.Code)
+	})
+}
+
+func Test_RecalculateOrders(t *testing.T) {
+	// Set Up
+	orderMediatorMock := mediator_mocks.NewOrderMediator(t)
+	router := api.NewRouter(
+		api.WithOrderMediator(orderMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleAdmin)),
+	)
+	newRequest := func(body string) *http.Request {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/order/recalculate", bytes.NewBufferString(body))
+		req.Header.Set("X-API-Key", testApiKey)
+		return req
+	}
+
+	t.Run("Dry run report", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		orderId := uuid.New()
+		orderMediatorMock.
+			On("RecalculateOrders", mock.Anything, domain_model.RecalculationFilter{
+				Statuses: []domain_model.OrderStatus{domain_model.OrderStatusCalculated},
+				From:     from,
+				DryRun:   true,
+			}).
+			Return(domain_model.RecalculationReport{
+				DryRun:   true,
+				PackSet:  []int{4, 2},
+				Examined: 3,
+				Changed: []domain_model.OrderRecalculation{
+					{OrderId: orderId, Quantity: 8, PreviousWaste: 2, Waste: 0, Added: domain_model.OrderPack{4: 2}, Removed: domain_model.OrderPack{5: 2}},
+				},
+			}, nil)
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest(`{"statuses": ["calculated"], "from": "2024-01-01T00:00:00Z", "dry_run": true}`))
+
+		// Assert
+		require.Equal(t, http.StatusOK, httpRecorder.Code)
+		var response viewmodel.RecalculationResponse
+		require.NoError(t, json.Unmarshal(httpRecorder.Body.Bytes(), &response))
+		require.True(t, response.DryRun)
+		require.Equal(t, 3, response.Examined)
+		require.Equal(t, 2, response.WasteSaved)
+		require.Equal(t, []viewmodel.OrderRecalculation{{
+			OrderId:       orderId,
+			Quantity:      8,
+			PreviousWaste: 2,
+			Waste:         0,
+			Added:         []viewmodel.OrderPack{{Size: 4, Quantity: 2}},
+			Removed:       []viewmodel.OrderPack{{Size: 5, Quantity: 2}},
+		}}, response.Changed)
+
+		// Clean up
+		orderMediatorMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Invalid filters", func(t *testing.T) {
+		for _, body := range []string{`{"statuses": ["shipped"]}`, `{"batch_size": -1}`} {
+			// Arrange
+			httpRecorder := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(httpRecorder, newRequest(body))
+
+			// Assert
+			require.Equal(t, http.StatusBadRequest, httpRecorder.Code, body)
+		}
+	})
+}
+
+func Test_ExportOrders(t *testing.T) {
+	// Set Up
+	orderMediatorMock := mediator_mocks.NewOrderMediator(t)
+	router := api.NewRouter(
+		api.WithOrderMediator(orderMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleAdmin)),
+	)
+	newRequest := func(query string) *http.Request {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/order/export"+query, nil)
+		req.Header.Set("X-API-Key", testApiKey)
+		return req
+	}
+	order := domain_model.OrderExport{
+		Order: domain_model.Order{OrderId: uuid.New(), Quantity: 12, Status: domain_model.OrderStatusCalculated},
+		Packs: domain_model.OrderPack{2: 1, 5: 2},
+	}
+
+	t.Run("JSON orders with their packs", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		orderMediatorMock.
+			On("ExportOrders", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				args.Get(1).(func(domain_model.OrderExport) error)(order)
+			}).
+			Return(nil).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest(""))
+
+		// Assert
+		require.Equal(t, http.StatusOK, httpRecorder.Code)
+		require.Equal(t, `attachment; filename="orders.json"`, httpRecorder.Header().Get("Content-Disposition"))
+		var response []viewmodel.OrderExport
+		require.NoError(t, json.Unmarshal(httpRecorder.Body.Bytes(), &response))
+		require.Len(t, response, 1)
+		require.Equal(t, order.Order.OrderId, response[0].OrderId)
+		require.Equal(t, []viewmodel.OrderPack{{Size: 2, Quantity: 1}, {Size: 5, Quantity: 2}}, response[0].Packs)
+	})
+
+	t.Run("CSV of no orders has a header", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		orderMediatorMock.On("ExportOrders", mock.Anything, mock.Anything).Return(nil).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest("?format=csv"))
+
+		// Assert
+		require.Equal(t, http.StatusOK, httpRecorder.Code)
+		require.Equal(t, "id,quantity,status,created_at,pack_size,pack_quantity\n", httpRecorder.Body.String())
+	})
+
+	t.Run("Error before the first order", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		orderMediatorMock.On("ExportOrders", mock.Anything, mock.Anything).Return(errors.New("connection reset")).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest(""))
+
+		// Assert
+		require.Equal(t, http.StatusInternalServerError, httpRecorder.Code)
+	})
+}
+
+func Test_ImportOrders(t *testing.T) {
+	// Set Up
+	orderMediatorMock := mediator_mocks.NewOrderMediator(t)
+	router := api.NewRouter(
+		api.WithOrderMediator(orderMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleClient)),
+	)
+	httpRecorder := httptest.NewRecorder()
+
+	// Arrange
+	orderId := uuid.New()
+	orderMediatorMock.
+		On("ImportOrder", mock.Anything, "", 12).
+		Return(domain_model.OrderPacks{OrderId: orderId, OptimalOrderPack: domain_model.OrderPack{5: 2, 2: 1}}, nil).Once()
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/order/import", bytes.NewBufferString("quantity\n12\ntwelve\n"))
+	req.Header.Set("X-API-Key", testApiKey)
+
+	// Act
+	router.ServeHTTP(httpRecorder, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, httpRecorder.Code)
+	var response []viewmodel.OrderImportResult
+	require.NoError(t, json.Unmarshal(httpRecorder.Body.Bytes(), &response))
+	require.Len(t, response, 2)
+	require.Equal(t, orderId, *response[0].OrderId)
+	require.Equal(t, []viewmodel.OrderPack{{Size: 2, Quantity: 1}, {Size: 5, Quantity: 2}}, response[0].Packs)
+	require.Empty(t, response[0].Error)
+	require.Nil(t, response[1].OrderId)
+	require.NotEmpty(t, response[1].Error)
+}
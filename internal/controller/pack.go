@@ -2,11 +2,18 @@ package controller
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
 	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/transfer"
 	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
 )
 
 // Dependency injection using optional pattern
@@ -21,6 +28,16 @@ func WithPackMediator(mediator mediator.PackMediator) PackControllerDeps {
 type PackController interface {
 	AddPack(w http.ResponseWriter, r *http.Request)
 	RemovePack(w http.ResponseWriter, r *http.Request)
+	AddPackProfile(w http.ResponseWriter, r *http.Request)
+	RetrievePackProfiles(w http.ResponseWriter, r *http.Request)
+	UpdatePackSpecification(w http.ResponseWriter, r *http.Request)
+	SchedulePack(w http.ResponseWriter, r *http.Request)
+	RetrievePackSchedule(w http.ResponseWriter, r *http.Request)
+	ExportPacks(w http.ResponseWriter, r *http.Request)
+	SimulatePackSet(w http.ResponseWriter, r *http.Request)
+	RecommendPackSet(w http.ResponseWriter, r *http.Request)
+	AnalyzePackSet(w http.ResponseWriter, r *http.Request)
+	ChartPacks(w http.ResponseWriter, r *http.Request)
 }
 
 type packController struct {
@@ -50,7 +67,15 @@ func (pc packController) AddPack(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if addPackErr := pc.packMediator.AddPack(r.Context(), requestBody.Size); addPackErr != nil {
+	if addPackErr := pc.packMediator.AddPack(r.Context(), packProfile(r), requestBody.Size); addPackErr != nil {
+		if errors.Is(addPackErr, mediator.ErrPackAlreadyExists) {
+			http.Error(w, addPackErr.Error(), http.StatusConflict)
+			return
+		}
+		if errors.Is(addPackErr, mediator.ErrPackProfileNotFound) {
+			http.Error(w, addPackErr.Error(), http.StatusNotFound)
+			return
+		}
 		http.Error(w, addPackErr.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -75,7 +100,11 @@ func (pc packController) RemovePack(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Remove pack
-	if addPackErr := pc.packMediator.RemovePack(r.Context(), requestBody.Size); addPackErr != nil {
+	if addPackErr := pc.packMediator.RemovePack(r.Context(), packProfile(r), requestBody.Size); addPackErr != nil {
+		if errors.Is(addPackErr, mediator.ErrPackNotFound) {
+			http.Error(w, addPackErr.Error(), http.StatusNotFound)
+			return
+		}
 		http.Error(w, addPackErr.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -84,3 +113,237 @@ func (pc packController) RemovePack(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte(""))
 }
+
+// Record the maximum weight and dimensions of the pack size in the path
+func (pc packController) UpdatePackSpecification(w http.ResponseWriter, r *http.Request) {
+	size, sizeErr := parseIntParam(mux.Vars(r)["size"], 0, 1, 0)
+	if sizeErr != nil {
+		http.Error(w, sizeErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Parse request to viewmodel
+	var requestBody viewmodel.PackSpecificationRequest
+	jsonErr := json.NewDecoder(r.Body).Decode(&requestBody)
+	if jsonErr != nil {
+		http.Error(w, jsonErr.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	validationErr := pc.validate.Struct(&requestBody)
+	if validationErr != nil {
+		http.Error(w, validationErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	specification := domain_model.PackSpecification{
+		PackSize:       size,
+		MaxWeightGrams: requestBody.MaxWeightGrams,
+		LengthMm:       requestBody.LengthMm,
+		WidthMm:        requestBody.WidthMm,
+		HeightMm:       requestBody.HeightMm,
+		Profile:        packProfile(r),
+	}
+	updated, updateErr := pc.packMediator.UpdatePackSpecification(r.Context(), specification)
+	if updateErr != nil {
+		if errors.Is(updateErr, mediator.ErrPackNotFound) {
+			http.Error(w, updateErr.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, updateErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJson(w, http.StatusOK, updated.ToViewModel())
+}
+
+func (pc packController) ExportPacks(w http.ResponseWriter, r *http.Request) {
+	format, formatErr := responseFormat(r)
+	if formatErr != nil {
+		http.Error(w, formatErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	packs, retrieveErr := pc.packMediator.RetrievePacks(r.Context(), packProfile(r))
+	if retrieveErr != nil {
+		http.Error(w, retrieveErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	packExports := make([]viewmodel.PackExport, 0, len(packs))
+	for _, pack := range packs {
+		packExports = append(packExports, viewmodel.PackExport{Size: pack})
+	}
+	writeAttachmentHeaders(w, format, "packs")
+	transfer.WritePacks(w, format, packExports)
+}
+
+// Compare how the order history would have been packed with the current and the proposed pack sizes
+func (pc packController) SimulatePackSet(w http.ResponseWriter, r *http.Request) {
+	// Validate JSON and request body
+	var requestBody viewmodel.SimulationRequest
+	jsonErr := json.NewDecoder(r.Body).Decode(&requestBody)
+	if jsonErr != nil {
+		http.Error(w, jsonErr.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	validationErr := pc.validate.Struct(&requestBody)
+	if validationErr != nil {
+		http.Error(w, validationErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Create domain model from viewmodel and replay the orders
+	simulation := domain_model.PackSetSimulation{PackSizes: requestBody.Sizes, Profile: packProfile(r)}
+	if len(requestBody.Costs) > 0 {
+		simulation.PackCosts = make(map[int]float64, len(requestBody.Costs))
+		for _, packCost := range requestBody.Costs {
+			simulation.PackCosts[packCost.Size] = packCost.Cost
+		}
+	}
+	report, simulateErr := pc.packMediator.SimulatePackSet(r.Context(), simulation)
+	if simulateErr != nil {
+		if errors.Is(simulateErr, mediator.ErrPackCostMissing) {
+			http.Error(w, simulateErr.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, simulateErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJson(w, http.StatusOK, report.ToViewModel())
+}
+
+// Suggest the pack set that packs the order history best, out of the candidate sizes
+func (pc packController) RecommendPackSet(w http.ResponseWriter, r *http.Request) {
+	// Validate JSON and request body
+	var requestBody viewmodel.RecommendationRequest
+	jsonErr := json.NewDecoder(r.Body).Decode(&requestBody)
+	if jsonErr != nil {
+		http.Error(w, jsonErr.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	validationErr := pc.validate.Struct(&requestBody)
+	if validationErr != nil {
+		http.Error(w, validationErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	recommendation, recommendErr := pc.packMediator.RecommendPackSet(r.Context(), domain_model.RecommendationRequest{
+		MaxSizes:       requestBody.MaxSizes,
+		Candidates:     requestBody.Candidates,
+		MaxEvaluations: requestBody.MaxEvaluations,
+		Profile:        packProfile(r),
+	})
+	if recommendErr != nil {
+		if errors.Is(recommendErr, mediator.ErrNoOrderHistory) {
+			http.Error(w, recommendErr.Error(), http.StatusConflict)
+			return
+		}
+		http.Error(w, recommendErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJson(w, http.StatusOK, recommendation.ToViewModel())
+}
+
+// Analyse the sizes given as a comma separated list, or the current pack set, for every quantity up to up_to
+func (pc packController) AnalyzePackSet(w http.ResponseWriter, r *http.Request) {
+	request, parseErr := parsePackSetAnalysisRequest(r)
+	if parseErr != nil {
+		http.Error(w, parseErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	analysis, analyzeErr := pc.packMediator.AnalyzePackSet(r.Context(), request)
+	if analyzeErr != nil {
+		if errors.Is(analyzeErr, mediator.ErrEmptyPackSet) {
+			http.Error(w, analyzeErr.Error(), http.StatusConflict)
+			return
+		}
+		http.Error(w, analyzeErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJson(w, http.StatusOK, analysis.ToViewModel())
+}
+
+func parsePackSetAnalysisRequest(r *http.Request) (domain_model.PackSetAnalysisRequest, error) {
+	query := r.URL.Query()
+	request := domain_model.PackSetAnalysisRequest{Profile: query.Get("profile")}
+	if sizes := query.Get("sizes"); sizes != "" {
+		for _, size := range strings.Split(sizes, ",") {
+			packSize, sizeErr := parseIntParam(strings.TrimSpace(size), 0, 1, mediator.MaxAnalysisQuantity)
+			if sizeErr != nil || slices.Contains(request.PackSizes, packSize) {
+				return request, fmt.Errorf("invalid sizes: [%v] is not a new pack size from 1 to %v", size, mediator.MaxAnalysisQuantity)
+			}
+			request.PackSizes = append(request.PackSizes, packSize)
+		}
+	}
+
+	var upToErr error
+	if request.UpTo, upToErr = parseIntParam(query.Get("up_to"), 0, 1, mediator.MaxAnalysisQuantity); upToErr != nil {
+		return request, fmt.Errorf("invalid up_to: %w", upToErr)
+	}
+	return request, nil
+}
+
+// Stream the packs of every step quantity from from to to, as JSON or as CSV to print
+func (pc packController) ChartPacks(w http.ResponseWriter, r *http.Request) {
+	request, parseErr := parsePackChartRequest(r)
+	if parseErr != nil {
+		http.Error(w, parseErr.Error(), http.StatusBadRequest)
+		return
+	}
+	format, formatErr := responseFormat(r)
+	if formatErr != nil {
+		http.Error(w, formatErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var chartWriter transfer.RecordWriter[viewmodel.PackChartRow]
+	startWriting := func() {
+		writeAttachmentHeaders(w, format, "pack_chart")
+		chartWriter = transfer.NewPackChartWriter(w, format)
+	}
+	chartErr := pc.packMediator.ChartPacks(r.Context(), request, func(row domain_model.PackChartRow) error {
+		if chartWriter == nil {
+			startWriting()
+		}
+		return chartWriter.Write(row.ToViewModel())
+	})
+	if chartErr != nil && chartWriter == nil {
+		if errors.Is(chartErr, mediator.ErrEmptyPackSet) {
+			http.Error(w, chartErr.Error(), http.StatusConflict)
+			return
+		}
+		http.Error(w, chartErr.Error(), http.StatusInternalServerError)
+		return
+	}
+	if chartErr != nil {
+		panic(http.ErrAbortHandler)
+	}
+
+	if chartWriter == nil {
+		startWriting()
+	}
+	chartWriter.Close()
+}
+
+func parsePackChartRequest(r *http.Request) (domain_model.PackChartRequest, error) {
+	query := r.URL.Query()
+	request := domain_model.PackChartRequest{Profile: query.Get("profile")}
+	var parseErr error
+	if query.Get("to") == "" {
+		return request, errors.New("to is required")
+	}
+	if request.To, parseErr = parseIntParam(query.Get("to"), 0, 1, mediator.MaxChartQuantity); parseErr != nil {
+		return request, fmt.Errorf("invalid to: %w", parseErr)
+	}
+	if request.From, parseErr = parseIntParam(query.Get("from"), 1, 1, request.To); parseErr != nil {
+		return request, fmt.Errorf("invalid from: %w", parseErr)
+	}
+	if request.Step, parseErr = parseIntParam(query.Get("step"), 1, 1, 0); parseErr != nil {
+		return request, fmt.Errorf("invalid step: %w", parseErr)
+	}
+	return request, nil
+}
@@ -11,8 +11,9 @@ import (
 
 	api "github.com/felipevillarrealdaza/go-service-template/internal/api/http"
 	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
 	mediator_mocks "github.com/felipevillarrealdaza/go-service-template/internal/mediator/mocks"
-	repository_mocks "github.com/felipevillarrealdaza/go-service-template/internal/repository/mocks"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -21,15 +22,22 @@ func Test_AddPack_OK(t *testing.T) {
 	// Arrange
 	orderMediatorMock := mediator_mocks.NewOrderMediator(t)
 	packMediatorMock := mediator_mocks.NewPackMediator(t)
-	repositoryMock := repository_mocks.NewQuerier(t)
-	router := api.NewRouter(packMediatorMock, orderMediatorMock, repositoryMock)
+	healthMediatorMock := mediator_mocks.NewHealthMediator(t)
+	authMediatorMock := newAuthMediatorMock(t, domain_model.RoleAdmin)
+	router := api.NewRouter(
+		api.WithPackMediator(packMediatorMock),
+		api.WithOrderMediator(orderMediatorMock),
+		api.WithHealthMediator(healthMediatorMock),
+		api.WithAuthMediator(authMediatorMock),
+	)
 	httpRecorder := httptest.NewRecorder()
 	reqBody := viewmodel.PackRequest{
 		Size: 2,
 	}
 	requestBytes, _ := json.Marshal(reqBody)
 	req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/pack", bytes.NewBuffer(requestBytes))
-	packMediatorMock.On("AddPack", mock.Anything, reqBody.Size).Return(nil)
+	req.Header.Set("X-API-Key", testApiKey)
+	packMediatorMock.On("AddPack", mock.Anything, "", reqBody.Size).Return(nil)
 
 	// Act
 	router.ServeHTTP(httpRecorder, req)
@@ -46,8 +54,14 @@ func Test_AddPack_Errors(t *testing.T) {
 	// Set up
 	orderMediatorMock := mediator_mocks.NewOrderMediator(t)
 	packMediatorMock := mediator_mocks.NewPackMediator(t)
-	repositoryMock := repository_mocks.NewQuerier(t)
-	router := api.NewRouter(packMediatorMock, orderMediatorMock, repositoryMock)
+	healthMediatorMock := mediator_mocks.NewHealthMediator(t)
+	authMediatorMock := newAuthMediatorMock(t, domain_model.RoleAdmin)
+	router := api.NewRouter(
+		api.WithPackMediator(packMediatorMock),
+		api.WithOrderMediator(orderMediatorMock),
+		api.WithHealthMediator(healthMediatorMock),
+		api.WithAuthMediator(authMediatorMock),
+	)
 
 	t.Run("Wrong JSON body", func(t *testing.T) {
 		// Arrange
@@ -57,6 +71,7 @@ func Test_AddPack_Errors(t *testing.T) {
 		}
 		requestBytes, _ := json.Marshal(reqBody)
 		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/pack", bytes.NewBuffer(requestBytes))
+		req.Header.Set("X-API-Key", testApiKey)
 
 		// Act
 		router.ServeHTTP(httpRecorder, req)
@@ -74,6 +89,7 @@ func Test_AddPack_Errors(t *testing.T) {
 		httpRecorder := httptest.NewRecorder()
 		requestBytes := []byte("{size: 2")
 		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/pack", bytes.NewBuffer(requestBytes))
+		req.Header.Set("X-API-Key", testApiKey)
 
 		// Act
 		router.ServeHTTP(httpRecorder, req)
@@ -94,7 +110,8 @@ func Test_AddPack_Errors(t *testing.T) {
 		}
 		requestBytes, _ := json.Marshal(reqBody)
 		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/pack", bytes.NewBuffer(requestBytes))
-		packMediatorMock.On("AddPack", mock.Anything, reqBody.Size).Return(errors.New("Pack already exists!"))
+		req.Header.Set("X-API-Key", testApiKey)
+		packMediatorMock.On("AddPack", mock.Anything, "", reqBody.Size).Return(mediator.ErrPackAlreadyExists)
 
 		// Act
 		router.ServeHTTP(httpRecorder, req)
@@ -112,8 +129,14 @@ func Test_MethodsNotAllowed(t *testing.T) {
 	// Set up
 	orderMediatorMock := mediator_mocks.NewOrderMediator(t)
 	packMediatorMock := mediator_mocks.NewPackMediator(t)
-	repositoryMock := repository_mocks.NewQuerier(t)
-	router := api.NewRouter(packMediatorMock, orderMediatorMock, repositoryMock)
+	healthMediatorMock := mediator_mocks.NewHealthMediator(t)
+	authMediatorMock := newAuthMediatorMock(t, domain_model.RoleAdmin)
+	router := api.NewRouter(
+		api.WithPackMediator(packMediatorMock),
+		api.WithOrderMediator(orderMediatorMock),
+		api.WithHealthMediator(healthMediatorMock),
+		api.WithAuthMediator(authMediatorMock),
+	)
 	httpRecorder := httptest.NewRecorder()
 
 	t.Run("Methods not implemented", func(t *testing.T) {
@@ -124,6 +147,7 @@ func Test_MethodsNotAllowed(t *testing.T) {
 			}
 			requestBytes, _ := json.Marshal(reqBody)
 			req, _ := http.NewRequestWithContext(context.Background(), httpVerb, "/api/v1/pack", bytes.NewBuffer(requestBytes))
+			req.Header.Set("X-API-Key", testApiKey)
 
 			// Act
 			router.ServeHTTP(httpRecorder, req)
@@ -142,15 +166,22 @@ func Test_RemovePack_OK(t *testing.T) {
 	// Arrange
 	orderMediatorMock := mediator_mocks.NewOrderMediator(t)
 	packMediatorMock := mediator_mocks.NewPackMediator(t)
-	repositoryMock := repository_mocks.NewQuerier(t)
-	router := api.NewRouter(packMediatorMock, orderMediatorMock, repositoryMock)
+	healthMediatorMock := mediator_mocks.NewHealthMediator(t)
+	authMediatorMock := newAuthMediatorMock(t, domain_model.RoleAdmin)
+	router := api.NewRouter(
+		api.WithPackMediator(packMediatorMock),
+		api.WithOrderMediator(orderMediatorMock),
+		api.WithHealthMediator(healthMediatorMock),
+		api.WithAuthMediator(authMediatorMock),
+	)
 	httpRecorder := httptest.NewRecorder()
 	reqBody := viewmodel.PackRequest{
 		Size: 2,
 	}
 	requestBytes, _ := json.Marshal(reqBody)
 	req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/api/v1/pack", bytes.NewBuffer(requestBytes))
-	packMediatorMock.On("RemovePack", mock.Anything, reqBody.Size).Return(nil)
+	req.Header.Set("X-API-Key", testApiKey)
+	packMediatorMock.On("RemovePack", mock.Anything, "", reqBody.Size).Return(nil)
 	// Act
 	router.ServeHTTP(httpRecorder, req)
 
@@ -166,8 +197,14 @@ func Test_RemovePack_Errors(t *testing.T) {
 	// Set up
 	orderMediatorMock := mediator_mocks.NewOrderMediator(t)
 	packMediatorMock := mediator_mocks.NewPackMediator(t)
-	repositoryMock := repository_mocks.NewQuerier(t)
-	router := api.NewRouter(packMediatorMock, orderMediatorMock, repositoryMock)
+	healthMediatorMock := mediator_mocks.NewHealthMediator(t)
+	authMediatorMock := newAuthMediatorMock(t, domain_model.RoleAdmin)
+	router := api.NewRouter(
+		api.WithPackMediator(packMediatorMock),
+		api.WithOrderMediator(orderMediatorMock),
+		api.WithHealthMediator(healthMediatorMock),
+		api.WithAuthMediator(authMediatorMock),
+	)
 
 	t.Run("Wrong JSON body", func(t *testing.T) {
 		// Arrange
@@ -177,6 +214,7 @@ func Test_RemovePack_Errors(t *testing.T) {
 		}
 		requestBytes, _ := json.Marshal(reqBody)
 		req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/api/v1/pack", bytes.NewBuffer(requestBytes))
+		req.Header.Set("X-API-Key", testApiKey)
 
 		// Act
 		router.ServeHTTP(httpRecorder, req)
@@ -194,6 +232,7 @@ func Test_RemovePack_Errors(t *testing.T) {
 		httpRecorder := httptest.NewRecorder()
 		requestBytes := []byte("{size: 2")
 		req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/api/v1/pack", bytes.NewBuffer(requestBytes))
+		req.Header.Set("X-API-Key", testApiKey)
 
 		// Act
 		router.ServeHTTP(httpRecorder, req)
@@ -214,7 +253,8 @@ func Test_RemovePack_Errors(t *testing.T) {
 		}
 		requestBytes, _ := json.Marshal(reqBody)
 		req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/api/v1/pack", bytes.NewBuffer(requestBytes))
-		packMediatorMock.On("RemovePack", mock.Anything, reqBody.Size).Return(errors.New("unexpected error happened"))
+		req.Header.Set("X-API-Key", testApiKey)
+		packMediatorMock.On("RemovePack", mock.Anything, "", reqBody.Size).Return(errors.New("unexpected error happened"))
 
 		// Act
 		router.ServeHTTP(httpRecorder, req)
@@ -226,4 +266,360 @@ func Test_RemovePack_Errors(t *testing.T) {
 		// Clean up
 		packMediatorMock.ExpectedCalls = make([]*mock.Call, 0)
 	})
+
+	t.Run("Pack not found", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		reqBody := viewmodel.PackRequest{
+			Size: 3,
+		}
+		requestBytes, _ := json.Marshal(reqBody)
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/api/v1/pack", bytes.NewBuffer(requestBytes))
+		req.Header.Set("X-API-Key", testApiKey)
+		packMediatorMock.On("RemovePack", mock.Anything, "", reqBody.Size).Return(mediator.ErrPackNotFound)
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusNotFound, httpRecorder.Code)
+		packMediatorMock.AssertExpectations(t)
+
+		// Clean up
+		packMediatorMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+}
+
+func Test_ExportPacks(t *testing.T) {
+	// Set Up
+	packMediatorMock := mediator_mocks.NewPackMediator(t)
+	router := api.NewRouter(
+		api.WithPackMediator(packMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleAdmin)),
+	)
+	newRequest := func(query string, accept string) *http.Request {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/pack/export"+query, nil)
+		req.Header.Set("X-API-Key", testApiKey)
+		req.Header.Set("Accept", accept)
+		return req
+	}
+
+	t.Run("CSV by Accept header", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		packMediatorMock.On("RetrievePacks", mock.Anything, "").Return([]int{500, 250}, nil).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest("", "text/csv"))
+
+		// Assert
+		require.Equal(t, http.StatusOK, httpRecorder.Code)
+		require.Equal(t, "text/csv", httpRecorder.Header().Get("Content-Type"))
+		require.Equal(t, `attachment; filename="packs.csv"`, httpRecorder.Header().Get("Content-Disposition"))
+		require.Equal(t, "size\n500\n250\n", httpRecorder.Body.String())
+	})
+
+	t.Run("JSON by default", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		packMediatorMock.On("RetrievePacks", mock.Anything, "").Return([]int{250}, nil).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest("", ""))
+
+		// Assert
+		require.Equal(t, http.StatusOK, httpRecorder.Code)
+		var response []viewmodel.PackExport
+		require.NoError(t, json.Unmarshal(httpRecorder.Body.Bytes(), &response))
+		require.Equal(t, []viewmodel.PackExport{{Size: 250}}, response)
+	})
+
+	t.Run("Unknown format", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest("?format=xml", ""))
+
+		// Assert
+		require.Equal(t, http.StatusBadRequest, httpRecorder.Code)
+	})
+
+	t.Run("Mediator error", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		packMediatorMock.On("RetrievePacks", mock.Anything, "").Return(nil, errors.New("connection reset")).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest("?format=csv", ""))
+
+		// Assert
+		require.Equal(t, http.StatusInternalServerError, httpRecorder.Code)
+	})
+}
+
+func Test_SimulatePackSet(t *testing.T) {
+	// Set Up
+	packMediatorMock := mediator_mocks.NewPackMediator(t)
+	router := api.NewRouter(
+		api.WithPackMediator(packMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleAdmin)),
+	)
+	newRequest := func(body string) *http.Request {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/pack/simulate", bytes.NewBufferString(body))
+		req.Header.Set("X-API-Key", testApiKey)
+		return req
+	}
+
+	t.Run("Report of the proposed sizes", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		packMediatorMock.
+			On("SimulatePackSet", mock.Anything, domain_model.PackSetSimulation{PackSizes: []int{250, 750}, PackCosts: map[int]float64{250: 0.4, 500: 0.6, 750: 0.8}}).
+			Return(domain_model.SimulationReport{
+				Costed:        true,
+				Orders:        1,
+				ChangedOrders: 1,
+				Current:       domain_model.PackSetOutcome{PackSet: []int{500, 250}, ItemsOrdered: 700, ItemsShipped: 750, Packs: 2, Cost: 1},
+				Proposed:      domain_model.PackSetOutcome{PackSet: []int{750, 250}, ItemsOrdered: 700, ItemsShipped: 750, Packs: 1, Cost: 0.8},
+			}, nil).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest(`{"sizes": [250, 750], "costs": [{"size": 250, "cost": 0.4}, {"size": 500, "cost": 0.6}, {"size": 750, "cost": 0.8}]}`))
+
+		// Assert
+		require.Equal(t, http.StatusOK, httpRecorder.Code)
+		var response viewmodel.SimulationResponse
+		require.NoError(t, json.Unmarshal(httpRecorder.Body.Bytes(), &response))
+		require.Equal(t, 700, response.ItemsOrdered)
+		require.Equal(t, 50, response.Proposed.Overage)
+		require.Equal(t, -1, response.Difference.Packs)
+		require.Equal(t, -0.2, *response.Difference.Cost)
+	})
+
+	t.Run("Invalid requests", func(t *testing.T) {
+		for _, body := range []string{`{"sizes": []}`, `{"sizes": [250, 250]}`, `{"sizes": [0]}`, `{"sizes": [250], "costs": [{"size": 250, "cost": -1}]}`} {
+			// Arrange
+			httpRecorder := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(httpRecorder, newRequest(body))
+
+			// Assert
+			require.Equal(t, http.StatusBadRequest, httpRecorder.Code, body)
+		}
+	})
+
+	t.Run("Missing costs", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		packMediatorMock.On("SimulatePackSet", mock.Anything, mock.Anything).Return(domain_model.SimulationReport{}, mediator.ErrPackCostMissing).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest(`{"sizes": [250], "costs": [{"size": 250, "cost": 0.4}]}`))
+
+		// Assert
+		require.Equal(t, http.StatusBadRequest, httpRecorder.Code)
+	})
+}
+
+func Test_RecommendPackSet(t *testing.T) {
+	// Set Up
+	packMediatorMock := mediator_mocks.NewPackMediator(t)
+	router := api.NewRouter(
+		api.WithPackMediator(packMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleAdmin)),
+	)
+	newRequest := func(body string) *http.Request {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/pack/recommend", bytes.NewBufferString(body))
+		req.Header.Set("X-API-Key", testApiKey)
+		return req
+	}
+
+	t.Run("Recommended set with candidate metrics", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		packMediatorMock.
+			On("RecommendPackSet", mock.Anything, domain_model.RecommendationRequest{MaxSizes: 2, Candidates: []int{250, 750}}).
+			Return(domain_model.PackSetRecommendation{
+				Evaluations: 3,
+				Recommended: domain_model.PackSetScore{PackSet: []int{750, 250}, Orders: 4, Overage: 0, Packs: 6},
+				Candidates:  []domain_model.CandidateMetrics{{Size: 250, Selected: true, OrdersUsing: 2, OverageChange: 1000, PacksChange: -1}},
+			}, nil).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest(`{"max_sizes": 2, "candidates": [250, 750]}`))
+
+		// Assert
+		require.Equal(t, http.StatusOK, httpRecorder.Code)
+		var response viewmodel.RecommendationResponse
+		require.NoError(t, json.Unmarshal(httpRecorder.Body.Bytes(), &response))
+		require.Equal(t, 4, response.Orders)
+		require.Equal(t, 1.5, response.Recommended.ExpectedPacks)
+		require.Equal(t, []viewmodel.CandidateMetrics{{Size: 250, Selected: true, OrdersUsing: 2, ExpectedOverageChange: 250, ExpectedPacksChange: -0.25}}, response.Candidates)
+	})
+
+	t.Run("Invalid requests", func(t *testing.T) {
+		for _, body := range []string{`{}`, `{"max_sizes": 21}`, `{"max_sizes": 2, "candidates": [0]}`, `{"max_sizes": 2, "max_evaluations": -1}`} {
+			// Arrange
+			httpRecorder := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(httpRecorder, newRequest(body))
+
+			// Assert
+			require.Equal(t, http.StatusBadRequest, httpRecorder.Code, body)
+		}
+	})
+
+	t.Run("No order history", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		packMediatorMock.On("RecommendPackSet", mock.Anything, mock.Anything).Return(domain_model.PackSetRecommendation{}, mediator.ErrNoOrderHistory).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest(`{"max_sizes": 2}`))
+
+		// Assert
+		require.Equal(t, http.StatusConflict, httpRecorder.Code)
+	})
+}
+
+func Test_AnalyzePackSet(t *testing.T) {
+	// Set Up
+	packMediatorMock := mediator_mocks.NewPackMediator(t)
+	router := api.NewRouter(
+		api.WithPackMediator(packMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleAdmin)),
+	)
+	newRequest := func(query string) *http.Request {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/pack/analysis"+query, nil)
+		req.Header.Set("X-API-Key", testApiKey)
+		return req
+	}
+
+	t.Run("Analysis of the given sizes", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		packMediatorMock.
+			On("AnalyzePackSet", mock.Anything, domain_model.PackSetAnalysisRequest{PackSizes: []int{250, 500}, UpTo: 1000}).
+			Return(domain_model.PackSetAnalysis{
+				PackSet:   []int{500, 250},
+				UpTo:      1000,
+				Gcd:       250,
+				ExactFits: 4,
+				Sizes:     []domain_model.PackSizeAnalysis{{Size: 500, QuantitiesUsing: 500, ExtraPacksWithout: 750}, {Size: 250, QuantitiesUsing: 750, OverageWithout: 1000}},
+			}, nil).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest("?sizes=250,500&up_to=1000"))
+
+		// Assert
+		require.Equal(t, http.StatusOK, httpRecorder.Code)
+		var response viewmodel.PackSetAnalysisResponse
+		require.NoError(t, json.Unmarshal(httpRecorder.Body.Bytes(), &response))
+		require.Nil(t, response.FrobeniusNumber)
+		require.Equal(t, 0.4, response.ExactFitPercentage)
+		require.True(t, response.Sizes[0].Redundant)
+		require.False(t, response.Sizes[1].Redundant)
+	})
+
+	t.Run("Invalid parameters", func(t *testing.T) {
+		for _, query := range []string{"?sizes=250,250", "?sizes=0", "?sizes=abc", "?sizes=2000000000,2000000001", "?up_to=0", "?up_to=100001"} {
+			// Arrange
+			httpRecorder := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(httpRecorder, newRequest(query))
+
+			// Assert
+			require.Equal(t, http.StatusBadRequest, httpRecorder.Code, query)
+		}
+	})
+
+	t.Run("No pack sizes", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		packMediatorMock.On("AnalyzePackSet", mock.Anything, domain_model.PackSetAnalysisRequest{}).Return(domain_model.PackSetAnalysis{}, mediator.ErrEmptyPackSet).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest(""))
+
+		// Assert
+		require.Equal(t, http.StatusConflict, httpRecorder.Code)
+	})
+}
+
+func Test_ChartPacks(t *testing.T) {
+	// Set Up
+	packMediatorMock := mediator_mocks.NewPackMediator(t)
+	router := api.NewRouter(
+		api.WithPackMediator(packMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleClient)),
+	)
+	newRequest := func(query string) *http.Request {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/pack/chart"+query, nil)
+		req.Header.Set("X-API-Key", testApiKey)
+		return req
+	}
+
+	t.Run("CSV chart", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		packMediatorMock.
+			On("ChartPacks", mock.Anything, domain_model.PackChartRequest{From: 250, To: 500, Step: 250}, mock.Anything).
+			Run(func(args mock.Arguments) {
+				writeRow := args.Get(2).(func(domain_model.PackChartRow) error)
+				writeRow(domain_model.PackChartRow{Quantity: 250, Packs: domain_model.OrderPack{250: 1, 500: 0}})
+				writeRow(domain_model.PackChartRow{Quantity: 500, Packs: domain_model.OrderPack{500: 1}})
+			}).
+			Return(nil).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest("?from=250&to=500&step=250&format=csv"))
+
+		// Assert
+		require.Equal(t, http.StatusOK, httpRecorder.Code)
+		require.Equal(t, `attachment; filename="pack_chart.csv"`, httpRecorder.Header().Get("Content-Disposition"))
+		require.Equal(t, "quantity,items,overage,pack_count,packs\n250,250,0,1,250x1\n500,500,0,1,500x1\n", httpRecorder.Body.String())
+	})
+
+	t.Run("Defaults to every quantity from 1", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		packMediatorMock.
+			On("ChartPacks", mock.Anything, domain_model.PackChartRequest{From: 1, To: 3, Step: 1}, mock.Anything).
+			Return(nil).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest("?to=3"))
+
+		// Assert
+		require.Equal(t, http.StatusOK, httpRecorder.Code)
+	})
+
+	t.Run("Invalid parameters", func(t *testing.T) {
+		for _, query := range []string{"", "?to=0", "?to=1000001", "?from=10&to=5", "?to=5&step=0", "?to=5&format=xml"} {
+			// Arrange
+			httpRecorder := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(httpRecorder, newRequest(query))
+
+			// Assert
+			require.Equal(t, http.StatusBadRequest, httpRecorder.Code, query)
+		}
+	})
+
+	t.Run("No pack sizes", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		packMediatorMock.On("ChartPacks", mock.Anything, mock.Anything, mock.Anything).Return(mediator.ErrEmptyPackSet).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest("?to=5"))
+
+		// Assert
+		require.Equal(t, http.StatusConflict, httpRecorder.Code)
+	})
 }
@@ -0,0 +1,164 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/shopspring/decimal"
+)
+
+var oneHundred = decimal.NewFromInt(100)
+
+// Dependency injection using optional pattern
+type PricingControllerDeps func(controller *pricingController)
+
+func WithPricingMediator(mediator mediator.PricingMediator) PricingControllerDeps {
+	return func(controller *pricingController) {
+		controller.pricingMediator = mediator
+	}
+}
+
+type PricingController interface {
+	UpdatePackPrice(w http.ResponseWriter, r *http.Request)
+	AddDiscountTier(w http.ResponseWriter, r *http.Request)
+	RetrieveDiscountTiers(w http.ResponseWriter, r *http.Request)
+	RemoveDiscountTier(w http.ResponseWriter, r *http.Request)
+}
+
+type pricingController struct {
+	pricingMediator mediator.PricingMediator
+	validate        *validator.Validate
+}
+
+func NewHttpPricingController(deps ...PricingControllerDeps) PricingController {
+	pricingController := pricingController{validate: validator.New(validator.WithRequiredStructEnabled())}
+	for _, opt := range deps {
+		opt(&pricingController)
+	}
+	return pricingController
+}
+
+func (pc pricingController) UpdatePackPrice(w http.ResponseWriter, r *http.Request) {
+	size, sizeErr := parseIntParam(mux.Vars(r)["size"], 0, 1, 0)
+	if sizeErr != nil {
+		http.Error(w, sizeErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Parse request to viewmodel
+	var requestBody viewmodel.PackPriceRequest
+	jsonErr := json.NewDecoder(r.Body).Decode(&requestBody)
+	if jsonErr != nil {
+		http.Error(w, jsonErr.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	validationErr := pc.validate.Struct(&requestBody)
+	if validationErr != nil {
+		http.Error(w, validationErr.Error(), http.StatusBadRequest)
+		return
+	}
+	price, priceErr := parseAmount(requestBody.Price, decimal.Zero, mediator.MaxPackPrice)
+	if priceErr != nil {
+		http.Error(w, priceErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	updated, updateErr := pc.pricingMediator.UpdatePackPrice(r.Context(), domain_model.PackPrice{PackSize: size, Price: price, Currency: requestBody.Currency, Profile: packProfile(r)})
+	if updateErr != nil {
+		if errors.Is(updateErr, mediator.ErrPackNotFound) {
+			http.Error(w, updateErr.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, updateErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJson(w, http.StatusOK, updated.ToViewModel())
+}
+
+func (pc pricingController) AddDiscountTier(w http.ResponseWriter, r *http.Request) {
+	// Parse request to viewmodel
+	var requestBody viewmodel.DiscountTierRequest
+	jsonErr := json.NewDecoder(r.Body).Decode(&requestBody)
+	if jsonErr != nil {
+		http.Error(w, jsonErr.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	validationErr := pc.validate.Struct(&requestBody)
+	if validationErr != nil {
+		http.Error(w, validationErr.Error(), http.StatusBadRequest)
+		return
+	}
+	percent, percentErr := parseAmount(requestBody.Percent, decimal.New(1, -2), oneHundred)
+	if percentErr != nil {
+		http.Error(w, percentErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	added, addErr := pc.pricingMediator.AddDiscountTier(r.Context(), domain_model.DiscountTier{MinQuantity: requestBody.MinQuantity, Percent: percent})
+	if addErr != nil {
+		if errors.Is(addErr, mediator.ErrDiscountTierAlreadyExists) {
+			http.Error(w, addErr.Error(), http.StatusConflict)
+			return
+		}
+		http.Error(w, addErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJson(w, http.StatusCreated, added.ToViewModel())
+}
+
+func (pc pricingController) RetrieveDiscountTiers(w http.ResponseWriter, r *http.Request) {
+	tiers, retrieveErr := pc.pricingMediator.RetrieveDiscountTiers(r.Context())
+	if retrieveErr != nil {
+		http.Error(w, retrieveErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]viewmodel.DiscountTierResponse, 0, len(tiers))
+	for _, tier := range tiers {
+		response = append(response, tier.ToViewModel())
+	}
+	writeJson(w, http.StatusOK, response)
+}
+
+func (pc pricingController) RemoveDiscountTier(w http.ResponseWriter, r *http.Request) {
+	minQuantity, minQuantityErr := parseIntParam(mux.Vars(r)["min_quantity"], 0, 1, 0)
+	if minQuantityErr != nil {
+		http.Error(w, minQuantityErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if removeErr := pc.pricingMediator.RemoveDiscountTier(r.Context(), minQuantity); removeErr != nil {
+		if errors.Is(removeErr, mediator.ErrDiscountTierNotFound) {
+			http.Error(w, removeErr.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, removeErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Parse a decimal amount of up to 2 decimals within the range, a zero max meaning unbounded
+func parseAmount(value string, min decimal.Decimal, max decimal.Decimal) (decimal.Decimal, error) {
+	amount, parseErr := decimal.NewFromString(value)
+	if parseErr != nil {
+		return decimal.Decimal{}, fmt.Errorf("[%v] is not a decimal number", value)
+	}
+	if !amount.Equal(amount.Round(2)) {
+		return decimal.Decimal{}, fmt.Errorf("[%v] has more than 2 decimals", value)
+	}
+	if amount.LessThan(min) || (!max.IsZero() && amount.GreaterThan(max)) {
+		return decimal.Decimal{}, fmt.Errorf("[%v] is out of range", value)
+	}
+	return amount, nil
+}
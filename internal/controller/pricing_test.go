@@ -0,0 +1,240 @@
+package controller_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	api "github.com/felipevillarrealdaza/go-service-template/internal/api/http"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	mediator_mocks "github.com/felipevillarrealdaza/go-service-template/internal/mediator/mocks"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UpdatePackPrice(t *testing.T) {
+	// Set Up
+	pricingMediatorMock := mediator_mocks.NewPricingMediator(t)
+	router := api.NewRouter(
+		api.WithPricingMediator(pricingMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleAdmin)),
+	)
+
+	t.Run("Updated", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/api/v1/pack/1000/price", bytes.NewBufferString(`{"price":"49.9"}`))
+		req.Header.Set("X-API-Key", testApiKey)
+		packPrice := domain_model.PackPrice{PackSize: 1000, Price: decimal.RequireFromString("49.9")}
+		pricingMediatorMock.On("UpdatePackPrice", mock.Anything, packPrice).Return(domain_model.PackPrice{PackSize: 1000, Price: packPrice.Price, Currency: "EUR"}, nil).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusOK, httpRecorder.Code)
+		require.JSONEq(t, `{"size":1000,"price":"49.90","currency":"EUR"}`, httpRecorder.Body.String())
+	})
+
+	t.Run("Updated in another currency", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/api/v1/pack/1000/price", bytes.NewBufferString(`{"price":"54.90","currency":"USD"}`))
+		req.Header.Set("X-API-Key", testApiKey)
+		packPrice := domain_model.PackPrice{PackSize: 1000, Price: decimal.RequireFromString("54.90"), Currency: "USD"}
+		pricingMediatorMock.On("UpdatePackPrice", mock.Anything, packPrice).Return(packPrice, nil).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusOK, httpRecorder.Code)
+		require.JSONEq(t, `{"size":1000,"price":"54.90","currency":"USD"}`, httpRecorder.Body.String())
+	})
+
+	t.Run("Pack not found", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/api/v1/pack/750/price", bytes.NewBufferString(`{"price":"10"}`))
+		req.Header.Set("X-API-Key", testApiKey)
+		pricingMediatorMock.On("UpdatePackPrice", mock.Anything, mock.Anything).
+			Return(domain_model.PackPrice{}, errors.Wrap(mediator.ErrPackNotFound, "could not update price of pack size [750]")).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusNotFound, httpRecorder.Code)
+	})
+
+	invalidBodies := map[string]string{
+		"Not a number":        `{"price":"ten"}`,
+		"Negative":            `{"price":"-1.00"}`,
+		"More than 2 decimal": `{"price":"1.005"}`,
+		"Above column range":  `{"price":"10000000000.00"}`,
+		"Lowercase currency":  `{"price":"1.00","currency":"eur"}`,
+		"Missing":             `{}`,
+	}
+	for name, body := range invalidBodies {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			httpRecorder := httptest.NewRecorder()
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/api/v1/pack/1000/price", bytes.NewBufferString(body))
+			req.Header.Set("X-API-Key", testApiKey)
+
+			// Act
+			router.ServeHTTP(httpRecorder, req)
+
+			// Assert
+			require.Equal(t, http.StatusBadRequest, httpRecorder.Code)
+		})
+	}
+}
+
+func Test_AddDiscountTier(t *testing.T) {
+	// Set Up
+	pricingMediatorMock := mediator_mocks.NewPricingMediator(t)
+	router := api.NewRouter(
+		api.WithPricingMediator(pricingMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleAdmin)),
+	)
+
+	t.Run("Created", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/discount", bytes.NewBufferString(`{"min_quantity":2000,"percent":"7.5"}`))
+		req.Header.Set("X-API-Key", testApiKey)
+		tier := domain_model.DiscountTier{MinQuantity: 2000, Percent: decimal.RequireFromString("7.5")}
+		pricingMediatorMock.On("AddDiscountTier", mock.Anything, tier).Return(tier, nil).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusCreated, httpRecorder.Code)
+		require.JSONEq(t, `{"min_quantity":2000,"percent":"7.50","created_at":"0001-01-01T00:00:00Z"}`, httpRecorder.Body.String())
+	})
+
+	t.Run("Already exists", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/discount", bytes.NewBufferString(`{"min_quantity":2000,"percent":"5"}`))
+		req.Header.Set("X-API-Key", testApiKey)
+		pricingMediatorMock.On("AddDiscountTier", mock.Anything, mock.Anything).
+			Return(domain_model.DiscountTier{}, errors.Wrap(mediator.ErrDiscountTierAlreadyExists, "could not add discount tier from [2000] items")).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusConflict, httpRecorder.Code)
+	})
+
+	invalidBodies := map[string]string{
+		"Zero percent":        `{"min_quantity":2000,"percent":"0"}`,
+		"Above 100 percent":   `{"min_quantity":2000,"percent":"100.01"}`,
+		"No minimum quantity": `{"percent":"5"}`,
+	}
+	for name, body := range invalidBodies {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			httpRecorder := httptest.NewRecorder()
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/discount", bytes.NewBufferString(body))
+			req.Header.Set("X-API-Key", testApiKey)
+
+			// Act
+			router.ServeHTTP(httpRecorder, req)
+
+			// Assert
+			require.Equal(t, http.StatusBadRequest, httpRecorder.Code)
+		})
+	}
+}
+
+func Test_RemoveDiscountTier(t *testing.T) {
+	// Set Up
+	pricingMediatorMock := mediator_mocks.NewPricingMediator(t)
+	router := api.NewRouter(
+		api.WithPricingMediator(pricingMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleAdmin)),
+	)
+
+	t.Run("Removed", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/api/v1/discount/2000", nil)
+		req.Header.Set("X-API-Key", testApiKey)
+		pricingMediatorMock.On("RemoveDiscountTier", mock.Anything, 2000).Return(nil).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusNoContent, httpRecorder.Code)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/api/v1/discount/3000", nil)
+		req.Header.Set("X-API-Key", testApiKey)
+		pricingMediatorMock.On("RemoveDiscountTier", mock.Anything, 3000).
+			Return(errors.Wrap(mediator.ErrDiscountTierNotFound, "could not remove discount tier from [3000] items")).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusNotFound, httpRecorder.Code)
+	})
+}
+
+func Test_AddOrder_Price(t *testing.T) {
+	// Set Up
+	orderMediatorMock := mediator_mocks.NewOrderMediator(t)
+	router := api.NewRouter(
+		api.WithOrderMediator(orderMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleClient)),
+	)
+	httpRecorder := httptest.NewRecorder()
+
+	// Arrange
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/order", bytes.NewBufferString(`{"quantity":2000}`))
+	req.Header.Set("X-API-Key", testApiKey)
+	orderPacks := domain_model.OrderPacks{
+		OrderQuantity: 2000,
+		ResultGrid:    map[int]domain_model.OrderPack{2000: {1000: 2}},
+		Price: &domain_model.OrderPrice{
+			Currency:        "EUR",
+			Lines:           []domain_model.PriceLine{{PackSize: 1000, Quantity: 2, UnitPrice: decimal.RequireFromString("49.99"), LineTotal: decimal.RequireFromString("99.98")}},
+			Subtotal:        decimal.RequireFromString("99.98"),
+			DiscountPercent: decimal.RequireFromString("7.5"),
+			Discount:        decimal.RequireFromString("7.50"),
+			Total:           decimal.RequireFromString("92.48"),
+		},
+	}
+	orderMediatorMock.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
+	orderMediatorMock.On("CalculateOrderPacks", mock.Anything, mock.Anything).Return(orderPacks, nil)
+
+	// Act
+	router.ServeHTTP(httpRecorder, req)
+
+	// Assert
+	require.Equal(t, http.StatusCreated, httpRecorder.Code)
+	var response map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(httpRecorder.Body.Bytes(), &response))
+	require.JSONEq(t, `{
+		"currency":"EUR",
+		"lines":[{"size":1000,"quantity":2,"unit_price":"49.99","line_total":"99.98"}],
+		"subtotal":"99.98",
+		"discount_percent":"7.50",
+		"discount":"7.50",
+		"total":"92.48"
+	}`, string(response["price"]))
+}
@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+)
+
+func (pc packController) AddPackProfile(w http.ResponseWriter, r *http.Request) {
+	// Parse request to viewmodel
+	var requestBody viewmodel.PackProfileRequest
+	jsonErr := json.NewDecoder(r.Body).Decode(&requestBody)
+	if jsonErr != nil {
+		http.Error(w, jsonErr.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	validationErr := pc.validate.Struct(&requestBody)
+	if validationErr != nil {
+		http.Error(w, validationErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	added, addErr := pc.packMediator.AddPackProfile(r.Context(), requestBody.Name)
+	if addErr != nil {
+		switch {
+		case errors.Is(addErr, mediator.ErrInvalidPackProfile):
+			http.Error(w, addErr.Error(), http.StatusBadRequest)
+		case errors.Is(addErr, mediator.ErrPackProfileAlreadyExists):
+			http.Error(w, addErr.Error(), http.StatusConflict)
+		default:
+			http.Error(w, addErr.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	writeJson(w, http.StatusCreated, added.ToViewModel())
+}
+
+func (pc packController) RetrievePackProfiles(w http.ResponseWriter, r *http.Request) {
+	profiles, retrieveErr := pc.packMediator.RetrievePackProfiles(r.Context())
+	if retrieveErr != nil {
+		http.Error(w, retrieveErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]viewmodel.PackProfileResponse, 0, len(profiles))
+	for _, profile := range profiles {
+		response = append(response, profile.ToViewModel())
+	}
+	writeJson(w, http.StatusOK, response)
+}
+
+// Profile of the packs named in the query, empty for the default profile
+func packProfile(r *http.Request) string {
+	return r.URL.Query().Get("profile")
+}
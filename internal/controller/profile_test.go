@@ -0,0 +1,164 @@
+package controller_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	api "github.com/felipevillarrealdaza/go-service-template/internal/api/http"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	mediator_mocks "github.com/felipevillarrealdaza/go-service-template/internal/mediator/mocks"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AddPackProfile(t *testing.T) {
+	// Set Up
+	packMediatorMock := mediator_mocks.NewPackMediator(t)
+	router := api.NewRouter(
+		api.WithPackMediator(packMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleAdmin)),
+	)
+
+	t.Run("Created", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/profile", bytes.NewBufferString(`{"name":"wholesale"}`))
+		req.Header.Set("X-API-Key", testApiKey)
+		createdAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+		packMediatorMock.On("AddPackProfile", mock.Anything, "wholesale").Return(domain_model.PackProfile{Name: "wholesale", CreatedAt: createdAt}, nil).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusCreated, httpRecorder.Code)
+		require.JSONEq(t, `{"name":"wholesale","created_at":"2024-03-01T12:00:00Z"}`, httpRecorder.Body.String())
+	})
+
+	useCases := []struct {
+		name           string
+		addErr         error
+		expectedStatus int
+	}{
+		{name: "Invalid name", addErr: errors.Wrap(mediator.ErrInvalidPackProfile, "[Wholesale]"), expectedStatus: http.StatusBadRequest},
+		{name: "Already exists", addErr: errors.Wrap(mediator.ErrPackProfileAlreadyExists, "could not add pack profile"), expectedStatus: http.StatusConflict},
+		{name: "Internal error", addErr: errors.New("connection refused"), expectedStatus: http.StatusInternalServerError},
+	}
+	for _, useCase := range useCases {
+		t.Run(useCase.name, func(t *testing.T) {
+			// Arrange
+			httpRecorder := httptest.NewRecorder()
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/profile", bytes.NewBufferString(`{"name":"Wholesale"}`))
+			req.Header.Set("X-API-Key", testApiKey)
+			packMediatorMock.On("AddPackProfile", mock.Anything, "Wholesale").Return(domain_model.PackProfile{}, useCase.addErr).Once()
+
+			// Act
+			router.ServeHTTP(httpRecorder, req)
+
+			// Assert
+			require.Equal(t, useCase.expectedStatus, httpRecorder.Code)
+		})
+	}
+
+	t.Run("Missing name", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/profile", bytes.NewBufferString(`{}`))
+		req.Header.Set("X-API-Key", testApiKey)
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusBadRequest, httpRecorder.Code)
+	})
+}
+
+func Test_RetrievePackProfiles(t *testing.T) {
+	// Set Up
+	packMediatorMock := mediator_mocks.NewPackMediator(t)
+	router := api.NewRouter(
+		api.WithPackMediator(packMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleAdmin)),
+	)
+
+	// Arrange
+	httpRecorder := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/profile", nil)
+	req.Header.Set("X-API-Key", testApiKey)
+	createdAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	packMediatorMock.On("RetrievePackProfiles", mock.Anything).Return([]domain_model.PackProfile{
+		{Name: "default", CreatedAt: createdAt},
+		{Name: "wholesale", CreatedAt: createdAt},
+	}, nil).Once()
+
+	// Act
+	router.ServeHTTP(httpRecorder, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, httpRecorder.Code)
+	require.JSONEq(t, `[
+		{"name":"default","created_at":"2024-03-01T12:00:00Z"},
+		{"name":"wholesale","created_at":"2024-03-01T12:00:00Z"}
+	]`, httpRecorder.Body.String())
+}
+
+func Test_PackProfileNotFound(t *testing.T) {
+	// Set Up
+	orderMediatorMock := mediator_mocks.NewOrderMediator(t)
+	packMediatorMock := mediator_mocks.NewPackMediator(t)
+	router := api.NewRouter(
+		api.WithOrderMediator(orderMediatorMock),
+		api.WithPackMediator(packMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleAdmin)),
+	)
+
+	t.Run("Order", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/order", bytes.NewBufferString(`{"quantity":500,"profile":"retail"}`))
+		req.Header.Set("X-API-Key", testApiKey)
+		orderMediatorMock.On("CreateOrder", mock.Anything, mock.MatchedBy(func(order domain_model.Order) bool { return order.Profile == "retail" })).
+			Return(errors.Wrap(mediator.ErrPackProfileNotFound, "could not add order")).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusNotFound, httpRecorder.Code)
+	})
+
+	t.Run("Pack", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/pack?profile=retail", bytes.NewBufferString(`{"size":250}`))
+		req.Header.Set("X-API-Key", testApiKey)
+		packMediatorMock.On("AddPack", mock.Anything, "retail", 250).Return(errors.Wrap(mediator.ErrPackProfileNotFound, "could not add pack")).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusNotFound, httpRecorder.Code)
+	})
+
+	t.Run("Quote", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/quote", bytes.NewBufferString(`{"quantity":500,"profile":"retail"}`))
+		req.Header.Set("X-API-Key", testApiKey)
+		orderMediatorMock.On("CreateQuote", mock.Anything, "retail", 500).Return(domain_model.Quote{}, errors.Wrap(mediator.ErrPackProfileNotFound, "could not add quote")).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusNotFound, httpRecorder.Code)
+	})
+}
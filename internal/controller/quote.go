@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+func (oc orderController) AddQuote(w http.ResponseWriter, r *http.Request) {
+	// Validate JSON and request body
+	var requestBody viewmodel.QuoteRequest
+	jsonErr := json.NewDecoder(r.Body).Decode(&requestBody)
+	if jsonErr != nil {
+		http.Error(w, jsonErr.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	validationErr := oc.validate.Struct(&requestBody)
+	if validationErr != nil {
+		http.Error(w, validationErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	quote, quoteErr := oc.orderMediator.CreateQuote(r.Context(), requestBody.Profile, requestBody.OrderQuantity)
+	if quoteErr != nil {
+		if errors.Is(quoteErr, mediator.ErrPackProfileNotFound) {
+			http.Error(w, quoteErr.Error(), http.StatusNotFound)
+			return
+		}
+		if errors.Is(quoteErr, mediator.ErrNoShippablePacks) {
+			http.Error(w, quoteErr.Error(), http.StatusConflict)
+			return
+		}
+		if errors.Is(quoteErr, mediator.ErrOrderPriceTooHigh) {
+			http.Error(w, quoteErr.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, quoteErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJson(w, http.StatusCreated, quote.ToViewModel())
+}
+
+func (oc orderController) AcceptQuote(w http.ResponseWriter, r *http.Request) {
+	quoteId, parseErr := uuid.Parse(mux.Vars(r)["id"])
+	if parseErr != nil {
+		http.Error(w, parseErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	orderPacks, acceptErr := oc.orderMediator.AcceptQuote(r.Context(), quoteId)
+	if acceptErr != nil {
+		switch {
+		case errors.Is(acceptErr, mediator.ErrQuoteNotFound):
+			http.Error(w, acceptErr.Error(), http.StatusNotFound)
+		case errors.Is(acceptErr, mediator.ErrQuoteExpired):
+			http.Error(w, acceptErr.Error(), http.StatusGone)
+		case errors.Is(acceptErr, mediator.ErrQuoteAlreadyAccepted):
+			http.Error(w, acceptErr.Error(), http.StatusConflict)
+		default:
+			http.Error(w, acceptErr.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	writeJson(w, http.StatusCreated, orderPacks.ToViewModel())
+}
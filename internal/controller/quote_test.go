@@ -0,0 +1,156 @@
+package controller_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	api "github.com/felipevillarrealdaza/go-service-template/internal/api/http"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	mediator_mocks "github.com/felipevillarrealdaza/go-service-template/internal/mediator/mocks"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AddQuote(t *testing.T) {
+	// Set Up
+	orderMediatorMock := mediator_mocks.NewOrderMediator(t)
+	router := api.NewRouter(
+		api.WithOrderMediator(orderMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleClient)),
+	)
+
+	t.Run("Created", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/quote", bytes.NewBufferString(`{"quantity":2000}`))
+		req.Header.Set("X-API-Key", testApiKey)
+		quoteId := uuid.MustParse("5f0c7a43-9c8e-4a3f-8d29-6a1f0b3c2e11")
+		quote := domain_model.Quote{
+			QuoteId:   quoteId,
+			Quantity:  2000,
+			Profile:   "default",
+			Packs:     domain_model.OrderPack{1000: 2},
+			ExpiresAt: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		}
+		orderMediatorMock.On("CreateQuote", mock.Anything, "", 2000).Return(quote, nil).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusCreated, httpRecorder.Code)
+		require.JSONEq(t, `{
+			"id":"5f0c7a43-9c8e-4a3f-8d29-6a1f0b3c2e11",
+			"quantity":2000,
+			"profile":"default",
+			"packs":[{"size":1000,"quantity":2}],
+			"expires_at":"2024-03-01T12:00:00Z"
+		}`, httpRecorder.Body.String())
+	})
+
+	t.Run("No pack can be shipped", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/quote", bytes.NewBufferString(`{"quantity":2000}`))
+		req.Header.Set("X-API-Key", testApiKey)
+		orderMediatorMock.On("CreateQuote", mock.Anything, "", 2000).
+			Return(domain_model.Quote{}, errors.Wrap(mediator.ErrNoShippablePacks, "could not retrieve available packs")).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusConflict, httpRecorder.Code)
+	})
+
+	t.Run("Quantity of zero", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/quote", bytes.NewBufferString(`{"quantity":0}`))
+		req.Header.Set("X-API-Key", testApiKey)
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusBadRequest, httpRecorder.Code)
+	})
+}
+
+func Test_AcceptQuote(t *testing.T) {
+	// Set Up
+	orderMediatorMock := mediator_mocks.NewOrderMediator(t)
+	router := api.NewRouter(
+		api.WithOrderMediator(orderMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleClient)),
+	)
+
+	t.Run("Accepted", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		quoteId := uuid.New()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/quote/"+quoteId.String()+"/accept", nil)
+		req.Header.Set("X-API-Key", testApiKey)
+		orderPacks := domain_model.OrderPacks{
+			OrderId:          uuid.New(),
+			OrderQuantity:    2000,
+			ResultGrid:       map[int]domain_model.OrderPack{2000: {1000: 2}},
+			OptimalOrderPack: domain_model.OrderPack{1000: 2},
+		}
+		orderMediatorMock.On("AcceptQuote", mock.Anything, quoteId).Return(orderPacks, nil).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusCreated, httpRecorder.Code)
+		require.Contains(t, httpRecorder.Body.String(), orderPacks.OrderId.String())
+	})
+
+	useCases := []struct {
+		name           string
+		acceptErr      error
+		expectedStatus int
+	}{
+		{name: "Not found", acceptErr: mediator.ErrQuoteNotFound, expectedStatus: http.StatusNotFound},
+		{name: "Expired", acceptErr: errors.Wrap(mediator.ErrQuoteExpired, "expired"), expectedStatus: http.StatusGone},
+		{name: "Already accepted", acceptErr: errors.Wrap(mediator.ErrQuoteAlreadyAccepted, "accepted"), expectedStatus: http.StatusConflict},
+		{name: "Internal error", acceptErr: errors.New("connection refused"), expectedStatus: http.StatusInternalServerError},
+	}
+	for _, useCase := range useCases {
+		t.Run(useCase.name, func(t *testing.T) {
+			// Arrange
+			httpRecorder := httptest.NewRecorder()
+			quoteId := uuid.New()
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/quote/"+quoteId.String()+"/accept", nil)
+			req.Header.Set("X-API-Key", testApiKey)
+			orderMediatorMock.On("AcceptQuote", mock.Anything, quoteId).Return(domain_model.OrderPacks{}, useCase.acceptErr).Once()
+
+			// Act
+			router.ServeHTTP(httpRecorder, req)
+
+			// Assert
+			require.Equal(t, useCase.expectedStatus, httpRecorder.Code)
+		})
+	}
+
+	t.Run("Invalid id", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/quote/not-a-uuid/accept", nil)
+		req.Header.Set("X-API-Key", testApiKey)
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusBadRequest, httpRecorder.Code)
+	})
+}
@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/transfer"
+)
+
+// Marshal the response body and write it along with the status code
+func writeJson(w http.ResponseWriter, statusCode int, body any) {
+	response, marshalErr := json.Marshal(body)
+	if marshalErr != nil {
+		http.Error(w, marshalErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	w.Write(response)
+}
+
+// Format asked for in the format query parameter, or else in the Accept header, JSON by default
+func responseFormat(r *http.Request) (transfer.Format, error) {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return transfer.ParseFormat(format)
+	}
+	if strings.Contains(r.Header.Get("Accept"), transfer.FormatCsv.ContentType()) {
+		return transfer.FormatCsv, nil
+	}
+	return transfer.FormatJson, nil
+}
+
+// Headers of a downloaded file, the status is written along with them
+func writeAttachmentHeaders(w http.ResponseWriter, format transfer.Format, name string) {
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", `attachment; filename="`+name+`.`+string(format)+`"`)
+	w.WriteHeader(http.StatusOK)
+}
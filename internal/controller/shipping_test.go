@@ -0,0 +1,86 @@
+package controller_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	api "github.com/felipevillarrealdaza/go-service-template/internal/api/http"
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	mediator_mocks "github.com/felipevillarrealdaza/go-service-template/internal/mediator/mocks"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UpdatePackSpecification(t *testing.T) {
+	// Set Up
+	packMediatorMock := mediator_mocks.NewPackMediator(t)
+	router := api.NewRouter(
+		api.WithPackMediator(packMediatorMock),
+		api.WithAuthMediator(newAuthMediatorMock(t, domain_model.RoleAdmin)),
+	)
+	specification := domain_model.PackSpecification{PackSize: 500, MaxWeightGrams: 15000, LengthMm: 400, WidthMm: 300, HeightMm: 200}
+
+	t.Run("Updated", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		body := []byte(`{"max_weight_grams":15000,"length_mm":400,"width_mm":300,"height_mm":200}`)
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/api/v1/pack/500", bytes.NewReader(body))
+		req.Header.Set("X-API-Key", testApiKey)
+		packMediatorMock.On("UpdatePackSpecification", mock.Anything, specification).Return(specification, nil).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusOK, httpRecorder.Code)
+		var response viewmodel.PackSpecificationResponse
+		require.NoError(t, json.Unmarshal(httpRecorder.Body.Bytes(), &response))
+		require.Equal(t, specification.ToViewModel(), response)
+	})
+
+	t.Run("Pack not found", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+		body := []byte(`{"max_weight_grams":15000}`)
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/api/v1/pack/750", bytes.NewReader(body))
+		req.Header.Set("X-API-Key", testApiKey)
+		packMediatorMock.On("UpdatePackSpecification", mock.Anything, domain_model.PackSpecification{PackSize: 750, MaxWeightGrams: 15000}).
+			Return(domain_model.PackSpecification{}, errors.Wrap(mediator.ErrPackNotFound, "could not update pack of size [750]")).Once()
+
+		// Act
+		router.ServeHTTP(httpRecorder, req)
+
+		// Assert
+		require.Equal(t, http.StatusNotFound, httpRecorder.Code)
+	})
+
+	invalidRequests := map[string]struct {
+		path string
+		body string
+	}{
+		"Negative weight":   {path: "/api/v1/pack/500", body: `{"max_weight_grams":-1}`},
+		"Pack size of zero": {path: "/api/v1/pack/0", body: `{"max_weight_grams":15000}`},
+		"Pack size not int": {path: "/api/v1/pack/big", body: `{"max_weight_grams":15000}`},
+	}
+	for name, invalidRequest := range invalidRequests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			httpRecorder := httptest.NewRecorder()
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, invalidRequest.path, bytes.NewReader([]byte(invalidRequest.body)))
+			req.Header.Set("X-API-Key", testApiKey)
+
+			// Act
+			router.ServeHTTP(httpRecorder, req)
+
+			// Assert
+			require.Equal(t, http.StatusBadRequest, httpRecorder.Code)
+		})
+	}
+}
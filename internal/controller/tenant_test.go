@@ -0,0 +1,133 @@
+package controller_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	api "github.com/felipevillarrealdaza/go-service-template/internal/api/http"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	mediator_mocks "github.com/felipevillarrealdaza/go-service-template/internal/mediator/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	boundApiKey   = "bound-api-key"
+	unboundClient = "unbound-client-key"
+)
+
+func Test_Tenant(t *testing.T) {
+	// Set Up
+	packMediatorMock := mediator_mocks.NewPackMediator(t)
+	authMediatorMock := newAuthMediatorMock(t, domain_model.RoleAdmin)
+	authMediatorMock.
+		On("Authenticate", mock.Anything, domain_model.Credentials{ApiKey: boundApiKey}).
+		Return(domain_model.Principal{Subject: "acme", Role: domain_model.RoleAdmin, Method: domain_model.AuthMethodApiKey, Tenant: "acme"}, nil).
+		Maybe()
+	authMediatorMock.
+		On("Authenticate", mock.Anything, domain_model.Credentials{ApiKey: unboundClient}).
+		Return(domain_model.Principal{Subject: "scanner", Role: domain_model.RoleClient, Method: domain_model.AuthMethodJwt}, nil).
+		Maybe()
+	orderMediatorMock := mediator_mocks.NewOrderMediator(t)
+	router := api.NewRouter(
+		api.WithPackMediator(packMediatorMock),
+		api.WithOrderMediator(orderMediatorMock),
+		api.WithAuthMediator(authMediatorMock),
+	)
+	newRequest := func(apiKey string, tenant string) *http.Request {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/pack/export", nil)
+		req.Header.Set("X-API-Key", apiKey)
+		if tenant != "" {
+			req.Header.Set("X-Tenant-ID", tenant)
+		}
+		return req
+	}
+	ofTenant := func(tenant string) interface{} {
+		return mock.MatchedBy(func(ctx context.Context) bool { return domain_model.TenantFromContext(ctx) == tenant })
+	}
+
+	useCases := []struct {
+		name           string
+		apiKey         string
+		header         string
+		expectedTenant string
+	}{
+		{name: "Default tenant without header", apiKey: testApiKey, expectedTenant: "default"},
+		{name: "Tenant of the header", apiKey: testApiKey, header: "globex", expectedTenant: "globex"},
+		{name: "Tenant of the bound key", apiKey: boundApiKey, expectedTenant: "acme"},
+		{name: "Header matching the bound key", apiKey: boundApiKey, header: "acme", expectedTenant: "acme"},
+	}
+	for _, useCase := range useCases {
+		t.Run(useCase.name, func(t *testing.T) {
+			// Arrange
+			httpRecorder := httptest.NewRecorder()
+			packMediatorMock.On("RetrievePacks", ofTenant(useCase.expectedTenant), "").Return([]int{250}, nil).Once()
+
+			// Act
+			router.ServeHTTP(httpRecorder, newRequest(useCase.apiKey, useCase.header))
+
+			// Assert
+			require.Equal(t, http.StatusOK, httpRecorder.Code)
+			packMediatorMock.AssertExpectations(t)
+		})
+	}
+
+	t.Run("Other tenant than the bound key", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest(boundApiKey, "globex"))
+
+		// Assert
+		require.Equal(t, http.StatusForbidden, httpRecorder.Code)
+	})
+
+	clientUseCases := []struct {
+		name           string
+		header         string
+		expectedStatus int
+	}{
+		{name: "Unbound client without header", expectedStatus: http.StatusOK},
+		{name: "Unbound client naming the default tenant", header: "default", expectedStatus: http.StatusOK},
+		{name: "Unbound client naming another tenant", header: "globex", expectedStatus: http.StatusForbidden},
+	}
+	for _, useCase := range clientUseCases {
+		t.Run(useCase.name, func(t *testing.T) {
+			// Arrange
+			httpRecorder := httptest.NewRecorder()
+			orderId := uuid.New()
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPatch, "/api/v1/order/"+orderId.String()+"/status", bytes.NewBufferString(`{"status":"picked"}`))
+			req.Header.Set("X-API-Key", unboundClient)
+			if useCase.header != "" {
+				req.Header.Set("X-Tenant-ID", useCase.header)
+			}
+			if useCase.expectedStatus == http.StatusOK {
+				orderMediatorMock.On("TransitionOrderStatus", ofTenant("default"), orderId, domain_model.OrderStatusPicked).
+					Return(domain_model.Order{OrderId: orderId, Status: domain_model.OrderStatusPicked}, nil).Once()
+			}
+
+			// Act
+			router.ServeHTTP(httpRecorder, req)
+
+			// Assert
+			require.Equal(t, useCase.expectedStatus, httpRecorder.Code)
+			orderMediatorMock.AssertExpectations(t)
+		})
+	}
+
+	t.Run("Invalid tenant", func(t *testing.T) {
+		// Arrange
+		httpRecorder := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(httpRecorder, newRequest(testApiKey, "Globex Corp"))
+
+		// Assert
+		require.Equal(t, http.StatusBadRequest, httpRecorder.Code)
+	})
+}
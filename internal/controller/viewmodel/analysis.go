@@ -0,0 +1,22 @@
+package viewmodel
+
+type PackSizeAnalysis struct {
+	Size              int  `json:"size"`
+	QuantitiesUsing   int  `json:"quantities_using"`
+	OverageWithout    int  `json:"overage_without"`
+	ExtraPacksWithout int  `json:"extra_packs_without"`
+	Unused            bool `json:"unused"`
+	Redundant         bool `json:"redundant"`
+}
+
+type PackSetAnalysisResponse struct {
+	PackSet            []int              `json:"pack_set"`
+	UpTo               int                `json:"up_to"`
+	Gcd                int                `json:"gcd"`
+	FrobeniusNumber    *int               `json:"frobenius_number"`
+	ExactFits          int                `json:"exact_fits"`
+	ExactFitPercentage float64            `json:"exact_fit_percentage"`
+	MaxOverage         int                `json:"max_overage"`
+	MaxOverageQuantity int                `json:"max_overage_quantity"`
+	Sizes              []PackSizeAnalysis `json:"sizes"`
+}
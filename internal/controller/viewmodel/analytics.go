@@ -0,0 +1,44 @@
+package viewmodel
+
+import "time"
+
+type AnalyticsRange struct {
+	From *time.Time `json:"from"`
+	To   *time.Time `json:"to"`
+}
+
+type OrderSummaryResponse struct {
+	AnalyticsRange
+	Orders               int     `json:"orders"`
+	ItemsOrdered         int     `json:"items_ordered"`
+	ItemsShipped         int     `json:"items_shipped"`
+	OverageItems         int     `json:"overage_items"`
+	OveragePercentage    float64 `json:"overage_percentage"`
+	Packs                int     `json:"packs"`
+	AveragePacksPerOrder float64 `json:"average_packs_per_order"`
+}
+
+type PackUsage struct {
+	Size            int     `json:"size"`
+	Packs           int     `json:"packs"`
+	Orders          int     `json:"orders"`
+	Items           int     `json:"items"`
+	SharePercentage float64 `json:"share_percentage"`
+}
+
+type PackUsageResponse struct {
+	AnalyticsRange
+	Packs []PackUsage `json:"packs"`
+}
+
+type QuantityBucket struct {
+	From   int `json:"from"`
+	To     int `json:"to"`
+	Orders int `json:"orders"`
+}
+
+type QuantityHistogramResponse struct {
+	AnalyticsRange
+	BucketSize int              `json:"bucket_size"`
+	Buckets    []QuantityBucket `json:"buckets"`
+}
@@ -0,0 +1,27 @@
+package viewmodel
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ApiKeyRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+	Role string `json:"role" validate:"required,oneof=admin client"`
+}
+
+type ApiKeyResponse struct {
+	ApiKeyId  uuid.UUID  `json:"id"`
+	Name      string     `json:"name"`
+	Role      string     `json:"role"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	Tenant    string     `json:"tenant,omitempty"`
+}
+
+// The plain key is only returned once, when it is created
+type CreatedApiKeyResponse struct {
+	ApiKeyResponse
+	Key string `json:"key"`
+}
@@ -0,0 +1,17 @@
+package viewmodel
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PackAuditResponse struct {
+	AuditId   uuid.UUID `json:"id"`
+	Actor     string    `json:"actor"`
+	Action    string    `json:"action"`
+	PackSize  int       `json:"pack_size"`
+	RequestId string    `json:"request_id"`
+	CreatedAt time.Time `json:"created_at"`
+	Profile   string    `json:"profile"`
+}
@@ -0,0 +1,15 @@
+package viewmodel
+
+import "time"
+
+// Null bounds leave the validity window open
+type PackValidityRequest struct {
+	ValidFrom *time.Time `json:"valid_from"`
+	ValidTo   *time.Time `json:"valid_to"`
+}
+
+type PackValidityResponse struct {
+	Size      int        `json:"size"`
+	ValidFrom *time.Time `json:"valid_from,omitempty"`
+	ValidTo   *time.Time `json:"valid_to,omitempty"`
+}
@@ -0,0 +1,9 @@
+package viewmodel
+
+type PackChartRow struct {
+	Quantity  int         `json:"quantity"`
+	Items     int         `json:"items"`
+	Overage   int         `json:"overage"`
+	PackCount int         `json:"pack_count"`
+	Packs     []OrderPack `json:"packs"`
+}
@@ -1,7 +1,19 @@
 package viewmodel
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
 type OrderRequest struct {
-	OrderQuantity int `json:"quantity" validate:"required"`
+	OrderQuantity int        `json:"quantity" validate:"required"`
+	Profile       string     `json:"profile"`
+	OrderDate     *time.Time `json:"order_date"`
+}
+
+type OrderAmendmentRequest struct {
+	OrderQuantity int `json:"quantity" validate:"required,gt=0"`
 }
 
 type OrderPack struct {
@@ -10,5 +22,39 @@ type OrderPack struct {
 }
 
 type OrderResponse struct {
-	Packs []OrderPack `json:"packs"`
+	OrderId   uuid.UUID           `json:"id"`
+	Packs     []OrderPack         `json:"packs"`
+	Packaging []PackagingUnit     `json:"packaging,omitempty"`
+	Shipments []ShipmentResponse  `json:"shipments,omitempty"`
+	Price     *OrderPriceResponse `json:"price,omitempty"`
+}
+
+// Statuses clients can move orders to, calculated is only reached by calculating the order
+type OrderStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=picked packed shipped cancelled"`
+}
+
+type OrderDetailsResponse struct {
+	OrderId      uuid.UUID  `json:"id"`
+	Quantity     int        `json:"quantity"`
+	Status       string     `json:"status"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	CalculatedAt *time.Time `json:"calculated_at,omitempty"`
+	PickedAt     *time.Time `json:"picked_at,omitempty"`
+	PackedAt     *time.Time `json:"packed_at,omitempty"`
+	ShippedAt    *time.Time `json:"shipped_at,omitempty"`
+	CancelledAt  *time.Time `json:"cancelled_at,omitempty"`
+	PackSet      []int      `json:"pack_set,omitempty"`
+	Profile      string     `json:"profile"`
+	OrderDate    time.Time  `json:"order_date"`
+}
+
+type OrderAmendmentResponse struct {
+	Order     OrderDetailsResponse `json:"order"`
+	Packs     []OrderPack          `json:"packs"`
+	Added     []OrderPack          `json:"added"`
+	Removed   []OrderPack          `json:"removed"`
+	Shipments []ShipmentResponse   `json:"shipments,omitempty"`
+	Price     *OrderPriceResponse  `json:"price,omitempty"`
 }
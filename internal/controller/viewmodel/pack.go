@@ -3,3 +3,19 @@ package viewmodel
 type PackRequest struct {
 	Size int `json:"size" validate:"required"`
 }
+
+// Zero leaves the value unknown
+type PackSpecificationRequest struct {
+	MaxWeightGrams int `json:"max_weight_grams" validate:"gte=0"`
+	LengthMm       int `json:"length_mm" validate:"gte=0"`
+	WidthMm        int `json:"width_mm" validate:"gte=0"`
+	HeightMm       int `json:"height_mm" validate:"gte=0"`
+}
+
+type PackSpecificationResponse struct {
+	Size           int `json:"size"`
+	MaxWeightGrams int `json:"max_weight_grams,omitempty"`
+	LengthMm       int `json:"length_mm,omitempty"`
+	WidthMm        int `json:"width_mm,omitempty"`
+	HeightMm       int `json:"height_mm,omitempty"`
+}
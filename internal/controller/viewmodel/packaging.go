@@ -0,0 +1,28 @@
+package viewmodel
+
+import "time"
+
+// Container type holding either packs of one size or containers of another type
+type ContainerTypeRequest struct {
+	Name       string `json:"name" validate:"required,max=100"`
+	PackSize   int    `json:"pack_size" validate:"required_without=Container,excluded_with=Container,omitempty,gt=0"`
+	Container  string `json:"container" validate:"required_without=PackSize,omitempty,max=100,nefield=Name"`
+	Capacities []int  `json:"capacities" validate:"required,min=1,max=10,unique,dive,gt=0"`
+}
+
+type ContainerTypeResponse struct {
+	Name       string    `json:"name"`
+	PackSize   int       `json:"pack_size,omitempty"`
+	Container  string    `json:"container,omitempty"`
+	Capacities []int     `json:"capacities"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Containers of one type, or loose packs, holding the contents once per unit. Nested quantities are per unit of
+// the parent, e.g. 3 pallets of 4 cartons of 5 packs.
+type PackagingUnit struct {
+	Container string         `json:"container,omitempty"`
+	PackSize  int            `json:"pack_size,omitempty"`
+	Quantity  int            `json:"quantity"`
+	Contents  *PackagingUnit `json:"contents,omitempty"`
+}
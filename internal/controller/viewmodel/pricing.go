@@ -0,0 +1,44 @@
+package viewmodel
+
+import "time"
+
+// Amounts are decimal strings, e.g. "12.50", so they are never rounded as floats
+// The currency defaults to the service one
+type PackPriceRequest struct {
+	Price    string `json:"price" validate:"required,max=20"`
+	Currency string `json:"currency" validate:"omitempty,len=3,uppercase,alpha"`
+}
+
+type PackPriceResponse struct {
+	Size     int    `json:"size"`
+	Price    string `json:"price"`
+	Currency string `json:"currency"`
+}
+
+// Discount applied to orders of at least the minimum quantity of items
+type DiscountTierRequest struct {
+	MinQuantity int    `json:"min_quantity" validate:"required,gt=0"`
+	Percent     string `json:"percent" validate:"required,max=10"`
+}
+
+type DiscountTierResponse struct {
+	MinQuantity int       `json:"min_quantity"`
+	Percent     string    `json:"percent"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type PriceLine struct {
+	Size      int    `json:"size"`
+	Quantity  int    `json:"quantity"`
+	UnitPrice string `json:"unit_price"`
+	LineTotal string `json:"line_total"`
+}
+
+type OrderPriceResponse struct {
+	Currency        string      `json:"currency"`
+	Lines           []PriceLine `json:"lines"`
+	Subtotal        string      `json:"subtotal"`
+	DiscountPercent string      `json:"discount_percent"`
+	Discount        string      `json:"discount"`
+	Total           string      `json:"total"`
+}
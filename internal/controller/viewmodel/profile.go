@@ -0,0 +1,12 @@
+package viewmodel
+
+import "time"
+
+type PackProfileRequest struct {
+	Name string `json:"name" validate:"required,max=63"`
+}
+
+type PackProfileResponse struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
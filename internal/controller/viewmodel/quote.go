@@ -0,0 +1,21 @@
+package viewmodel
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type QuoteRequest struct {
+	OrderQuantity int    `json:"quantity" validate:"required,gt=0"`
+	Profile       string `json:"profile"`
+}
+
+type QuoteResponse struct {
+	QuoteId   uuid.UUID           `json:"id"`
+	Quantity  int                 `json:"quantity"`
+	Packs     []OrderPack         `json:"packs"`
+	Price     *OrderPriceResponse `json:"price,omitempty"`
+	ExpiresAt time.Time           `json:"expires_at"`
+	Profile   string              `json:"profile"`
+}
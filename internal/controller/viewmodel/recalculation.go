@@ -0,0 +1,36 @@
+package viewmodel
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RecalculationRequest struct {
+	Statuses  []string   `json:"statuses" validate:"omitempty,dive,oneof=created calculated"`
+	From      *time.Time `json:"from"`
+	To        *time.Time `json:"to"`
+	BatchSize int        `json:"batch_size" validate:"omitempty,min=1,max=10000"`
+	DryRun    bool       `json:"dry_run"`
+	Profile   string     `json:"profile"`
+}
+
+type OrderRecalculation struct {
+	OrderId       uuid.UUID   `json:"id"`
+	Quantity      int         `json:"quantity"`
+	PreviousWaste int         `json:"previous_waste"`
+	Waste         int         `json:"waste"`
+	Added         []OrderPack `json:"added"`
+	Removed       []OrderPack `json:"removed"`
+	PackSet       []int       `json:"pack_set"`
+}
+
+type RecalculationResponse struct {
+	DryRun     bool                 `json:"dry_run"`
+	Profile    string               `json:"profile"`
+	PackSet    []int                `json:"pack_set"`
+	Examined   int                  `json:"examined"`
+	WasteSaved int                  `json:"waste_saved"`
+	Changed    []OrderRecalculation `json:"changed"`
+	Skipped    []uuid.UUID          `json:"skipped"`
+}
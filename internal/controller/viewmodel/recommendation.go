@@ -0,0 +1,32 @@
+package viewmodel
+
+type RecommendationRequest struct {
+	MaxSizes       int   `json:"max_sizes" validate:"required,min=1,max=20"`
+	Candidates     []int `json:"candidates" validate:"omitempty,max=100,unique,dive,gt=0"`
+	MaxEvaluations int   `json:"max_evaluations" validate:"omitempty,min=1,max=10000"`
+}
+
+type PackSetScore struct {
+	PackSet         []int   `json:"pack_set"`
+	Overage         int     `json:"overage"`
+	Packs           int     `json:"packs"`
+	ExpectedOverage float64 `json:"expected_overage"`
+	ExpectedPacks   float64 `json:"expected_packs"`
+}
+
+// Changes per order from removing a selected size, or adding one that was not selected
+type CandidateMetrics struct {
+	Size                  int     `json:"size"`
+	Selected              bool    `json:"selected"`
+	OrdersUsing           int     `json:"orders_using"`
+	ExpectedOverageChange float64 `json:"expected_overage_change"`
+	ExpectedPacksChange   float64 `json:"expected_packs_change"`
+}
+
+type RecommendationResponse struct {
+	Orders      int                `json:"orders"`
+	Evaluations int                `json:"evaluations"`
+	Current     PackSetScore       `json:"current"`
+	Recommended PackSetScore       `json:"recommended"`
+	Candidates  []CandidateMetrics `json:"candidates"`
+}
@@ -0,0 +1,14 @@
+package viewmodel
+
+// Weight is per pack, once filled
+type ShipmentPack struct {
+	Size        int `json:"size"`
+	Quantity    int `json:"quantity"`
+	WeightGrams int `json:"weight_grams,omitempty"`
+}
+
+type ShipmentResponse struct {
+	Packs       []ShipmentPack `json:"packs"`
+	ParcelCount int            `json:"parcel_count"`
+	WeightGrams int            `json:"weight_grams,omitempty"`
+}
@@ -0,0 +1,35 @@
+package viewmodel
+
+type PackCost struct {
+	Size int     `json:"size" validate:"gt=0"`
+	Cost float64 `json:"cost" validate:"gte=0"`
+}
+
+type SimulationRequest struct {
+	Sizes []int      `json:"sizes" validate:"required,min=1,max=50,unique,dive,gt=0"`
+	Costs []PackCost `json:"costs" validate:"omitempty,unique=Size,dive"`
+}
+
+type PackSetOutcome struct {
+	PackSet      []int    `json:"pack_set"`
+	ItemsShipped int      `json:"items_shipped"`
+	Overage      int      `json:"overage"`
+	Packs        int      `json:"packs"`
+	Cost         *float64 `json:"cost,omitempty"`
+}
+
+// Proposed minus current, negative values are savings
+type PackSetDifference struct {
+	Overage int      `json:"overage"`
+	Packs   int      `json:"packs"`
+	Cost    *float64 `json:"cost,omitempty"`
+}
+
+type SimulationResponse struct {
+	Orders        int               `json:"orders"`
+	ItemsOrdered  int               `json:"items_ordered"`
+	ChangedOrders int               `json:"changed_orders"`
+	Current       PackSetOutcome    `json:"current"`
+	Proposed      PackSetOutcome    `json:"proposed"`
+	Difference    PackSetDifference `json:"difference"`
+}
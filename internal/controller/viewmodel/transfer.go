@@ -0,0 +1,21 @@
+package viewmodel
+
+import "github.com/google/uuid"
+
+type PackExport struct {
+	Size int `json:"size"`
+}
+
+type OrderExport struct {
+	OrderDetailsResponse
+	Packs []OrderPack `json:"packs"`
+}
+
+// Outcome of importing one row, either the calculated order or the reason it was not imported
+type OrderImportResult struct {
+	Row      int         `json:"row"`
+	Quantity int         `json:"quantity"`
+	OrderId  *uuid.UUID  `json:"id,omitempty"`
+	Packs    []OrderPack `json:"packs,omitempty"`
+	Error    string      `json:"error,omitempty"`
+}
@@ -0,0 +1,159 @@
+package mediator
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+)
+
+const (
+	// Quantities analysed when no range is given, as a multiple of the largest size
+	defaultAnalysisSizeMultiple = 10
+	MaxAnalysisQuantity         = 100000
+)
+
+var ErrEmptyPackSet = errors.New("pack set has no sizes")
+
+// Analyse how well the pack set hits every quantity up to a limit, and what each of its sizes adds
+func (pm packMediator) AnalyzePackSet(ctx context.Context, request domain_model.PackSetAnalysisRequest) (domain_model.PackSetAnalysis, error) {
+	packSet := slices.Clone(request.PackSizes)
+	if len(packSet) == 0 {
+		params := repository.RetrievePacksParams{Profile: resolvePackProfile(request.Profile, pm.defaultProfile), TenantID: domain_model.TenantFromContext(ctx)}
+		packs, retrievePacksErr := pm.packRepository.RetrievePacks(ctx, params)
+		if retrievePacksErr != nil {
+			return domain_model.PackSetAnalysis{}, errors.Wrap(retrievePacksErr, "could not retrieve available packs")
+		}
+		packSet = packSetToDomainModel(packs)
+	}
+	if len(packSet) == 0 {
+		return domain_model.PackSetAnalysis{}, ErrEmptyPackSet
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(packSet)))
+
+	upTo := request.UpTo
+	if upTo <= 0 {
+		upTo = min(packSet[0]*defaultAnalysisSizeMultiple, MaxAnalysisQuantity)
+	}
+	analysis := domain_model.PackSetAnalysis{
+		PackSet:         packSet,
+		UpTo:            upTo,
+		Gcd:             packSetGcd(packSet),
+		FrobeniusNumber: frobeniusNumber(packSet),
+	}
+
+	resultGrid := planUpTo(packSet, upTo)
+	for quantity := 1; quantity <= upTo; quantity++ {
+		items, _ := resultGrid[quantity].TotalItemsAndPackages()
+		overage := items - quantity
+		if overage == 0 {
+			analysis.ExactFits++
+		}
+		if overage > analysis.MaxOverage {
+			analysis.MaxOverage, analysis.MaxOverageQuantity = overage, quantity
+		}
+	}
+
+	for _, packSize := range packSet {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return domain_model.PackSetAnalysis{}, errors.Wrap(ctxErr, fmt.Sprintf("analysis interrupted at size [%v]", packSize))
+		}
+		analysis.Sizes = append(analysis.Sizes, analyzePackSize(packSet, packSize, upTo, resultGrid))
+	}
+	return analysis, nil
+}
+
+// Count the quantities packed with the size, and solve them again without it when there are other sizes
+func analyzePackSize(packSet []int, packSize int, upTo int, resultGrid map[int]domain_model.OrderPack) domain_model.PackSizeAnalysis {
+	sizeAnalysis := domain_model.PackSizeAnalysis{Size: packSize}
+	for quantity := 1; quantity <= upTo; quantity++ {
+		if resultGrid[quantity][packSize] > 0 {
+			sizeAnalysis.QuantitiesUsing++
+		}
+	}
+	if len(packSet) == 1 {
+		return sizeAnalysis
+	}
+
+	withoutSize := slices.DeleteFunc(slices.Clone(packSet), func(size int) bool { return size == packSize })
+	resultGridWithout := planUpTo(withoutSize, upTo)
+	for quantity := 1; quantity <= upTo; quantity++ {
+		items, packs := resultGrid[quantity].TotalItemsAndPackages()
+		itemsWithout, packsWithout := resultGridWithout[quantity].TotalItemsAndPackages()
+		sizeAnalysis.OverageWithout += itemsWithout - items
+		sizeAnalysis.ExtraPacksWithout += packsWithout - packs
+	}
+	return sizeAnalysis
+}
+
+// Packs of every quantity up to maxQuantity with the pack set, the solver fills in every smaller quantity on its way
+// to the largest
+func planUpTo(packSet []int, maxQuantity int) map[int]domain_model.OrderPack {
+	packs := make([]int32, 0, len(packSet))
+	for _, packSize := range packSet {
+		packs = append(packs, int32(packSize))
+	}
+	return calculateOrderPacks(translateToDomainModel(repository.Order{OrderQuantity: int32(maxQuantity)}, packs)).ResultGrid
+}
+
+func packSetGcd(packSet []int) int {
+	divisor := 0
+	for _, packSize := range packSet {
+		for other := packSize; other != 0; {
+			divisor, other = other, divisor%other
+		}
+	}
+	return divisor
+}
+
+// The largest quantity the sizes cannot add up to. The smallest quantity that can be hit is found for each remainder
+// of the smallest size, every quantity above it with the same remainder can be hit by adding the smallest size, so
+// the last one missed is just below the largest of them. It takes memory in the smallest size, so it is skipped when
+// that is above MaxAnalysisQuantity.
+func frobeniusNumber(packSet []int) *int {
+	smallest := slices.Min(packSet)
+	if packSetGcd(packSet) != 1 || smallest > MaxAnalysisQuantity {
+		return nil
+	}
+
+	smallestHit := make([]int, smallest)
+	for remainder := range smallestHit {
+		smallestHit[remainder] = -1
+	}
+	quantities := &quantityHeap{0}
+	for quantities.Len() > 0 {
+		quantity := heap.Pop(quantities).(int)
+		if smallestHit[quantity%smallest] >= 0 {
+			continue
+		}
+		smallestHit[quantity%smallest] = quantity
+		for _, packSize := range packSet {
+			if next := quantity + packSize; smallestHit[next%smallest] < 0 {
+				heap.Push(quantities, next)
+			}
+		}
+	}
+
+	frobenius := slices.Max(smallestHit) - smallest
+	return &frobenius
+}
+
+// Min-heap of quantities
+type quantityHeap []int
+
+func (qh quantityHeap) Len() int           { return len(qh) }
+func (qh quantityHeap) Less(i, j int) bool { return qh[i] < qh[j] }
+func (qh quantityHeap) Swap(i, j int)      { qh[i], qh[j] = qh[j], qh[i] }
+func (qh *quantityHeap) Push(x any)        { *qh = append(*qh, x.(int)) }
+func (qh *quantityHeap) Pop() any {
+	old := *qh
+	last := old[len(old)-1]
+	*qh = old[:len(old)-1]
+	return last
+}
@@ -0,0 +1,98 @@
+package mediator_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+	repository_mocks "github.com/felipevillarrealdaza/go-service-template/internal/repository/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AnalyzePackSet(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	packMediator := mediator.NewPackMediator(mediator.WithPackRepository(repositoryMock))
+
+	t.Run("Frobenius number of coprime sizes", func(t *testing.T) {
+		// Act
+		analysis, analyzeErr := packMediator.AnalyzePackSet(context.Background(), domain_model.PackSetAnalysisRequest{PackSizes: []int{6, 9, 20}, UpTo: 100})
+
+		// Assert
+		require.NoError(t, analyzeErr)
+		require.Equal(t, []int{20, 9, 6}, analysis.PackSet)
+		require.Equal(t, 1, analysis.Gcd)
+		require.Equal(t, 43, *analysis.FrobeniusNumber)
+		require.Equal(t, 100, analysis.UpTo)
+		require.Positive(t, analysis.MaxOverage)
+		require.Len(t, analysis.Sizes, 3)
+	})
+
+	t.Run("Size that only saves packs is redundant", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrievePacks", mock.Anything, repository.RetrievePacksParams{Profile: "default", TenantID: "default"}).Return([]int32{500, 250}, nil).Once()
+
+		// Act
+		analysis, analyzeErr := packMediator.AnalyzePackSet(context.Background(), domain_model.PackSetAnalysisRequest{UpTo: 1000})
+
+		// Assert
+		require.NoError(t, analyzeErr)
+		require.Equal(t, 250, analysis.Gcd)
+		require.Nil(t, analysis.FrobeniusNumber)
+		require.Equal(t, 4, analysis.ExactFits)
+		require.Equal(t, 249, analysis.MaxOverage)
+		require.Equal(t, 1, analysis.MaxOverageQuantity)
+		sizeOf500 := analysis.Sizes[0]
+		require.Equal(t, 500, sizeOf500.Size)
+		require.False(t, sizeOf500.Unused())
+		require.True(t, sizeOf500.Redundant(len(analysis.PackSet)))
+		require.Positive(t, sizeOf500.ExtraPacksWithout)
+		require.False(t, analysis.Sizes[1].Redundant(len(analysis.PackSet)))
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Every quantity can be hit", func(t *testing.T) {
+		// Act
+		analysis, analyzeErr := packMediator.AnalyzePackSet(context.Background(), domain_model.PackSetAnalysisRequest{PackSizes: []int{1, 4}})
+
+		// Assert
+		require.NoError(t, analyzeErr)
+		require.Equal(t, -1, *analysis.FrobeniusNumber)
+		require.Equal(t, 40, analysis.UpTo)
+		require.Equal(t, 40, analysis.ExactFits)
+		require.Zero(t, analysis.MaxOverage)
+	})
+
+	t.Run("Frobenius number skipped for large sizes", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrievePacks", mock.Anything, repository.RetrievePacksParams{Profile: "default", TenantID: "default"}).Return([]int32{2000000000, 2000000001}, nil).Once()
+
+		// Act
+		analysis, analyzeErr := packMediator.AnalyzePackSet(context.Background(), domain_model.PackSetAnalysisRequest{UpTo: 10})
+
+		// Assert
+		require.NoError(t, analyzeErr)
+		require.Equal(t, 1, analysis.Gcd)
+		require.Nil(t, analysis.FrobeniusNumber)
+	})
+
+	t.Run("No pack sizes", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrievePacks", mock.Anything, repository.RetrievePacksParams{Profile: "default", TenantID: "default"}).Return([]int32{}, nil).Once()
+
+		// Act
+		_, analyzeErr := packMediator.AnalyzePackSet(context.Background(), domain_model.PackSetAnalysisRequest{})
+
+		// Assert
+		require.ErrorIs(t, analyzeErr, mediator.ErrEmptyPackSet)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+}
@@ -0,0 +1,100 @@
+package mediator
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+)
+
+type AnalyticsMediatorDeps func(mediator *analyticsMediator)
+
+func WithAnalyticsRepository(repository repository.Querier) AnalyticsMediatorDeps {
+	return func(mediator *analyticsMediator) {
+		mediator.analyticsRepository = repository
+	}
+}
+
+type AnalyticsMediator interface {
+	RetrieveOrderSummary(ctx context.Context, analyticsRange domain_model.AnalyticsRange) (domain_model.OrderSummary, error)
+	RetrievePackUsage(ctx context.Context, analyticsRange domain_model.AnalyticsRange) (domain_model.PackUsageReport, error)
+	RetrieveQuantityHistogram(ctx context.Context, analyticsRange domain_model.AnalyticsRange, bucketSize int) (domain_model.QuantityHistogram, error)
+}
+
+type analyticsMediator struct {
+	analyticsRepository repository.Querier
+}
+
+func NewAnalyticsMediator(deps ...AnalyticsMediatorDeps) AnalyticsMediator {
+	analyticsMediator := analyticsMediator{}
+	for _, opt := range deps {
+		opt(&analyticsMediator)
+	}
+	return analyticsMediator
+}
+
+// Total items ordered and shipped in packs by the calculated orders of the range
+func (am analyticsMediator) RetrieveOrderSummary(ctx context.Context, analyticsRange domain_model.AnalyticsRange) (domain_model.OrderSummary, error) {
+	from, to := analyticsRangeToParams(analyticsRange)
+	totals, retrieveErr := am.analyticsRepository.RetrieveOrderTotals(ctx, repository.RetrieveOrderTotalsParams{From: from, To: to, TenantID: domain_model.TenantFromContext(ctx)})
+	if retrieveErr != nil {
+		return domain_model.OrderSummary{}, errors.Wrap(retrieveErr, fmt.Sprintf("could not retrieve order totals between [%v] and [%v]", analyticsRange.From, analyticsRange.To))
+	}
+
+	return domain_model.OrderSummary{
+		Range:        analyticsRange,
+		Orders:       int(totals.OrderCount),
+		ItemsOrdered: int(totals.ItemsOrdered),
+		ItemsShipped: int(totals.ItemsShipped),
+		Packs:        int(totals.PackCount),
+	}, nil
+}
+
+// Packs used of each size by the orders of the range, ordered by size
+func (am analyticsMediator) RetrievePackUsage(ctx context.Context, analyticsRange domain_model.AnalyticsRange) (domain_model.PackUsageReport, error) {
+	from, to := analyticsRangeToParams(analyticsRange)
+	packUsages, retrieveErr := am.analyticsRepository.RetrievePackUsage(ctx, repository.RetrievePackUsageParams{From: from, To: to, TenantID: domain_model.TenantFromContext(ctx)})
+	if retrieveErr != nil {
+		return domain_model.PackUsageReport{}, errors.Wrap(retrieveErr, fmt.Sprintf("could not retrieve pack usage between [%v] and [%v]", analyticsRange.From, analyticsRange.To))
+	}
+
+	report := domain_model.PackUsageReport{Range: analyticsRange, Usage: make([]domain_model.PackUsage, 0, len(packUsages))}
+	for _, packUsage := range packUsages {
+		report.Usage = append(report.Usage, domain_model.PackUsage{
+			Size:   int(packUsage.PackSize),
+			Packs:  int(packUsage.PackCount),
+			Orders: int(packUsage.OrderCount),
+		})
+	}
+	return report, nil
+}
+
+// Orders of the range counted by quantity, in buckets of bucketSize items
+func (am analyticsMediator) RetrieveQuantityHistogram(ctx context.Context, analyticsRange domain_model.AnalyticsRange, bucketSize int) (domain_model.QuantityHistogram, error) {
+	from, to := analyticsRangeToParams(analyticsRange)
+	params := repository.RetrieveOrderQuantityHistogramParams{
+		From:       from,
+		To:         to,
+		BucketSize: int32(bucketSize),
+		TenantID:   domain_model.TenantFromContext(ctx),
+	}
+	buckets, retrieveErr := am.analyticsRepository.RetrieveOrderQuantityHistogram(ctx, params)
+	if retrieveErr != nil {
+		return domain_model.QuantityHistogram{}, errors.Wrap(retrieveErr, fmt.Sprintf("could not retrieve order quantities between [%v] and [%v]", analyticsRange.From, analyticsRange.To))
+	}
+
+	histogram := domain_model.QuantityHistogram{Range: analyticsRange, BucketSize: bucketSize, Buckets: make([]domain_model.QuantityBucket, 0, len(buckets))}
+	for _, bucket := range buckets {
+		histogram.Buckets = append(histogram.Buckets, domain_model.QuantityBucket{From: int(bucket.BucketStart), Orders: int(bucket.OrderCount)})
+	}
+	return histogram, nil
+}
+
+func analyticsRangeToParams(analyticsRange domain_model.AnalyticsRange) (sql.NullTime, sql.NullTime) {
+	return sql.NullTime{Time: analyticsRange.From, Valid: !analyticsRange.From.IsZero()},
+		sql.NullTime{Time: analyticsRange.To, Valid: !analyticsRange.To.IsZero()}
+}
@@ -0,0 +1,109 @@
+package mediator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+	repository_mocks "github.com/felipevillarrealdaza/go-service-template/internal/repository/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RetrieveOrderSummary(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	analyticsMediator := mediator.NewAnalyticsMediator(mediator.WithAnalyticsRepository(repositoryMock))
+	analyticsRange := domain_model.AnalyticsRange{From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	t.Run("Overage of the range", func(t *testing.T) {
+		// Arrange
+		repositoryMock.
+			On("RetrieveOrderTotals", mock.Anything, mock.MatchedBy(func(params repository.RetrieveOrderTotalsParams) bool {
+				return params.From.Valid && params.From.Time.Equal(analyticsRange.From) && !params.To.Valid
+			})).
+			Return(repository.RetrieveOrderTotalsRow{OrderCount: 3, ItemsOrdered: 1000, ItemsShipped: 1250, PackCount: 4}, nil).Once()
+
+		// Act
+		summary, retrieveErr := analyticsMediator.RetrieveOrderSummary(context.Background(), analyticsRange)
+
+		// Assert
+		require.NoError(t, retrieveErr)
+		require.Equal(t, 250, summary.OverageItems())
+		require.Equal(t, 25.0, summary.OveragePercentage())
+		require.Equal(t, 1.33, summary.AveragePacksPerOrder())
+		require.Equal(t, analyticsRange, summary.Range)
+	})
+
+	t.Run("No orders", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrieveOrderTotals", mock.Anything, mock.Anything).Return(repository.RetrieveOrderTotalsRow{}, nil).Once()
+
+		// Act
+		summary, retrieveErr := analyticsMediator.RetrieveOrderSummary(context.Background(), domain_model.AnalyticsRange{})
+
+		// Assert
+		require.NoError(t, retrieveErr)
+		require.Zero(t, summary.OveragePercentage())
+		require.Zero(t, summary.AveragePacksPerOrder())
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrieveOrderTotals", mock.Anything, mock.Anything).Return(repository.RetrieveOrderTotalsRow{}, errors.New("connection refused")).Once()
+
+		// Act
+		_, retrieveErr := analyticsMediator.RetrieveOrderSummary(context.Background(), analyticsRange)
+
+		// Assert
+		require.Error(t, retrieveErr)
+	})
+}
+
+func Test_RetrievePackUsage(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	analyticsMediator := mediator.NewAnalyticsMediator(mediator.WithAnalyticsRepository(repositoryMock))
+
+	// Arrange
+	repositoryMock.On("RetrievePackUsage", mock.Anything, repository.RetrievePackUsageParams{TenantID: "default"}).Return([]repository.RetrievePackUsageRow{
+		{PackSize: 250, PackCount: 3, OrderCount: 2},
+		{PackSize: 500, PackCount: 1, OrderCount: 1},
+	}, nil)
+
+	// Act
+	report, retrieveErr := analyticsMediator.RetrievePackUsage(context.Background(), domain_model.AnalyticsRange{})
+
+	// Assert
+	require.NoError(t, retrieveErr)
+	require.Equal(t, []domain_model.PackUsage{{Size: 250, Packs: 3, Orders: 2}, {Size: 500, Packs: 1, Orders: 1}}, report.Usage)
+	response := report.ToViewModel()
+	require.Equal(t, 750, response.Packs[0].Items)
+	require.Equal(t, 75.0, response.Packs[0].SharePercentage)
+	require.Equal(t, 25.0, response.Packs[1].SharePercentage)
+}
+
+func Test_RetrieveQuantityHistogram(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	analyticsMediator := mediator.NewAnalyticsMediator(mediator.WithAnalyticsRepository(repositoryMock))
+
+	// Arrange
+	repositoryMock.
+		On("RetrieveOrderQuantityHistogram", mock.Anything, repository.RetrieveOrderQuantityHistogramParams{BucketSize: 100, TenantID: "default"}).
+		Return([]repository.RetrieveOrderQuantityHistogramRow{{BucketStart: 0, OrderCount: 4}, {BucketStart: 300, OrderCount: 1}}, nil)
+
+	// Act
+	histogram, retrieveErr := analyticsMediator.RetrieveQuantityHistogram(context.Background(), domain_model.AnalyticsRange{}, 100)
+
+	// Assert
+	require.NoError(t, retrieveErr)
+	require.Equal(t, []domain_model.QuantityBucket{{From: 0, Orders: 4}, {From: 300, Orders: 1}}, histogram.Buckets)
+	response := histogram.ToViewModel()
+	require.Equal(t, 400, response.Buckets[1].To)
+}
@@ -0,0 +1,99 @@
+package mediator
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+)
+
+type AuditMediatorDeps func(mediator *auditMediator)
+
+func WithAuditRepository(repository repository.Querier) AuditMediatorDeps {
+	return func(mediator *auditMediator) {
+		mediator.auditRepository = repository
+	}
+}
+
+type AuditMediator interface {
+	RetrievePackAudits(ctx context.Context, filter domain_model.PackAuditFilter) ([]domain_model.PackAuditEntry, error)
+	ExportPackAudits(ctx context.Context, filter domain_model.PackAuditFilter, fn func(entry domain_model.PackAuditEntry) error) error
+}
+
+type auditMediator struct {
+	auditRepository repository.Querier
+}
+
+func NewAuditMediator(deps ...AuditMediatorDeps) AuditMediator {
+	auditMediator := auditMediator{}
+	for _, opt := range deps {
+		opt(&auditMediator)
+	}
+	return auditMediator
+}
+
+// Retrieve the audit entries matching the filter, newest first
+func (am auditMediator) RetrievePackAudits(ctx context.Context, filter domain_model.PackAuditFilter) ([]domain_model.PackAuditEntry, error) {
+	params := repository.RetrievePackAuditsParams{
+		Actor:    sql.NullString{String: filter.Actor, Valid: filter.Actor != ""},
+		Action:   sql.NullString{String: string(filter.Action), Valid: filter.Action != ""},
+		PackSize: sql.NullInt32{Int32: int32(filter.PackSize), Valid: filter.PackSize != 0},
+		From:     sql.NullTime{Time: filter.From, Valid: !filter.From.IsZero()},
+		To:       sql.NullTime{Time: filter.To, Valid: !filter.To.IsZero()},
+		Limit:    int32(filter.Limit),
+		Offset:   int32(filter.Offset),
+		TenantID: domain_model.TenantFromContext(ctx),
+	}
+	packAudits, retrieveErr := am.auditRepository.RetrievePackAudits(ctx, params)
+	if retrieveErr != nil {
+		return nil, errors.Wrap(retrieveErr, "could not retrieve pack audits")
+	}
+
+	result := make([]domain_model.PackAuditEntry, 0, len(packAudits))
+	for _, packAudit := range packAudits {
+		result = append(result, translatePackAuditToDomainModel(packAudit))
+	}
+	return result, nil
+}
+
+// Hand every audit entry matching the filter to fn, newest first from the filter offset, a page at a time regardless of
+// the filter limit. Without an end, entries recorded once the export started are left out so the pages do not shift.
+func (am auditMediator) ExportPackAudits(ctx context.Context, filter domain_model.PackAuditFilter, fn func(entry domain_model.PackAuditEntry) error) error {
+	if filter.To.IsZero() {
+		filter.To = time.Now()
+	}
+	filter.Limit = exportPageSize
+	for {
+		entries, retrieveErr := am.RetrievePackAudits(ctx, filter)
+		if retrieveErr != nil {
+			return errors.Wrap(retrieveErr, fmt.Sprintf("could not export pack audits after [%v] entries", filter.Offset))
+		}
+		for _, entry := range entries {
+			if fnErr := fn(entry); fnErr != nil {
+				return fnErr
+			}
+		}
+		if len(entries) < exportPageSize {
+			return nil
+		}
+		filter.Offset += len(entries)
+	}
+}
+
+// Translate from repository models to domain models
+func translatePackAuditToDomainModel(packAudit repository.PackAudit) domain_model.PackAuditEntry {
+	return domain_model.PackAuditEntry{
+		AuditId:   packAudit.AuditID,
+		Actor:     packAudit.Actor,
+		Action:    domain_model.PackAuditAction(packAudit.Action),
+		PackSize:  int(packAudit.PackSize),
+		RequestId: packAudit.RequestID,
+		Profile:   packAudit.Profile,
+		CreatedAt: packAudit.CreatedAt,
+	}
+}
@@ -0,0 +1,88 @@
+package mediator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+	repository_mocks "github.com/felipevillarrealdaza/go-service-template/internal/repository/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RetrievePackAudits(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	auditMediator := mediator.NewAuditMediator(mediator.WithAuditRepository(repositoryMock))
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Only given filters are applied", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrievePackAudits", mock.Anything, mock.MatchedBy(func(params repository.RetrievePackAuditsParams) bool {
+			return params.Action.Valid && params.Action.String == "add" &&
+				params.From.Valid && params.From.Time.Equal(from) &&
+				!params.Actor.Valid && !params.PackSize.Valid && !params.To.Valid &&
+				params.Limit == 100 && params.Offset == 0
+		})).Return([]repository.PackAudit{{Actor: "api_key:ops", Action: "add", PackSize: 250, RequestID: "request-1", CreatedAt: from}}, nil)
+
+		// Act
+		entries, retrieveErr := auditMediator.RetrievePackAudits(context.Background(), domain_model.PackAuditFilter{
+			Action: domain_model.PackAuditActionAdd,
+			From:   from,
+			Limit:  100,
+		})
+
+		// Assert
+		require.NoError(t, retrieveErr)
+		require.Len(t, entries, 1)
+		require.Equal(t, domain_model.PackAuditEntry{Actor: "api_key:ops", Action: domain_model.PackAuditActionAdd, PackSize: 250, RequestId: "request-1", CreatedAt: from}, entries[0])
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Error retrieving the audits", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrievePackAudits", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+
+		// Act
+		_, retrieveErr := auditMediator.RetrievePackAudits(context.Background(), domain_model.PackAuditFilter{Limit: 100})
+
+		// Assert
+		require.Error(t, retrieveErr)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+}
+
+func Test_ExportPackAudits(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	auditMediator := mediator.NewAuditMediator(mediator.WithAuditRepository(repositoryMock))
+	fullPage := make([]repository.PackAudit, 1000)
+
+	// Arrange, every page is read up to the time the export started, past the filter limit
+	repositoryMock.On("RetrievePackAudits", mock.Anything, mock.MatchedBy(func(params repository.RetrievePackAuditsParams) bool {
+		return params.Offset == 10 && params.Limit == 1000 && params.To.Valid
+	})).Return(fullPage, nil).Once()
+	repositoryMock.On("RetrievePackAudits", mock.Anything, mock.MatchedBy(func(params repository.RetrievePackAuditsParams) bool {
+		return params.Offset == 1010 && params.Limit == 1000 && params.To.Valid
+	})).Return([]repository.PackAudit{{Action: "add"}}, nil).Once()
+
+	// Act
+	exported := 0
+	exportErr := auditMediator.ExportPackAudits(context.Background(), domain_model.PackAuditFilter{Limit: 100, Offset: 10}, func(entry domain_model.PackAuditEntry) error {
+		exported++
+		return nil
+	})
+
+	// Assert
+	require.NoError(t, exportErr)
+	require.Equal(t, 1001, exported)
+}
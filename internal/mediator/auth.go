@@ -0,0 +1,260 @@
+package mediator
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+)
+
+// Prefix of the generated API keys, so they are easy to spot in logs and secret scanners
+const apiKeyPrefix = "pck_"
+
+var (
+	ErrUnauthenticated = errors.New("invalid or missing credentials")
+	ErrApiKeyNotFound  = errors.New("api key not found")
+)
+
+type AuthMediatorDeps func(mediator *authMediator)
+
+func WithAuthRepository(repository repository.Querier) AuthMediatorDeps {
+	return func(mediator *authMediator) {
+		mediator.authRepository = repository
+	}
+}
+
+// Accept JWTs signed with HS256 using this secret
+func WithJwtHmacSecret(secret []byte) AuthMediatorDeps {
+	return func(mediator *authMediator) {
+		mediator.jwtHmacSecret = secret
+	}
+}
+
+// Accept JWTs signed with RS256 by the private counterpart of this key
+func WithJwtRsaPublicKey(publicKey *rsa.PublicKey) AuthMediatorDeps {
+	return func(mediator *authMediator) {
+		mediator.jwtRsaPublicKey = publicKey
+	}
+}
+
+// Require the iss and aud claims of the JWTs to match, when not empty
+func WithJwtIssuerAndAudience(issuer string, audience string) AuthMediatorDeps {
+	return func(mediator *authMediator) {
+		mediator.jwtIssuer = issuer
+		mediator.jwtAudience = audience
+	}
+}
+
+type AuthMediator interface {
+	Authenticate(ctx context.Context, credentials domain_model.Credentials) (domain_model.Principal, error)
+	CreateApiKey(ctx context.Context, name string, role domain_model.Role) (domain_model.ApiKey, string, error)
+	EnsureApiKey(ctx context.Context, name string, key string, role domain_model.Role) error
+	RetrieveApiKeys(ctx context.Context) ([]domain_model.ApiKey, error)
+	RevokeApiKey(ctx context.Context, apiKeyId uuid.UUID) error
+}
+
+type authMediator struct {
+	authRepository  repository.Querier
+	jwtHmacSecret   []byte
+	jwtRsaPublicKey *rsa.PublicKey
+	jwtIssuer       string
+	jwtAudience     string
+}
+
+// Claims expected in the JWTs, besides the registered ones
+type jwtClaims struct {
+	Role   string `json:"role"`
+	Tenant string `json:"tenant"`
+	jwt.RegisteredClaims
+}
+
+func NewAuthMediator(deps ...AuthMediatorDeps) AuthMediator {
+	authMediator := authMediator{}
+	for _, opt := range deps {
+		opt(&authMediator)
+	}
+	return authMediator
+}
+
+func (am authMediator) Authenticate(ctx context.Context, credentials domain_model.Credentials) (domain_model.Principal, error) {
+	switch {
+	case credentials.ApiKey != "":
+		return am.authenticateApiKey(ctx, credentials.ApiKey)
+	case credentials.BearerToken != "":
+		return am.authenticateJwt(credentials.BearerToken)
+	default:
+		return domain_model.Principal{}, ErrUnauthenticated
+	}
+}
+
+func (am authMediator) authenticateApiKey(ctx context.Context, key string) (domain_model.Principal, error) {
+	apiKey, retrieveErr := am.authRepository.RetrieveActiveApiKeyByHash(ctx, hashApiKey(key))
+	if errors.Is(retrieveErr, sql.ErrNoRows) {
+		return domain_model.Principal{}, ErrUnauthenticated
+	}
+	if retrieveErr != nil {
+		return domain_model.Principal{}, errors.Wrap(retrieveErr, "could not retrieve api key")
+	}
+	// Key names are free text and may repeat, so keys are told apart by their id
+	return domain_model.Principal{
+		Subject: apiKey.ApiKeyID.String(),
+		Role:    domain_model.Role(apiKey.Role),
+		Method:  domain_model.AuthMethodApiKey,
+		Tenant:  apiKey.TenantID.String,
+	}, nil
+}
+
+func (am authMediator) authenticateJwt(token string) (domain_model.Principal, error) {
+	var validMethods []string
+	if am.jwtHmacSecret != nil {
+		validMethods = append(validMethods, jwt.SigningMethodHS256.Alg())
+	}
+	if am.jwtRsaPublicKey != nil {
+		validMethods = append(validMethods, jwt.SigningMethodRS256.Alg())
+	}
+	if len(validMethods) == 0 {
+		return domain_model.Principal{}, ErrUnauthenticated
+	}
+
+	parserOptions := []jwt.ParserOption{jwt.WithValidMethods(validMethods), jwt.WithExpirationRequired()}
+	if am.jwtIssuer != "" {
+		parserOptions = append(parserOptions, jwt.WithIssuer(am.jwtIssuer))
+	}
+	if am.jwtAudience != "" {
+		parserOptions = append(parserOptions, jwt.WithAudience(am.jwtAudience))
+	}
+
+	claims := jwtClaims{}
+	_, parseErr := jwt.ParseWithClaims(token, &claims, am.jwtVerificationKey, parserOptions...)
+	if parseErr != nil {
+		return domain_model.Principal{}, errors.Wrap(ErrUnauthenticated, parseErr.Error())
+	}
+	role := domain_model.Role(claims.Role)
+	if !role.IsValid() {
+		return domain_model.Principal{}, errors.Wrap(ErrUnauthenticated, fmt.Sprintf("unknown role [%v]", claims.Role))
+	}
+	if claims.Tenant != "" && !domain_model.IsValidTenant(claims.Tenant) {
+		return domain_model.Principal{}, errors.Wrap(ErrUnauthenticated, fmt.Sprintf("invalid tenant [%v]", claims.Tenant))
+	}
+	return domain_model.Principal{Subject: claims.Subject, Role: role, Method: domain_model.AuthMethodJwt, Tenant: claims.Tenant}, nil
+}
+
+// Pick the key matching the algorithm of the token, which has already been checked against the valid methods
+func (am authMediator) jwtVerificationKey(token *jwt.Token) (interface{}, error) {
+	if token.Method.Alg() == jwt.SigningMethodRS256.Alg() {
+		return am.jwtRsaPublicKey, nil
+	}
+	return am.jwtHmacSecret, nil
+}
+
+// Create a random API key bound to the tenant of the request. Only its hash is stored, the plain key is returned to be
+// handed to the client once.
+func (am authMediator) CreateApiKey(ctx context.Context, name string, role domain_model.Role) (domain_model.ApiKey, string, error) {
+	if !role.IsValid() {
+		return domain_model.ApiKey{}, "", errors.New(fmt.Sprintf("role [%v] is not valid", role))
+	}
+
+	randomBytes := make([]byte, 32)
+	if _, randErr := rand.Read(randomBytes); randErr != nil {
+		return domain_model.ApiKey{}, "", errors.Wrap(randErr, "could not generate api key")
+	}
+	key := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(randomBytes)
+
+	params := repository.AddApiKeyParams{
+		ApiKeyID: uuid.New(),
+		Name:     name,
+		KeyHash:  hashApiKey(key),
+		Role:     string(role),
+		TenantID: sql.NullString{String: domain_model.TenantFromContext(ctx), Valid: true},
+	}
+	apiKey, addErr := am.authRepository.AddApiKey(ctx, params)
+	if addErr != nil {
+		return domain_model.ApiKey{}, "", errors.Wrap(addErr, fmt.Sprintf("could not add api key [%v]", name))
+	}
+	return translateApiKeyToDomainModel(apiKey), key, nil
+}
+
+// Store the given key unless it already exists, used to bootstrap the first admin key from config. The key is bound to
+// no tenant, so it can act on any of them.
+func (am authMediator) EnsureApiKey(ctx context.Context, name string, key string, role domain_model.Role) error {
+	exists, existsErr := am.authRepository.ExistsApiKeyByHash(ctx, hashApiKey(key))
+	if existsErr != nil {
+		return errors.Wrap(existsErr, fmt.Sprintf("could not check api key [%v]", name))
+	}
+	if exists {
+		return nil
+	}
+
+	params := repository.AddApiKeyParams{ApiKeyID: uuid.New(), Name: name, KeyHash: hashApiKey(key), Role: string(role)}
+	if _, addErr := am.authRepository.AddApiKey(ctx, params); addErr != nil {
+		return errors.Wrap(addErr, fmt.Sprintf("could not add api key [%v]", name))
+	}
+	return nil
+}
+
+// List the keys of the tenant of the request, along with the keys bound to no tenant for admins bound to none
+func (am authMediator) RetrieveApiKeys(ctx context.Context) ([]domain_model.ApiKey, error) {
+	params := repository.RetrieveApiKeysParams{TenantID: domain_model.TenantFromContext(ctx), IncludeUnbound: isUnboundAdmin(ctx)}
+	apiKeys, retrieveErr := am.authRepository.RetrieveApiKeys(ctx, params)
+	if retrieveErr != nil {
+		return nil, errors.Wrap(retrieveErr, "could not retrieve api keys")
+	}
+
+	result := make([]domain_model.ApiKey, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		result = append(result, translateApiKeyToDomainModel(apiKey))
+	}
+	return result, nil
+}
+
+// Revoke a key of the tenant of the request. Keys bound to no tenant, like the bootstrap key, are only revoked by admins
+// bound to none.
+func (am authMediator) RevokeApiKey(ctx context.Context, apiKeyId uuid.UUID) error {
+	params := repository.RevokeApiKeyParams{ApiKeyID: apiKeyId, TenantID: domain_model.TenantFromContext(ctx), IncludeUnbound: isUnboundAdmin(ctx)}
+	revoked, revokeErr := am.authRepository.RevokeApiKey(ctx, params)
+	if revokeErr != nil {
+		return errors.Wrap(revokeErr, fmt.Sprintf("could not revoke api key [%v]", apiKeyId))
+	}
+	if revoked == 0 {
+		return errors.Wrap(ErrApiKeyNotFound, fmt.Sprintf("could not revoke api key [%v]", apiKeyId))
+	}
+	return nil
+}
+
+// Whether the request comes from an admin that may act on any tenant
+func isUnboundAdmin(ctx context.Context) bool {
+	principal, found := domain_model.PrincipalFromContext(ctx)
+	return found && principal.Role == domain_model.RoleAdmin && principal.Tenant == ""
+}
+
+// Translate from repository models to domain models
+func translateApiKeyToDomainModel(apiKey repository.ApiKey) domain_model.ApiKey {
+	domainApiKey := domain_model.ApiKey{
+		ApiKeyId:  apiKey.ApiKeyID,
+		Name:      apiKey.Name,
+		Role:      domain_model.Role(apiKey.Role),
+		CreatedAt: apiKey.CreatedAt,
+		Tenant:    apiKey.TenantID.String,
+	}
+	if apiKey.RevokedAt.Valid {
+		domainApiKey.RevokedAt = &apiKey.RevokedAt.Time
+	}
+	return domainApiKey
+}
+
+// API keys are long random strings, so a fast unsalted hash is enough to make a leaked table useless
+func hashApiKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,237 @@
+package mediator_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+	repository_mocks "github.com/felipevillarrealdaza/go-service-template/internal/repository/mocks"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var hmacSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func signToken(t *testing.T, method jwt.SigningMethod, key interface{}, claims jwt.MapClaims) string {
+	token, signErr := jwt.NewWithClaims(method, claims).SignedString(key)
+	require.NoError(t, signErr)
+	return token
+}
+
+func Test_Authenticate_ApiKey(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	authMediator := mediator.NewAuthMediator(mediator.WithAuthRepository(repositoryMock))
+	apiKeyId := uuid.New()
+
+	t.Run("Active key", func(t *testing.T) {
+		// Arrange
+		repositoryMock.
+			On("RetrieveActiveApiKeyByHash", mock.Anything, mock.MatchedBy(func(hash string) bool { return len(hash) == 64 })).
+			Return(repository.ApiKey{ApiKeyID: apiKeyId, Name: "warehouse", Role: "client"}, nil)
+
+		// Act
+		principal, authErr := authMediator.Authenticate(context.Background(), domain_model.Credentials{ApiKey: "pck_key"})
+
+		// Assert
+		require.NoError(t, authErr)
+		require.Equal(t, domain_model.Principal{Subject: apiKeyId.String(), Role: domain_model.RoleClient, Method: domain_model.AuthMethodApiKey}, principal)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Unknown or revoked key", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrieveActiveApiKeyByHash", mock.Anything, mock.Anything).Return(repository.ApiKey{}, sql.ErrNoRows)
+
+		// Act
+		_, authErr := authMediator.Authenticate(context.Background(), domain_model.Credentials{ApiKey: "pck_key"})
+
+		// Assert
+		require.ErrorIs(t, authErr, mediator.ErrUnauthenticated)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+}
+
+func Test_Authenticate_Jwt(t *testing.T) {
+	// Set Up
+	rsaKey, keyErr := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, keyErr)
+	authMediator := mediator.NewAuthMediator(
+		mediator.WithJwtHmacSecret(hmacSecret),
+		mediator.WithJwtRsaPublicKey(&rsaKey.PublicKey),
+		mediator.WithJwtIssuerAndAudience("issuer", "pack-calculator"),
+	)
+	validClaims := func(role string) jwt.MapClaims {
+		return jwt.MapClaims{"sub": "alice", "role": role, "iss": "issuer", "aud": "pack-calculator", "exp": time.Now().Add(time.Hour).Unix()}
+	}
+
+	t.Run("HS256", func(t *testing.T) {
+		// Act
+		principal, authErr := authMediator.Authenticate(context.Background(), domain_model.Credentials{BearerToken: signToken(t, jwt.SigningMethodHS256, hmacSecret, validClaims("admin"))})
+
+		// Assert
+		require.NoError(t, authErr)
+		require.Equal(t, domain_model.Principal{Subject: "alice", Role: domain_model.RoleAdmin, Method: domain_model.AuthMethodJwt}, principal)
+	})
+
+	t.Run("RS256", func(t *testing.T) {
+		// Act
+		principal, authErr := authMediator.Authenticate(context.Background(), domain_model.Credentials{BearerToken: signToken(t, jwt.SigningMethodRS256, rsaKey, validClaims("client"))})
+
+		// Assert
+		require.NoError(t, authErr)
+		require.Equal(t, domain_model.RoleClient, principal.Role)
+	})
+
+	t.Run("Tenant claim", func(t *testing.T) {
+		// Arrange
+		claims := validClaims("client")
+		claims["tenant"] = "acme"
+
+		// Act
+		principal, authErr := authMediator.Authenticate(context.Background(), domain_model.Credentials{BearerToken: signToken(t, jwt.SigningMethodHS256, hmacSecret, claims)})
+
+		// Assert
+		require.NoError(t, authErr)
+		require.Equal(t, "acme", principal.Tenant)
+	})
+
+	invalidTokens := map[string]string{
+		"Wrong secret":    signToken(t, jwt.SigningMethodHS256, []byte(strings.Repeat("x", 32)), validClaims("admin")),
+		"Unknown role":    signToken(t, jwt.SigningMethodHS256, hmacSecret, validClaims("root")),
+		"Expired":         signToken(t, jwt.SigningMethodHS256, hmacSecret, jwt.MapClaims{"role": "admin", "iss": "issuer", "aud": "pack-calculator", "exp": time.Now().Add(-time.Hour).Unix()}),
+		"Without expiry":  signToken(t, jwt.SigningMethodHS256, hmacSecret, jwt.MapClaims{"role": "admin", "iss": "issuer", "aud": "pack-calculator"}),
+		"Wrong audience":  signToken(t, jwt.SigningMethodHS256, hmacSecret, jwt.MapClaims{"role": "admin", "iss": "issuer", "aud": "other", "exp": time.Now().Add(time.Hour).Unix()}),
+		"Unsigned":        signToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, validClaims("admin")),
+		"Invalid tenant":  signToken(t, jwt.SigningMethodHS256, hmacSecret, jwt.MapClaims{"role": "admin", "tenant": "Acme Corp", "iss": "issuer", "aud": "pack-calculator", "exp": time.Now().Add(time.Hour).Unix()}),
+		"Malformed token": "not-a-jwt",
+	}
+	for name, token := range invalidTokens {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			_, authErr := authMediator.Authenticate(context.Background(), domain_model.Credentials{BearerToken: token})
+
+			// Assert
+			require.ErrorIs(t, authErr, mediator.ErrUnauthenticated)
+		})
+	}
+}
+
+func Test_CreateApiKey_OK(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	authMediator := mediator.NewAuthMediator(mediator.WithAuthRepository(repositoryMock))
+
+	// Arrange
+	var storedHash string
+	repositoryMock.
+		On("AddApiKey", mock.Anything, mock.MatchedBy(func(params repository.AddApiKeyParams) bool {
+			storedHash = params.KeyHash
+			return params.Name == "warehouse" && params.Role == "client"
+		})).
+		Return(repository.ApiKey{ApiKeyID: uuid.New(), Name: "warehouse", Role: "client"}, nil)
+
+	// Act
+	apiKey, key, createErr := authMediator.CreateApiKey(context.Background(), "warehouse", domain_model.RoleClient)
+
+	// Assert
+	repositoryMock.AssertExpectations(t)
+	require.NoError(t, createErr)
+	require.Equal(t, domain_model.RoleClient, apiKey.Role)
+	require.True(t, strings.HasPrefix(key, "pck_"))
+	require.NotContains(t, storedHash, key)
+}
+
+func Test_RevokeApiKey_NotFound(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	authMediator := mediator.NewAuthMediator(mediator.WithAuthRepository(repositoryMock))
+
+	// Arrange
+	apiKeyId := uuid.New()
+	repositoryMock.On("RevokeApiKey", mock.Anything, repository.RevokeApiKeyParams{ApiKeyID: apiKeyId, TenantID: "default"}).Return(int64(0), nil)
+
+	// Act
+	revokeErr := authMediator.RevokeApiKey(context.Background(), apiKeyId)
+
+	// Assert
+	repositoryMock.AssertExpectations(t)
+	require.ErrorIs(t, revokeErr, mediator.ErrApiKeyNotFound)
+}
+
+func Test_RevokeApiKey_BootstrapKey(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	authMediator := mediator.NewAuthMediator(mediator.WithAuthRepository(repositoryMock))
+	bootstrapKeyId := uuid.New()
+
+	useCases := []struct {
+		name           string
+		principal      domain_model.Principal
+		includeUnbound bool
+		revoked        int64
+		expectedErr    error
+	}{
+		{
+			name:           "Revoked by an admin bound to no tenant",
+			principal:      domain_model.Principal{Subject: "bootstrap", Role: domain_model.RoleAdmin, Method: domain_model.AuthMethodApiKey},
+			includeUnbound: true,
+			revoked:        1,
+		},
+		{
+			name:        "Not found by an admin bound to a tenant",
+			principal:   domain_model.Principal{Subject: "acme-admin", Role: domain_model.RoleAdmin, Method: domain_model.AuthMethodApiKey, Tenant: "default"},
+			expectedErr: mediator.ErrApiKeyNotFound,
+		},
+	}
+	for _, useCase := range useCases {
+		t.Run(useCase.name, func(t *testing.T) {
+			// Arrange
+			params := repository.RevokeApiKeyParams{ApiKeyID: bootstrapKeyId, TenantID: "default", IncludeUnbound: useCase.includeUnbound}
+			repositoryMock.On("RevokeApiKey", mock.Anything, params).Return(useCase.revoked, nil).Once()
+
+			// Act
+			revokeErr := authMediator.RevokeApiKey(domain_model.ContextWithPrincipal(context.Background(), useCase.principal), bootstrapKeyId)
+
+			// Assert
+			if useCase.expectedErr != nil {
+				require.ErrorIs(t, revokeErr, useCase.expectedErr)
+			} else {
+				require.NoError(t, revokeErr)
+			}
+		})
+	}
+}
+
+func Test_RetrieveApiKeys_UnboundKeys(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	authMediator := mediator.NewAuthMediator(mediator.WithAuthRepository(repositoryMock))
+	principal := domain_model.Principal{Subject: "bootstrap", Role: domain_model.RoleAdmin, Method: domain_model.AuthMethodApiKey}
+
+	// Arrange
+	repositoryMock.On("RetrieveApiKeys", mock.Anything, repository.RetrieveApiKeysParams{TenantID: "default", IncludeUnbound: true}).
+		Return([]repository.ApiKey{{ApiKeyID: uuid.New(), Name: "bootstrap", Role: "admin"}}, nil).Once()
+
+	// Act
+	apiKeys, retrieveErr := authMediator.RetrieveApiKeys(domain_model.ContextWithPrincipal(context.Background(), principal))
+
+	// Assert
+	require.NoError(t, retrieveErr)
+	require.Len(t, apiKeys, 1)
+	require.Empty(t, apiKeys[0].Tenant)
+}
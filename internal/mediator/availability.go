@@ -0,0 +1,91 @@
+package mediator
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+)
+
+var ErrInvalidPackValidity = errors.New("invalid pack validity")
+
+// Set when the pack size of the profile is used, adding the pack when it is missing, so pack set changes can be
+// scheduled ahead of time instead of made when they are due
+func (pm packMediator) SchedulePack(ctx context.Context, validity domain_model.PackValidity) (domain_model.PackValidity, error) {
+	if validity.PackSize <= 0 {
+		return domain_model.PackValidity{}, errors.New(fmt.Sprintf("pack size [%v] must be bigger than 0", validity.PackSize))
+	}
+	if validity.ValidFrom != nil && validity.ValidTo != nil && !validity.ValidFrom.Before(*validity.ValidTo) {
+		return domain_model.PackValidity{}, errors.Wrap(ErrInvalidPackValidity, fmt.Sprintf("valid from [%v] must be before valid to [%v]", *validity.ValidFrom, *validity.ValidTo))
+	}
+	params := repository.SchedulePackParams{
+		Profile:   resolvePackProfile(validity.Profile, pm.defaultProfile),
+		PackSize:  int32(validity.PackSize),
+		TenantID:  domain_model.TenantFromContext(ctx),
+		ValidFrom: pointerToNullTime(validity.ValidFrom),
+		ValidTo:   pointerToNullTime(validity.ValidTo),
+	}
+
+	// Schedule pack and audit it in db
+	var scheduled repository.Pack
+	txErr := pm.packTransactor.WithinTransaction(ctx, func(querier repository.Querier) error {
+		var scheduleErr error
+		if scheduled, scheduleErr = querier.SchedulePack(ctx, params); scheduleErr != nil {
+			return scheduleErr
+		}
+		if auditErr := addPackAudit(ctx, querier, domain_model.PackAuditActionSchedule, params.Profile, validity.PackSize); auditErr != nil {
+			return auditErr
+		}
+		return notifyPackSetChanged(ctx, querier)
+	})
+	if txErr != nil {
+		if isForeignKeyViolation(txErr) {
+			return domain_model.PackValidity{}, errors.Wrap(ErrPackProfileNotFound, fmt.Sprintf("could not schedule pack of size [%v] in profile [%v]", validity.PackSize, params.Profile))
+		}
+		return domain_model.PackValidity{}, errors.Wrap(txErr, fmt.Sprintf("could not schedule pack of size [%v] in profile [%v]", validity.PackSize, params.Profile))
+	}
+	pm.invalidateSolverCache()
+	return translatePackValidityToDomainModel(scheduled), nil
+}
+
+// Retrieve every pack size of the profile with the window it is valid in, biggest first
+func (pm packMediator) RetrievePackSchedule(ctx context.Context, profile string) ([]domain_model.PackValidity, error) {
+	profile = resolvePackProfile(profile, pm.defaultProfile)
+	params := repository.RetrievePackScheduleParams{Profile: profile, TenantID: domain_model.TenantFromContext(ctx)}
+	packs, retrieveErr := pm.packRepository.RetrievePackSchedule(ctx, params)
+	if retrieveErr != nil {
+		return nil, errors.Wrap(retrieveErr, fmt.Sprintf("could not retrieve pack schedule of profile [%v]", profile))
+	}
+	result := make([]domain_model.PackValidity, 0, len(packs))
+	for _, pack := range packs {
+		result = append(result, translatePackValidityToDomainModel(pack))
+	}
+	return result, nil
+}
+
+// Packs are retrieved as valid at the given time, or at the current time of the database when it is zero
+func packsValidAt(at time.Time) sql.NullTime {
+	return sql.NullTime{Time: at, Valid: !at.IsZero()}
+}
+
+func pointerToNullTime(at *time.Time) sql.NullTime {
+	if at == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *at, Valid: true}
+}
+
+// Translate from repository models to domain models
+func translatePackValidityToDomainModel(pack repository.Pack) domain_model.PackValidity {
+	return domain_model.PackValidity{
+		PackSize:  int(pack.PackSize),
+		ValidFrom: nullTimeToPointer(pack.ValidFrom),
+		ValidTo:   nullTimeToPointer(pack.ValidTo),
+		Profile:   pack.Profile,
+	}
+}
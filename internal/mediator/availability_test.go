@@ -0,0 +1,146 @@
+package mediator_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+	repository_mocks "github.com/felipevillarrealdaza/go-service-template/internal/repository/mocks"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SchedulePack(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	packMediator := mediator.NewPackMediator(
+		mediator.WithPackRepository(repositoryMock),
+		mediator.WithPackTransactor(newTransactorMock(t, repositoryMock)),
+	)
+	validFrom := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+	validTo := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Scheduled", func(t *testing.T) {
+		// Arrange
+		params := repository.SchedulePackParams{
+			Profile:   "default",
+			PackSize:  750,
+			TenantID:  "default",
+			ValidFrom: sql.NullTime{Time: validFrom, Valid: true},
+			ValidTo:   sql.NullTime{Time: validTo, Valid: true},
+		}
+		repositoryMock.On("SchedulePack", mock.Anything, params).
+			Return(repository.Pack{PackSize: 750, Profile: "default", ValidFrom: params.ValidFrom, ValidTo: params.ValidTo}, nil).Once()
+		repositoryMock.On("AddPackAudit", mock.Anything, mock.MatchedBy(func(params repository.AddPackAuditParams) bool {
+			return params.Action == "schedule" && params.PackSize == 750
+		})).Return(nil).Once()
+		repositoryMock.On("NotifyPackSetChanged", mock.Anything).Return(nil).Once()
+
+		// Act
+		scheduled, scheduleErr := packMediator.SchedulePack(context.Background(), domain_model.PackValidity{PackSize: 750, ValidFrom: &validFrom, ValidTo: &validTo})
+
+		// Assert
+		require.NoError(t, scheduleErr)
+		require.Equal(t, domain_model.PackValidity{PackSize: 750, ValidFrom: &validFrom, ValidTo: &validTo, Profile: "default"}, scheduled)
+	})
+
+	t.Run("Window ending before it starts", func(t *testing.T) {
+		// Act
+		_, scheduleErr := packMediator.SchedulePack(context.Background(), domain_model.PackValidity{PackSize: 750, ValidFrom: &validTo, ValidTo: &validFrom})
+
+		// Assert
+		require.ErrorIs(t, scheduleErr, mediator.ErrInvalidPackValidity)
+	})
+
+	t.Run("Unknown profile", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("SchedulePack", mock.Anything, mock.MatchedBy(func(params repository.SchedulePackParams) bool {
+			return params.Profile == "wholesale"
+		})).Return(repository.Pack{}, &pq.Error{Code: "23503"}).Once()
+
+		// Act
+		_, scheduleErr := packMediator.SchedulePack(context.Background(), domain_model.PackValidity{PackSize: 750, ValidTo: &validTo, Profile: "wholesale"})
+
+		// Assert
+		require.ErrorIs(t, scheduleErr, mediator.ErrPackProfileNotFound)
+	})
+}
+
+func Test_RetrievePackSchedule(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	packMediator := mediator.NewPackMediator(mediator.WithPackRepository(repositoryMock))
+	validTo := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+
+	// Arrange
+	repositoryMock.On("RetrievePackSchedule", mock.Anything, repository.RetrievePackScheduleParams{Profile: "default", TenantID: "default"}).Return([]repository.Pack{
+		{PackSize: 750, Profile: "default", ValidTo: sql.NullTime{Time: validTo, Valid: true}},
+		{PackSize: 250, Profile: "default"},
+	}, nil).Once()
+
+	// Act
+	schedule, retrieveErr := packMediator.RetrievePackSchedule(context.Background(), "")
+
+	// Assert
+	require.NoError(t, retrieveErr)
+	require.Equal(t, []domain_model.PackValidity{
+		{PackSize: 750, ValidTo: &validTo, Profile: "default"},
+		{PackSize: 250, Profile: "default"},
+	}, schedule)
+}
+
+func Test_CalculateOrderPacks_OrderDate(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	orderMediator := mediator.NewOrderMediator(mediator.WithOrderRepository(repositoryMock), mediator.WithOrderTransactor(newTransactorMock(t, repositoryMock)))
+
+	t.Run("Backdated order created with its date", func(t *testing.T) {
+		// Arrange
+		order := domain_model.Order{OrderId: uuid.New(), Quantity: 500, OrderDate: time.Date(2024, 11, 15, 0, 0, 0, 0, time.UTC)}
+		repositoryMock.On("AddOrder", mock.Anything, repository.AddOrderParams{
+			OrderID:       order.OrderId,
+			OrderQuantity: 500,
+			Profile:       "default",
+			TenantID:      "default",
+			OrderDate:     sql.NullTime{Time: order.OrderDate, Valid: true},
+		}).Return(nil).Once()
+
+		// Act
+		createErr := orderMediator.CreateOrder(context.Background(), order)
+
+		// Assert
+		require.NoError(t, createErr)
+	})
+
+	t.Run("Packs valid at the order date", func(t *testing.T) {
+		// Arrange
+		orderId := uuid.New()
+		orderDate := time.Date(2024, 11, 15, 0, 0, 0, 0, time.UTC)
+		repositoryMock.On("RetrieveOrderById", mock.Anything, repository.RetrieveOrderByIdParams{OrderID: orderId, TenantID: "default"}).
+			Return(repository.Order{OrderID: orderId, OrderQuantity: 12, Status: "created", Profile: "default", OrderDate: orderDate}, nil).Once()
+		repositoryMock.On("RetrievePacks", mock.Anything, repository.RetrievePacksParams{
+			Profile:  "default",
+			TenantID: "default",
+			ValidAt:  sql.NullTime{Time: orderDate, Valid: true},
+		}).Return([]int32{6}, nil).Once()
+		repositoryMock.On("RetrieveContainerTypes", mock.Anything, "default").Return(nil, nil).Once()
+		repositoryMock.On("RetrievePackPrices", mock.Anything, mock.Anything).Return(nil, nil).Once()
+		repositoryMock.On("AddOrderPack", mock.Anything, mock.Anything).Return(nil).Once()
+		repositoryMock.On("UpdateOrderPackSet", mock.Anything, mock.Anything).Return(nil).Once()
+		repositoryMock.On("UpdateOrderStatus", mock.Anything, mock.Anything).Return(repository.Order{OrderID: orderId, Status: "calculated"}, nil).Once()
+
+		// Act
+		orderPacks, calculateErr := orderMediator.CalculateOrderPacks(context.Background(), orderId)
+
+		// Assert
+		require.NoError(t, calculateErr)
+		require.Equal(t, domain_model.OrderPack{6: 2}, orderPacks.OptimalOrderPack)
+	})
+}
@@ -0,0 +1,46 @@
+package mediator
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+)
+
+const MaxChartQuantity = 1000000
+
+// Solve every quantity up to the end of the chart once, calling fn with the packs of the charted ones in order.
+// Quantities more than the largest pack size below the one being solved are no longer needed, so they are dropped
+// and the grid stays as small as the largest pack.
+func (pm packMediator) ChartPacks(ctx context.Context, request domain_model.PackChartRequest, fn func(row domain_model.PackChartRow) error) error {
+	params := repository.RetrievePacksParams{Profile: resolvePackProfile(request.Profile, pm.defaultProfile), TenantID: domain_model.TenantFromContext(ctx)}
+	packs, retrievePacksErr := pm.packRepository.RetrievePacks(ctx, params)
+	if retrievePacksErr != nil {
+		return errors.Wrap(retrievePacksErr, "could not retrieve available packs")
+	}
+	if len(packs) == 0 {
+		return ErrEmptyPackSet
+	}
+
+	orderPacks := translateToDomainModel(repository.Order{OrderQuantity: int32(request.To)}, packs)
+	largestPack := slices.Max(orderPacks.AvailablePacks)
+	for gridIndex := 1; gridIndex <= request.To; gridIndex++ {
+		solveQuantity(&orderPacks, gridIndex)
+		delete(orderPacks.ResultGrid, gridIndex-largestPack)
+
+		if gridIndex < request.From || (gridIndex-request.From)%request.Step != 0 {
+			continue
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return errors.Wrap(ctxErr, fmt.Sprintf("chart interrupted at quantity [%v]", gridIndex))
+		}
+		if fnErr := fn(domain_model.PackChartRow{Quantity: gridIndex, Packs: orderPacks.ResultGrid[gridIndex]}); fnErr != nil {
+			return fnErr
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package mediator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+	repository_mocks "github.com/felipevillarrealdaza/go-service-template/internal/repository/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ChartPacks(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	packMediator := mediator.NewPackMediator(mediator.WithPackRepository(repositoryMock))
+
+	t.Run("Every step of the range", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrievePacks", mock.Anything, repository.RetrievePacksParams{Profile: "default", TenantID: "default"}).Return([]int32{1000, 500, 250}, nil).Once()
+		var rows []viewmodel.PackChartRow
+
+		// Act
+		chartErr := packMediator.ChartPacks(context.Background(), domain_model.PackChartRequest{From: 1, To: 751, Step: 250}, func(row domain_model.PackChartRow) error {
+			rows = append(rows, row.ToViewModel())
+			return nil
+		})
+
+		// Assert
+		require.NoError(t, chartErr)
+		require.Equal(t, []viewmodel.PackChartRow{
+			{Quantity: 1, Items: 250, Overage: 249, PackCount: 1, Packs: []viewmodel.OrderPack{{Size: 250, Quantity: 1}}},
+			{Quantity: 251, Items: 500, Overage: 249, PackCount: 1, Packs: []viewmodel.OrderPack{{Size: 500, Quantity: 1}}},
+			{Quantity: 501, Items: 750, Overage: 249, PackCount: 2, Packs: []viewmodel.OrderPack{{Size: 250, Quantity: 1}, {Size: 500, Quantity: 1}}},
+			{Quantity: 751, Items: 1000, Overage: 249, PackCount: 1, Packs: []viewmodel.OrderPack{{Size: 1000, Quantity: 1}}},
+		}, rows)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Rows match solving each quantity on its own", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrievePacks", mock.Anything, repository.RetrievePacksParams{Profile: "default", TenantID: "default"}).Return([]int32{53, 31, 23}, nil).Times(2)
+		var orders []repository.Order
+		items, packs := 0, 0
+
+		// Act
+		chartErr := packMediator.ChartPacks(context.Background(), domain_model.PackChartRequest{From: 100, To: 500, Step: 7}, func(row domain_model.PackChartRow) error {
+			orders = append(orders, repository.Order{OrderQuantity: int32(row.Quantity), Status: "created", Profile: "default"})
+			rowItems, rowPacks := row.Packs.TotalItemsAndPackages()
+			items, packs = items+rowItems, packs+rowPacks
+			return nil
+		})
+
+		// Assert
+		require.NoError(t, chartErr)
+		require.Len(t, orders, 58)
+		repositoryMock.On("RetrieveOrders", mock.Anything, "default").Return(orders, nil).Once()
+		report, simulateErr := packMediator.SimulatePackSet(context.Background(), domain_model.PackSetSimulation{PackSizes: []int{53}})
+		require.NoError(t, simulateErr)
+		require.Equal(t, report.Current.ItemsShipped, items)
+		require.Equal(t, report.Current.Packs, packs)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Row error stops the chart", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrievePacks", mock.Anything, repository.RetrievePacksParams{Profile: "default", TenantID: "default"}).Return([]int32{250}, nil).Once()
+		writeErr := errors.New("client went away")
+
+		// Act
+		chartErr := packMediator.ChartPacks(context.Background(), domain_model.PackChartRequest{From: 1, To: 1000, Step: 1}, func(row domain_model.PackChartRow) error {
+			return writeErr
+		})
+
+		// Assert
+		require.ErrorIs(t, chartErr, writeErr)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+}
@@ -0,0 +1,71 @@
+package domain_model
+
+import (
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+)
+
+// Pack set to analyse, the current one when no sizes are given, for every quantity from 1 to UpTo. 0 uses ten
+// times the largest size.
+type PackSetAnalysisRequest struct {
+	PackSizes []int
+	UpTo      int
+	Profile   string
+}
+
+// What a size brings to the pack set up to the analysed quantity. The changes are totals over every quantity when
+// the size is removed, and are left at 0 when it is the only size.
+type PackSizeAnalysis struct {
+	Size              int
+	QuantitiesUsing   int
+	OverageWithout    int
+	ExtraPacksWithout int
+}
+
+// Never part of the packs of any quantity analysed
+func (pa PackSizeAnalysis) Unused() bool {
+	return pa.QuantitiesUsing == 0
+}
+
+// Removing it does not add overage to any quantity analysed, at most more packs
+func (pa PackSizeAnalysis) Redundant(packSetSize int) bool {
+	return packSetSize > 1 && pa.OverageWithout == 0
+}
+
+// FrobeniusNumber is the largest quantity no combination of packs can hit exactly. It is nil when the sizes share a
+// divisor, as quantities that are not multiples of it can never be hit, or when the smallest size is too large to work
+// it out, and -1 when every quantity can be hit.
+type PackSetAnalysis struct {
+	PackSet            []int
+	UpTo               int
+	Gcd                int
+	FrobeniusNumber    *int
+	ExactFits          int
+	MaxOverage         int
+	MaxOverageQuantity int
+	Sizes              []PackSizeAnalysis
+}
+
+func (pa PackSetAnalysis) ToViewModel() viewmodel.PackSetAnalysisResponse {
+	response := viewmodel.PackSetAnalysisResponse{
+		PackSet:            pa.PackSet,
+		UpTo:               pa.UpTo,
+		Gcd:                pa.Gcd,
+		FrobeniusNumber:    pa.FrobeniusNumber,
+		ExactFits:          pa.ExactFits,
+		ExactFitPercentage: roundToHundredths(float64(pa.ExactFits) / float64(max(pa.UpTo, 1)) * 100),
+		MaxOverage:         pa.MaxOverage,
+		MaxOverageQuantity: pa.MaxOverageQuantity,
+		Sizes:              make([]viewmodel.PackSizeAnalysis, 0, len(pa.Sizes)),
+	}
+	for _, size := range pa.Sizes {
+		response.Sizes = append(response.Sizes, viewmodel.PackSizeAnalysis{
+			Size:              size.Size,
+			QuantitiesUsing:   size.QuantitiesUsing,
+			OverageWithout:    size.OverageWithout,
+			ExtraPacksWithout: size.ExtraPacksWithout,
+			Unused:            size.Unused(),
+			Redundant:         size.Redundant(len(pa.PackSet)),
+		})
+	}
+	return response
+}
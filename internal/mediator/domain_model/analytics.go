@@ -0,0 +1,134 @@
+package domain_model
+
+import (
+	"math"
+	"time"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+)
+
+// Creation time of the orders analysed, From is inclusive and To exclusive. Zero values are unbounded.
+type AnalyticsRange struct {
+	From time.Time
+	To   time.Time
+}
+
+func (ar AnalyticsRange) ToViewModel() viewmodel.AnalyticsRange {
+	return viewmodel.AnalyticsRange{From: timeToPointer(ar.From), To: timeToPointer(ar.To)}
+}
+
+// Totals of the calculated orders that were not cancelled. Items shipped are the items in their packs, so the
+// overage is what is given away beyond the quantities ordered.
+type OrderSummary struct {
+	Range        AnalyticsRange
+	Orders       int
+	ItemsOrdered int
+	ItemsShipped int
+	Packs        int
+}
+
+func (s OrderSummary) OverageItems() int {
+	return s.ItemsShipped - s.ItemsOrdered
+}
+
+// Overage as a percentage of the items ordered, 0 when nothing was ordered
+func (s OrderSummary) OveragePercentage() float64 {
+	if s.ItemsOrdered == 0 {
+		return 0
+	}
+	return roundToHundredths(float64(s.OverageItems()) / float64(s.ItemsOrdered) * 100)
+}
+
+func (s OrderSummary) AveragePacksPerOrder() float64 {
+	if s.Orders == 0 {
+		return 0
+	}
+	return roundToHundredths(float64(s.Packs) / float64(s.Orders))
+}
+
+func (s OrderSummary) ToViewModel() viewmodel.OrderSummaryResponse {
+	return viewmodel.OrderSummaryResponse{
+		AnalyticsRange:       s.Range.ToViewModel(),
+		Orders:               s.Orders,
+		ItemsOrdered:         s.ItemsOrdered,
+		ItemsShipped:         s.ItemsShipped,
+		OverageItems:         s.OverageItems(),
+		OveragePercentage:    s.OveragePercentage(),
+		Packs:                s.Packs,
+		AveragePacksPerOrder: s.AveragePacksPerOrder(),
+	}
+}
+
+// How many packs of a size were used, and by how many orders
+type PackUsage struct {
+	Size   int
+	Packs  int
+	Orders int
+}
+
+type PackUsageReport struct {
+	Range AnalyticsRange
+	Usage []PackUsage
+}
+
+func (pr PackUsageReport) ToViewModel() viewmodel.PackUsageResponse {
+	totalPacks := 0
+	for _, usage := range pr.Usage {
+		totalPacks += usage.Packs
+	}
+
+	response := viewmodel.PackUsageResponse{
+		AnalyticsRange: pr.Range.ToViewModel(),
+		Packs:          make([]viewmodel.PackUsage, 0, len(pr.Usage)),
+	}
+	for _, usage := range pr.Usage {
+		response.Packs = append(response.Packs, viewmodel.PackUsage{
+			Size:            usage.Size,
+			Packs:           usage.Packs,
+			Orders:          usage.Orders,
+			Items:           usage.Size * usage.Packs,
+			SharePercentage: roundToHundredths(float64(usage.Packs) / float64(totalPacks) * 100),
+		})
+	}
+	return response
+}
+
+// Orders whose quantity is at least From and below From plus the bucket size. Empty buckets are left out.
+type QuantityBucket struct {
+	From   int
+	Orders int
+}
+
+type QuantityHistogram struct {
+	Range      AnalyticsRange
+	BucketSize int
+	Buckets    []QuantityBucket
+}
+
+func (qh QuantityHistogram) ToViewModel() viewmodel.QuantityHistogramResponse {
+	response := viewmodel.QuantityHistogramResponse{
+		AnalyticsRange: qh.Range.ToViewModel(),
+		BucketSize:     qh.BucketSize,
+		Buckets:        make([]viewmodel.QuantityBucket, 0, len(qh.Buckets)),
+	}
+	for _, bucket := range qh.Buckets {
+		response.Buckets = append(response.Buckets, viewmodel.QuantityBucket{
+			From:   bucket.From,
+			To:     bucket.From + qh.BucketSize,
+			Orders: bucket.Orders,
+		})
+	}
+	return response
+}
+
+// Percentages, averages and costs are reported with two decimals
+func roundToHundredths(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+func timeToPointer(value time.Time) *time.Time {
+	if value.IsZero() {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,62 @@
+package domain_model
+
+import (
+	"context"
+	"time"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+	"github.com/google/uuid"
+)
+
+type PackAuditAction string
+
+const (
+	PackAuditActionAdd      PackAuditAction = "add"
+	PackAuditActionRemove   PackAuditAction = "remove"
+	PackAuditActionSchedule PackAuditAction = "schedule"
+)
+
+// Actor recorded in the audit log for changes made without an authenticated principal, e.g. from the CLI
+const AnonymousActor = "anonymous"
+
+type PackAuditEntry struct {
+	AuditId   uuid.UUID
+	Actor     string
+	Action    PackAuditAction
+	PackSize  int
+	RequestId string
+	CreatedAt time.Time
+	Profile   string
+}
+
+func (pae PackAuditEntry) ToViewModel() viewmodel.PackAuditResponse {
+	return viewmodel.PackAuditResponse{
+		AuditId:   pae.AuditId,
+		Actor:     pae.Actor,
+		Action:    string(pae.Action),
+		PackSize:  pae.PackSize,
+		RequestId: pae.RequestId,
+		CreatedAt: pae.CreatedAt,
+		Profile:   pae.Profile,
+	}
+}
+
+// Filters of the audit log, zero values match everything. From is inclusive and To exclusive.
+type PackAuditFilter struct {
+	Actor    string
+	Action   PackAuditAction
+	PackSize int
+	From     time.Time
+	To       time.Time
+	Limit    int
+	Offset   int
+}
+
+// Actor of the request, identified by authentication method and subject
+func ActorFromContext(ctx context.Context) string {
+	principal, authenticated := PrincipalFromContext(ctx)
+	if !authenticated {
+		return AnonymousActor
+	}
+	return string(principal.Method) + ":" + principal.Subject
+}
@@ -0,0 +1,85 @@
+package domain_model
+
+import (
+	"context"
+	"time"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+	"github.com/google/uuid"
+)
+
+type Role string
+
+const (
+	// Clients can calculate orders
+	RoleClient Role = "client"
+	// Admins can do everything clients do, and also manage packs and API keys
+	RoleAdmin Role = "admin"
+)
+
+func (r Role) IsValid() bool {
+	return r == RoleClient || r == RoleAdmin
+}
+
+// Whether this role is allowed to do what the required role can do
+func (r Role) Grants(required Role) bool {
+	return r == required || r == RoleAdmin
+}
+
+type AuthMethod string
+
+const (
+	AuthMethodApiKey AuthMethod = "api_key"
+	AuthMethodJwt    AuthMethod = "jwt"
+)
+
+// Credentials sent by the client, at most one of them is expected
+type Credentials struct {
+	ApiKey      string
+	BearerToken string
+}
+
+func (c Credentials) IsEmpty() bool {
+	return c.ApiKey == "" && c.BearerToken == ""
+}
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	Subject string
+	Role    Role
+	Method  AuthMethod
+	// Tenant the credentials are bound to, empty when they may act on any tenant
+	Tenant string
+}
+
+type ApiKey struct {
+	ApiKeyId  uuid.UUID
+	Name      string
+	Role      Role
+	CreatedAt time.Time
+	RevokedAt *time.Time
+	Tenant    string
+}
+
+func (ak ApiKey) ToViewModel() viewmodel.ApiKeyResponse {
+	return viewmodel.ApiKeyResponse{
+		ApiKeyId:  ak.ApiKeyId,
+		Name:      ak.Name,
+		Role:      string(ak.Role),
+		CreatedAt: ak.CreatedAt,
+		RevokedAt: ak.RevokedAt,
+		Tenant:    ak.Tenant,
+	}
+}
+
+type principalContextKey struct{}
+
+func ContextWithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// Retrieve the principal of the request, if it was authenticated
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, found := ctx.Value(principalContextKey{}).(Principal)
+	return principal, found
+}
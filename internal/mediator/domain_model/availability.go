@@ -0,0 +1,19 @@
+package domain_model
+
+import (
+	"time"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+)
+
+// Window in which a pack size is used, from ValidFrom included to ValidTo excluded. Nil bounds leave it open.
+type PackValidity struct {
+	PackSize  int
+	ValidFrom *time.Time
+	ValidTo   *time.Time
+	Profile   string
+}
+
+func (pv PackValidity) ToViewModel() viewmodel.PackValidityResponse {
+	return viewmodel.PackValidityResponse{Size: pv.PackSize, ValidFrom: pv.ValidFrom, ValidTo: pv.ValidTo}
+}
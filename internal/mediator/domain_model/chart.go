@@ -0,0 +1,30 @@
+package domain_model
+
+import (
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+)
+
+// Quantities charted, from From up to To, every Step
+type PackChartRequest struct {
+	From    int
+	To      int
+	Step    int
+	Profile string
+}
+
+// Packs of a quantity of the chart
+type PackChartRow struct {
+	Quantity int
+	Packs    OrderPack
+}
+
+func (pr PackChartRow) ToViewModel() viewmodel.PackChartRow {
+	items, packs := pr.Packs.TotalItemsAndPackages()
+	return viewmodel.PackChartRow{
+		Quantity:  pr.Quantity,
+		Items:     items,
+		Overage:   items - pr.Quantity,
+		PackCount: packs,
+		Packs:     pr.Packs.ToViewModel(),
+	}
+}
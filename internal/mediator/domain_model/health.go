@@ -0,0 +1,57 @@
+package domain_model
+
+import (
+	"time"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+)
+
+type HealthStatus string
+
+const (
+	HealthStatusUp   HealthStatus = "up"
+	HealthStatusDown HealthStatus = "down"
+)
+
+type HealthCheck struct {
+	Name     string
+	Status   HealthStatus
+	Err      error
+	Duration time.Duration
+}
+
+type HealthReport struct {
+	Status HealthStatus
+	Checks []HealthCheck
+}
+
+// Add a check to the report. A single failing check marks the whole report as down.
+func (hr *HealthReport) AddCheck(check HealthCheck) {
+	if hr.Status == "" {
+		hr.Status = HealthStatusUp
+	}
+	if check.Status != HealthStatusUp {
+		hr.Status = HealthStatusDown
+	}
+	hr.Checks = append(hr.Checks, check)
+}
+
+func (hr HealthReport) IsHealthy() bool {
+	return hr.Status == HealthStatusUp
+}
+
+func (hr HealthReport) ToViewModel() viewmodel.HealthResponse {
+	healthResponse := viewmodel.HealthResponse{Status: string(hr.Status)}
+	for _, check := range hr.Checks {
+		checkResponse := viewmodel.HealthCheck{
+			Name:       check.Name,
+			Status:     string(check.Status),
+			DurationMs: check.Duration.Milliseconds(),
+		}
+		if check.Err != nil {
+			checkResponse.Error = check.Err.Error()
+		}
+		healthResponse.Checks = append(healthResponse.Checks, checkResponse)
+	}
+	return healthResponse
+}
@@ -2,18 +2,78 @@ package domain_model
 
 import (
 	"math"
+	"sort"
+	"time"
 
 	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
 	"github.com/google/uuid"
 )
 
-type Order struct {
-	OrderId  uuid.UUID
-	Quantity int
+type OrderStatus string
+
+const (
+	OrderStatusCreated    OrderStatus = "created"
+	OrderStatusCalculated OrderStatus = "calculated"
+	OrderStatusPicked     OrderStatus = "picked"
+	OrderStatusPacked     OrderStatus = "packed"
+	OrderStatusShipped    OrderStatus = "shipped"
+	OrderStatusCancelled  OrderStatus = "cancelled"
+)
+
+// Statuses each status can move to. Orders can be cancelled until they are shipped, shipped and cancelled are final.
+var orderStatusTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusCreated:    {OrderStatusCalculated, OrderStatusCancelled},
+	OrderStatusCalculated: {OrderStatusPicked, OrderStatusCancelled},
+	OrderStatusPicked:     {OrderStatusPacked, OrderStatusCancelled},
+	OrderStatusPacked:     {OrderStatusShipped, OrderStatusCancelled},
 }
 
-func (o Order) ToViewModel() {
+func (os OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderStatusTransitions[os] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
 
+// Quantities can only be amended before the order is picked
+func (os OrderStatus) IsAmendable() bool {
+	return os == OrderStatusCreated || os == OrderStatusCalculated
+}
+
+type Order struct {
+	OrderId      uuid.UUID
+	Quantity     int
+	Status       OrderStatus
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	CalculatedAt *time.Time
+	PickedAt     *time.Time
+	PackedAt     *time.Time
+	ShippedAt    *time.Time
+	CancelledAt  *time.Time
+	PackSet      []int
+	Profile      string
+	OrderDate    time.Time
+}
+
+func (o Order) ToViewModel() viewmodel.OrderDetailsResponse {
+	return viewmodel.OrderDetailsResponse{
+		OrderId:      o.OrderId,
+		Quantity:     o.Quantity,
+		Status:       string(o.Status),
+		CreatedAt:    o.CreatedAt,
+		UpdatedAt:    o.UpdatedAt,
+		CalculatedAt: o.CalculatedAt,
+		PickedAt:     o.PickedAt,
+		PackedAt:     o.PackedAt,
+		ShippedAt:    o.ShippedAt,
+		CancelledAt:  o.CancelledAt,
+		PackSet:      o.PackSet,
+		Profile:      o.Profile,
+		OrderDate:    o.OrderDate,
+	}
 }
 
 type AvailablePacks []int
@@ -28,6 +88,34 @@ func (op OrderPack) TotalItemsAndPackages() (int, int) {
 	return totalItemsPackaged, totalPackages
 }
 
+// Packs in current that are not in previous, and packs in previous that are not in current, by size
+func (op OrderPack) Diff(previous OrderPack) (added OrderPack, removed OrderPack) {
+	added, removed = make(OrderPack), make(OrderPack)
+	for packSize, packQuantity := range op {
+		if delta := packQuantity - previous[packSize]; delta > 0 {
+			added[packSize] = delta
+		}
+	}
+	for packSize, packQuantity := range previous {
+		if delta := packQuantity - op[packSize]; delta > 0 {
+			removed[packSize] = delta
+		}
+	}
+	return added, removed
+}
+
+// Packs with a quantity, sorted by size
+func (op OrderPack) ToViewModel() []viewmodel.OrderPack {
+	orderPacks := make([]viewmodel.OrderPack, 0, len(op))
+	for packSize, packQuantity := range op {
+		if packQuantity > 0 {
+			orderPacks = append(orderPacks, viewmodel.OrderPack{Size: packSize, Quantity: packQuantity})
+		}
+	}
+	sort.Slice(orderPacks, func(i, j int) bool { return orderPacks[i].Size < orderPacks[j].Size })
+	return orderPacks
+}
+
 type Pack struct {
 	PackId   uuid.UUID
 	PackSize int
@@ -41,6 +129,9 @@ type OrderPacks struct {
 	BestItemQuantity int
 	BestPackQuantity int
 	OptimalOrderPack OrderPack
+	Packaging        []PackagingUnit
+	Shipments        []Shipment
+	Price            *OrderPrice
 }
 
 func (o *OrderPacks) ResetItemsAndPackageQuantities() {
@@ -56,9 +147,46 @@ func (o *OrderPacks) UseAsOptimalSolution(currentPackArrangement OrderPack) {
 }
 
 func (o OrderPacks) ToViewModel() viewmodel.OrderResponse {
-	var orderPacksResponse viewmodel.OrderResponse
+	orderPacksResponse := viewmodel.OrderResponse{OrderId: o.OrderId}
 	for packSize, packQuantity := range o.ResultGrid[o.OrderQuantity] {
 		orderPacksResponse.Packs = append(orderPacksResponse.Packs, viewmodel.OrderPack{Size: packSize, Quantity: packQuantity})
 	}
+	for _, unit := range o.Packaging {
+		orderPacksResponse.Packaging = append(orderPacksResponse.Packaging, unit.ToViewModel())
+	}
+	orderPacksResponse.Shipments = shipmentsToViewModel(o.Shipments)
+	orderPacksResponse.Price = o.Price.ToViewModel()
 	return orderPacksResponse
 }
+
+// Result of amending the quantity of an order, with the packs added and removed by the recalculation
+type OrderAmendment struct {
+	Order     Order
+	Packs     OrderPack
+	Added     OrderPack
+	Removed   OrderPack
+	Shipments []Shipment
+	Price     *OrderPrice
+}
+
+func (oa OrderAmendment) ToViewModel() viewmodel.OrderAmendmentResponse {
+	return viewmodel.OrderAmendmentResponse{
+		Order:   oa.Order.ToViewModel(),
+		Packs:   oa.Packs.ToViewModel(),
+		Added:   oa.Added.ToViewModel(),
+		Removed: oa.Removed.ToViewModel(),
+
+		Shipments: shipmentsToViewModel(oa.Shipments),
+		Price:     oa.Price.ToViewModel(),
+	}
+}
+
+// Order along with its packs, as exported
+type OrderExport struct {
+	Order Order
+	Packs OrderPack
+}
+
+func (oe OrderExport) ToViewModel() viewmodel.OrderExport {
+	return viewmodel.OrderExport{OrderDetailsResponse: oe.Order.ToViewModel(), Packs: oe.Packs.ToViewModel()}
+}
@@ -0,0 +1,53 @@
+package domain_model
+
+import (
+	"time"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+)
+
+// Container holding either packs of one size or containers of another type, up to any of its capacities.
+// Each pack size and container type has at most one container type holding it, so they form a chain.
+type ContainerType struct {
+	Name       string
+	PackSize   int
+	Container  string
+	Capacities []int
+	CreatedAt  time.Time
+}
+
+func (ct ContainerType) ToViewModel() viewmodel.ContainerTypeResponse {
+	return viewmodel.ContainerTypeResponse{
+		Name:       ct.Name,
+		PackSize:   ct.PackSize,
+		Container:  ct.Container,
+		Capacities: ct.Capacities,
+		CreatedAt:  ct.CreatedAt,
+	}
+}
+
+// Containers of one type, or loose packs when Container is empty, each holding the contents once.
+// Quantities of the contents are per unit of their parent.
+type PackagingUnit struct {
+	Container string
+	PackSize  int
+	Quantity  int
+	Contents  *PackagingUnit
+}
+
+func (pu PackagingUnit) ToViewModel() viewmodel.PackagingUnit {
+	unit := viewmodel.PackagingUnit{Container: pu.Container, PackSize: pu.PackSize, Quantity: pu.Quantity}
+	if pu.Contents != nil {
+		contents := pu.Contents.ToViewModel()
+		unit.Contents = &contents
+	}
+	return unit
+}
+
+// Packs held by the unit across every level of nesting
+func (pu PackagingUnit) TotalPacks() int {
+	if pu.Contents == nil {
+		return pu.Quantity
+	}
+	return pu.Quantity * pu.Contents.TotalPacks()
+}
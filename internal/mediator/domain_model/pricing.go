@@ -0,0 +1,72 @@
+package domain_model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+)
+
+// Price of a pack size, in the currency it was set in
+type PackPrice struct {
+	PackSize int
+	Price    decimal.Decimal
+	Currency string
+	Profile  string
+}
+
+func (pp PackPrice) ToViewModel() viewmodel.PackPriceResponse {
+	return viewmodel.PackPriceResponse{Size: pp.PackSize, Price: pp.Price.StringFixed(2), Currency: pp.Currency}
+}
+
+// Discount applied to orders of at least the minimum quantity of items
+type DiscountTier struct {
+	MinQuantity int
+	Percent     decimal.Decimal
+	CreatedAt   time.Time
+}
+
+func (dt DiscountTier) ToViewModel() viewmodel.DiscountTierResponse {
+	return viewmodel.DiscountTierResponse{MinQuantity: dt.MinQuantity, Percent: dt.Percent.StringFixed(2), CreatedAt: dt.CreatedAt}
+}
+
+// Packs of one size in a priced order
+type PriceLine struct {
+	PackSize  int
+	Quantity  int
+	UnitPrice decimal.Decimal
+	LineTotal decimal.Decimal
+}
+
+// Price of an order as calculated, kept with the order so later price changes leave it as it was
+type OrderPrice struct {
+	Currency        string
+	Lines           []PriceLine
+	Subtotal        decimal.Decimal
+	DiscountPercent decimal.Decimal
+	Discount        decimal.Decimal
+	Total           decimal.Decimal
+}
+
+func (op *OrderPrice) ToViewModel() *viewmodel.OrderPriceResponse {
+	if op == nil {
+		return nil
+	}
+	response := viewmodel.OrderPriceResponse{
+		Currency:        op.Currency,
+		Subtotal:        op.Subtotal.StringFixed(2),
+		DiscountPercent: op.DiscountPercent.StringFixed(2),
+		Discount:        op.Discount.StringFixed(2),
+		Total:           op.Total.StringFixed(2),
+	}
+	for _, line := range op.Lines {
+		response.Lines = append(response.Lines, viewmodel.PriceLine{
+			Size:      line.PackSize,
+			Quantity:  line.Quantity,
+			UnitPrice: line.UnitPrice.StringFixed(2),
+			LineTotal: line.LineTotal.StringFixed(2),
+		})
+	}
+	return &response
+}
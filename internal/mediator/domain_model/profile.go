@@ -0,0 +1,20 @@
+package domain_model
+
+import (
+	"time"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+)
+
+// Profile every pack, order and quote belonged to before profiles existed
+const DefaultPackProfile = "default"
+
+// Named pack set, e.g. of a warehouse or sales channel, with its own pack sizes, specifications and prices
+type PackProfile struct {
+	Name      string
+	CreatedAt time.Time
+}
+
+func (pp PackProfile) ToViewModel() viewmodel.PackProfileResponse {
+	return viewmodel.PackProfileResponse{Name: pp.Name, CreatedAt: pp.CreatedAt}
+}
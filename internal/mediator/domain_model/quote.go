@@ -0,0 +1,30 @@
+package domain_model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+)
+
+// Packs and price offered for a quantity, which an order can be created from until the quote expires
+type Quote struct {
+	QuoteId   uuid.UUID
+	Quantity  int
+	Packs     OrderPack
+	Price     *OrderPrice
+	ExpiresAt time.Time
+	Profile   string
+}
+
+func (q Quote) ToViewModel() viewmodel.QuoteResponse {
+	return viewmodel.QuoteResponse{
+		QuoteId:   q.QuoteId,
+		Quantity:  q.Quantity,
+		Packs:     q.Packs.ToViewModel(),
+		Price:     q.Price.ToViewModel(),
+		ExpiresAt: q.ExpiresAt,
+		Profile:   q.Profile,
+	}
+}
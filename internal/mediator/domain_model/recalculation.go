@@ -0,0 +1,74 @@
+package domain_model
+
+import (
+	"time"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+	"github.com/google/uuid"
+)
+
+// Orders of a profile to recalculate with its current pack set. Only amendable statuses can be recalculated, zero
+// values match every amendable order of the default profile. From is inclusive and To exclusive.
+type RecalculationFilter struct {
+	Statuses  []OrderStatus
+	From      time.Time
+	To        time.Time
+	BatchSize int
+	DryRun    bool
+	Profile   string
+}
+
+// An order whose packs changed when recalculated with the pack set valid at its date. Waste is the number of items
+// packed beyond the order quantity.
+type OrderRecalculation struct {
+	OrderId       uuid.UUID
+	Quantity      int
+	PreviousWaste int
+	Waste         int
+	Added         OrderPack
+	Removed       OrderPack
+	PackSet       []int
+}
+
+type RecalculationReport struct {
+	DryRun   bool
+	Profile  string
+	PackSet  []int
+	Examined int
+	Changed  []OrderRecalculation
+	// Orders left as they were because no pack of the profile was valid at their date
+	Skipped []uuid.UUID
+}
+
+// Items saved by the recalculation across the changed orders, negative when the waste grew
+func (rr RecalculationReport) WasteSaved() int {
+	saved := 0
+	for _, recalculation := range rr.Changed {
+		saved += recalculation.PreviousWaste - recalculation.Waste
+	}
+	return saved
+}
+
+func (rr RecalculationReport) ToViewModel() viewmodel.RecalculationResponse {
+	response := viewmodel.RecalculationResponse{
+		DryRun:     rr.DryRun,
+		Profile:    rr.Profile,
+		PackSet:    rr.PackSet,
+		Examined:   rr.Examined,
+		WasteSaved: rr.WasteSaved(),
+		Changed:    make([]viewmodel.OrderRecalculation, 0, len(rr.Changed)),
+		Skipped:    append(make([]uuid.UUID, 0, len(rr.Skipped)), rr.Skipped...),
+	}
+	for _, recalculation := range rr.Changed {
+		response.Changed = append(response.Changed, viewmodel.OrderRecalculation{
+			OrderId:       recalculation.OrderId,
+			Quantity:      recalculation.Quantity,
+			PreviousWaste: recalculation.PreviousWaste,
+			Waste:         recalculation.Waste,
+			Added:         recalculation.Added.ToViewModel(),
+			Removed:       recalculation.Removed.ToViewModel(),
+			PackSet:       recalculation.PackSet,
+		})
+	}
+	return response
+}
@@ -0,0 +1,93 @@
+package domain_model
+
+import (
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+)
+
+// Search for at most MaxSizes pack sizes out of the candidates. No candidates means the current sizes along with the
+// most frequent order quantities. The search stops after MaxEvaluations pack sets, 0 uses the default.
+type RecommendationRequest struct {
+	MaxSizes       int
+	Candidates     []int
+	MaxEvaluations int
+	Profile        string
+}
+
+// How the order history is packed with a pack set
+type PackSetScore struct {
+	PackSet []int
+	Orders  int
+	Overage int
+	Packs   int
+}
+
+func (ps PackSetScore) ExpectedOverage() float64 {
+	if ps.Orders == 0 {
+		return 0
+	}
+	return roundToHundredths(float64(ps.Overage) / float64(ps.Orders))
+}
+
+func (ps PackSetScore) ExpectedPacks() float64 {
+	if ps.Orders == 0 {
+		return 0
+	}
+	return roundToHundredths(float64(ps.Packs) / float64(ps.Orders))
+}
+
+// Less overage wins, as it does for a single order, then fewer packs and then fewer sizes to stock
+func (ps PackSetScore) Better(other PackSetScore) bool {
+	if ps.Overage != other.Overage {
+		return ps.Overage < other.Overage
+	}
+	if ps.Packs != other.Packs {
+		return ps.Packs < other.Packs
+	}
+	return len(ps.PackSet) < len(other.PackSet)
+}
+
+func (ps PackSetScore) ToViewModel() viewmodel.PackSetScore {
+	return viewmodel.PackSetScore{
+		PackSet:         ps.PackSet,
+		Overage:         ps.Overage,
+		Packs:           ps.Packs,
+		ExpectedOverage: ps.ExpectedOverage(),
+		ExpectedPacks:   ps.ExpectedPacks(),
+	}
+}
+
+// What a candidate size brings to the recommended set. Changes are the total overage and packs from removing the
+// size when it was selected or adding it when it was not.
+type CandidateMetrics struct {
+	Size          int
+	Selected      bool
+	OrdersUsing   int
+	OverageChange int
+	PacksChange   int
+}
+
+type PackSetRecommendation struct {
+	Evaluations int
+	Current     PackSetScore
+	Recommended PackSetScore
+	Candidates  []CandidateMetrics
+}
+
+func (pr PackSetRecommendation) ToViewModel() viewmodel.RecommendationResponse {
+	response := viewmodel.RecommendationResponse{
+		Orders:      pr.Recommended.Orders,
+		Evaluations: pr.Evaluations,
+		Current:     pr.Current.ToViewModel(),
+		Recommended: pr.Recommended.ToViewModel(),
+		Candidates:  make([]viewmodel.CandidateMetrics, 0, len(pr.Candidates)),
+	}
+	for _, candidate := range pr.Candidates {
+		candidateMetrics := viewmodel.CandidateMetrics{Size: candidate.Size, Selected: candidate.Selected, OrdersUsing: candidate.OrdersUsing}
+		if orders := pr.Recommended.Orders; orders > 0 {
+			candidateMetrics.ExpectedOverageChange = roundToHundredths(float64(candidate.OverageChange) / float64(orders))
+			candidateMetrics.ExpectedPacksChange = roundToHundredths(float64(candidate.PacksChange) / float64(orders))
+		}
+		response.Candidates = append(response.Candidates, candidateMetrics)
+	}
+	return response
+}
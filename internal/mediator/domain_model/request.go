@@ -0,0 +1,15 @@
+package domain_model
+
+import "context"
+
+type requestIdContextKey struct{}
+
+func ContextWithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdContextKey{}, requestId)
+}
+
+// Retrieve the id of the request, empty when the request went through no request id middleware
+func RequestIdFromContext(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIdContextKey{}).(string)
+	return requestId
+}
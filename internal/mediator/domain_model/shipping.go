@@ -0,0 +1,100 @@
+package domain_model
+
+import (
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+)
+
+// Weight and outer dimensions of a pack size, zero when unknown
+type PackSpecification struct {
+	PackSize       int
+	MaxWeightGrams int
+	LengthMm       int
+	WidthMm        int
+	HeightMm       int
+	Profile        string
+}
+
+func (ps PackSpecification) ToViewModel() viewmodel.PackSpecificationResponse {
+	return viewmodel.PackSpecificationResponse{
+		Size:           ps.PackSize,
+		MaxWeightGrams: ps.MaxWeightGrams,
+		LengthMm:       ps.LengthMm,
+		WidthMm:        ps.WidthMm,
+		HeightMm:       ps.HeightMm,
+	}
+}
+
+// Weight of the items and what carriers accept, each pack being a parcel. Zero values mean no constraint.
+type ShippingConstraints struct {
+	ItemWeightGrams       int
+	MaxParcelWeightGrams  int
+	MaxParcelsPerShipment int
+	MaxItemsPerShipment   int
+	// Spread the packs evenly across the shipments instead of filling each one before the next
+	BalanceShipments bool
+}
+
+// Shipments are only planned when there is something to report or to split on
+func (sc ShippingConstraints) Enabled() bool {
+	return sc.ItemWeightGrams > 0 || sc.MaxParcelsPerShipment > 0 || sc.MaxItemsPerShipment > 0
+}
+
+// Whether a pack can be added to the shipment without going over the caps. Empty shipments take any pack, so
+// packs bigger than the items cap still ship on their own.
+func (sc ShippingConstraints) Fits(shipment Shipment, packSize int) bool {
+	items, parcels := shipment.Packs.TotalItemsAndPackages()
+	if parcels == 0 {
+		return true
+	}
+	if sc.MaxParcelsPerShipment > 0 && parcels >= sc.MaxParcelsPerShipment {
+		return false
+	}
+	return sc.MaxItemsPerShipment == 0 || items+packSize <= sc.MaxItemsPerShipment
+}
+
+// Weight of a pack once filled, zero when the item weight is unknown
+func (sc ShippingConstraints) PackWeightGrams(packSize int) int {
+	return packSize * sc.ItemWeightGrams
+}
+
+// Whether a filled pack is within its own maximum weight and the carrier's one
+func (sc ShippingConstraints) CanShip(pack PackSpecification) bool {
+	packWeight := sc.PackWeightGrams(pack.PackSize)
+	if pack.MaxWeightGrams > 0 && packWeight > pack.MaxWeightGrams {
+		return false
+	}
+	return sc.MaxParcelWeightGrams == 0 || packWeight <= sc.MaxParcelWeightGrams
+}
+
+// Packs sent together to the carrier, each of them a parcel
+type Shipment struct {
+	Packs           OrderPack
+	ItemWeightGrams int
+}
+
+func (s Shipment) ParcelCount() int {
+	_, packs := s.Packs.TotalItemsAndPackages()
+	return packs
+}
+
+// Weight of the filled packs, zero when the item weight is unknown
+func (s Shipment) WeightGrams() int {
+	items, _ := s.Packs.TotalItemsAndPackages()
+	return items * s.ItemWeightGrams
+}
+
+func (s Shipment) ToViewModel() viewmodel.ShipmentResponse {
+	shipment := viewmodel.ShipmentResponse{ParcelCount: s.ParcelCount(), WeightGrams: s.WeightGrams()}
+	for _, pack := range s.Packs.ToViewModel() {
+		shipment.Packs = append(shipment.Packs, viewmodel.ShipmentPack{Size: pack.Size, Quantity: pack.Quantity, WeightGrams: pack.Size * s.ItemWeightGrams})
+	}
+	return shipment
+}
+
+func shipmentsToViewModel(shipments []Shipment) []viewmodel.ShipmentResponse {
+	var response []viewmodel.ShipmentResponse
+	for _, shipment := range shipments {
+		response = append(response, shipment.ToViewModel())
+	}
+	return response
+}
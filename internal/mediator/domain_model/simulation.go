@@ -0,0 +1,82 @@
+package domain_model
+
+import (
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+)
+
+// Pack sizes to replay the order history with. Costs are optional, but when given they must price a pack of every
+// size in both the current and the proposed sets.
+type PackSetSimulation struct {
+	PackSizes []int
+	PackCosts map[int]float64
+	Profile   string
+}
+
+func (ps PackSetSimulation) Costed() bool {
+	return len(ps.PackCosts) > 0
+}
+
+// Packs the order history would have been shipped in with a pack set
+type PackSetOutcome struct {
+	PackSet      []int
+	ItemsOrdered int
+	ItemsShipped int
+	Packs        int
+	Cost         float64
+}
+
+func (po PackSetOutcome) Overage() int {
+	return po.ItemsShipped - po.ItemsOrdered
+}
+
+// Add the packs an order would be shipped in
+func (po *PackSetOutcome) Add(quantity int, orderPack OrderPack, packCosts map[int]float64) {
+	items, packs := orderPack.TotalItemsAndPackages()
+	po.ItemsOrdered += quantity
+	po.ItemsShipped += items
+	po.Packs += packs
+	for packSize, packQuantity := range orderPack {
+		po.Cost += packCosts[packSize] * float64(packQuantity)
+	}
+}
+
+func (po PackSetOutcome) toViewModel(costed bool) viewmodel.PackSetOutcome {
+	outcome := viewmodel.PackSetOutcome{
+		PackSet:      po.PackSet,
+		ItemsShipped: po.ItemsShipped,
+		Overage:      po.Overage(),
+		Packs:        po.Packs,
+	}
+	if costed {
+		cost := roundToHundredths(po.Cost)
+		outcome.Cost = &cost
+	}
+	return outcome
+}
+
+type SimulationReport struct {
+	Costed        bool
+	Orders        int
+	ChangedOrders int
+	Current       PackSetOutcome
+	Proposed      PackSetOutcome
+}
+
+func (sr SimulationReport) ToViewModel() viewmodel.SimulationResponse {
+	response := viewmodel.SimulationResponse{
+		Orders:        sr.Orders,
+		ItemsOrdered:  sr.Current.ItemsOrdered,
+		ChangedOrders: sr.ChangedOrders,
+		Current:       sr.Current.toViewModel(sr.Costed),
+		Proposed:      sr.Proposed.toViewModel(sr.Costed),
+		Difference: viewmodel.PackSetDifference{
+			Overage: sr.Proposed.Overage() - sr.Current.Overage(),
+			Packs:   sr.Proposed.Packs - sr.Current.Packs,
+		},
+	}
+	if sr.Costed {
+		cost := roundToHundredths(sr.Proposed.Cost - sr.Current.Cost)
+		response.Difference.Cost = &cost
+	}
+	return response
+}
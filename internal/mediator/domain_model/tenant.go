@@ -0,0 +1,31 @@
+package domain_model
+
+import (
+	"context"
+	"regexp"
+)
+
+// Tenant every pack, order and setting belonged to before tenants existed
+const DefaultTenant = "default"
+
+// Lowercase letters, digits, dashes and underscores, starting with a letter or digit
+var tenantPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
+func IsValidTenant(tenant string) bool {
+	return tenantPattern.MatchString(tenant)
+}
+
+type tenantContextKey struct{}
+
+func ContextWithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenant)
+}
+
+// Retrieve the tenant the request acts on, the default tenant when the request went through no tenant middleware
+func TenantFromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantContextKey{}).(string)
+	if tenant == "" {
+		return DefaultTenant
+	}
+	return tenant
+}
@@ -0,0 +1,140 @@
+package mediator
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+)
+
+const defaultHealthCheckTimeout = 2 * time.Second
+
+type HealthMediatorDeps func(mediator *healthMediator)
+
+func WithHealthRepository(repository repository.Querier) HealthMediatorDeps {
+	return func(mediator *healthMediator) {
+		mediator.healthRepository = repository
+	}
+}
+
+func WithDatabasePinger(pinger DatabasePinger) HealthMediatorDeps {
+	return func(mediator *healthMediator) {
+		mediator.databasePinger = pinger
+	}
+}
+
+func WithHealthCheckTimeout(timeout time.Duration) HealthMediatorDeps {
+	return func(mediator *healthMediator) {
+		mediator.checkTimeout = timeout
+	}
+}
+
+func WithExpectedSchemaVersion(version int) HealthMediatorDeps {
+	return func(mediator *healthMediator) {
+		mediator.expectedSchemaVersion = version
+	}
+}
+
+// DatabasePinger is satisfied by *sql.DB
+type DatabasePinger interface {
+	PingContext(ctx context.Context) error
+}
+
+type HealthMediator interface {
+	CheckLiveness(ctx context.Context) domain_model.HealthReport
+	CheckReadiness(ctx context.Context) domain_model.HealthReport
+	StartDraining()
+}
+
+type healthMediator struct {
+	healthRepository      repository.Querier
+	databasePinger        DatabasePinger
+	checkTimeout          time.Duration
+	expectedSchemaVersion int
+	draining              *atomic.Bool
+}
+
+func NewHealthMediator(deps ...HealthMediatorDeps) HealthMediator {
+	healthMediator := healthMediator{checkTimeout: defaultHealthCheckTimeout, draining: &atomic.Bool{}}
+	for _, opt := range deps {
+		opt(&healthMediator)
+	}
+	return healthMediator
+}
+
+// Liveness only tells whether the process is able to serve requests, it must not depend on external services.
+func (hm healthMediator) CheckLiveness(ctx context.Context) domain_model.HealthReport {
+	return domain_model.HealthReport{Status: domain_model.HealthStatusUp}
+}
+
+// Readiness tells whether the service can handle traffic: it is not shutting down, the database answers, its schema
+// is up to date and at least one pack is configured so orders can be calculated.
+func (hm healthMediator) CheckReadiness(ctx context.Context) domain_model.HealthReport {
+	report := domain_model.HealthReport{}
+	report.AddCheck(hm.runCheck(ctx, "shutdown", hm.checkNotDraining))
+	report.AddCheck(hm.runCheck(ctx, "database", hm.checkDatabase))
+	report.AddCheck(hm.runCheck(ctx, "migrations", hm.checkSchemaVersion))
+	report.AddCheck(hm.runCheck(ctx, "packs", hm.checkPacksConfigured))
+	return report
+}
+
+// Once draining, readiness reports down so load balancers stop sending new requests while in-flight ones finish
+func (hm healthMediator) StartDraining() {
+	hm.draining.Store(true)
+}
+
+// Run a single check bounded by the configured timeout
+func (hm healthMediator) runCheck(ctx context.Context, name string, check func(ctx context.Context) error) domain_model.HealthCheck {
+	checkCtx, cancel := context.WithTimeout(ctx, hm.checkTimeout)
+	defer cancel()
+
+	start := time.Now()
+	checkErr := check(checkCtx)
+	healthCheck := domain_model.HealthCheck{Name: name, Status: domain_model.HealthStatusUp, Duration: time.Since(start)}
+	if checkErr != nil {
+		healthCheck.Status = domain_model.HealthStatusDown
+		healthCheck.Err = checkErr
+	}
+	return healthCheck
+}
+
+func (hm healthMediator) checkNotDraining(ctx context.Context) error {
+	if hm.draining.Load() {
+		return errors.New("service is shutting down")
+	}
+	return nil
+}
+
+func (hm healthMediator) checkDatabase(ctx context.Context) error {
+	if pingErr := hm.databasePinger.PingContext(ctx); pingErr != nil {
+		return errors.Wrap(pingErr, "could not ping database")
+	}
+	return nil
+}
+
+func (hm healthMediator) checkSchemaVersion(ctx context.Context) error {
+	version, versionErr := hm.healthRepository.RetrieveSchemaVersion(ctx)
+	if versionErr != nil {
+		return errors.Wrap(versionErr, "could not retrieve schema version")
+	}
+	if int(version) != hm.expectedSchemaVersion {
+		return errors.New(fmt.Sprintf("schema version [%v] does not match expected version [%v]", version, hm.expectedSchemaVersion))
+	}
+	return nil
+}
+
+func (hm healthMediator) checkPacksConfigured(ctx context.Context) error {
+	packCount, countErr := hm.healthRepository.CountPacks(ctx)
+	if countErr != nil {
+		return errors.Wrap(countErr, "could not count configured packs")
+	}
+	if packCount == 0 {
+		return errors.New("no packs are configured")
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package mediator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	mediator_mocks "github.com/felipevillarrealdaza/go-service-template/internal/mediator/mocks"
+	repository_mocks "github.com/felipevillarrealdaza/go-service-template/internal/repository/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CheckLiveness_OK(t *testing.T) {
+	// Set Up
+	healthMediator := mediator.NewHealthMediator()
+
+	// Act
+	report := healthMediator.CheckLiveness(context.Background())
+
+	// Assert
+	require.True(t, report.IsHealthy())
+}
+
+func Test_CheckReadiness_OK(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	pingerMock := mediator_mocks.NewDatabasePinger(t)
+	healthMediator := mediator.NewHealthMediator(
+		mediator.WithHealthRepository(repositoryMock),
+		mediator.WithDatabasePinger(pingerMock),
+		mediator.WithExpectedSchemaVersion(1),
+	)
+
+	// Arrange
+	pingerMock.On("PingContext", mock.Anything).Return(nil)
+	repositoryMock.On("RetrieveSchemaVersion", mock.Anything).Return(int32(1), nil)
+	repositoryMock.On("CountPacks", mock.Anything).Return(int64(5), nil)
+
+	// Act
+	report := healthMediator.CheckReadiness(context.Background())
+
+	// Assert
+	repositoryMock.AssertExpectations(t)
+	pingerMock.AssertExpectations(t)
+	require.True(t, report.IsHealthy())
+	require.Len(t, report.Checks, 4)
+}
+
+func Test_CheckReadiness_Errors(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	pingerMock := mediator_mocks.NewDatabasePinger(t)
+	healthMediator := mediator.NewHealthMediator(
+		mediator.WithHealthRepository(repositoryMock),
+		mediator.WithDatabasePinger(pingerMock),
+		mediator.WithExpectedSchemaVersion(2),
+	)
+
+	useCases := []struct {
+		name          string
+		pingErr       error
+		schemaVersion int32
+		packCount     int64
+		failingCheck  string
+	}{
+		{name: "Database is down", pingErr: errors.New("connection refused"), schemaVersion: 2, packCount: 5, failingCheck: "database"},
+		{name: "Schema is outdated", schemaVersion: 1, packCount: 5, failingCheck: "migrations"},
+		{name: "No packs configured", schemaVersion: 2, packCount: 0, failingCheck: "packs"},
+	}
+
+	for _, useCase := range useCases {
+		t.Run(useCase.name, func(t *testing.T) {
+			// Arrange
+			pingerMock.On("PingContext", mock.Anything).Return(useCase.pingErr)
+			repositoryMock.On("RetrieveSchemaVersion", mock.Anything).Return(useCase.schemaVersion, nil)
+			repositoryMock.On("CountPacks", mock.Anything).Return(useCase.packCount, nil)
+
+			// Act
+			report := healthMediator.CheckReadiness(context.Background())
+
+			// Assert
+			require.False(t, report.IsHealthy())
+			for _, check := range report.Checks {
+				if check.Name == useCase.failingCheck {
+					require.Equal(t, domain_model.HealthStatusDown, check.Status)
+					require.Error(t, check.Err)
+				} else {
+					require.Equal(t, domain_model.HealthStatusUp, check.Status)
+				}
+			}
+
+			// Clean up
+			repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+			pingerMock.ExpectedCalls = make([]*mock.Call, 0)
+		})
+	}
+}
+
+func Test_CheckReadiness_Draining(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	pingerMock := mediator_mocks.NewDatabasePinger(t)
+	healthMediator := mediator.NewHealthMediator(
+		mediator.WithHealthRepository(repositoryMock),
+		mediator.WithDatabasePinger(pingerMock),
+		mediator.WithExpectedSchemaVersion(1),
+	)
+
+	// Arrange
+	pingerMock.On("PingContext", mock.Anything).Return(nil)
+	repositoryMock.On("RetrieveSchemaVersion", mock.Anything).Return(int32(1), nil)
+	repositoryMock.On("CountPacks", mock.Anything).Return(int64(5), nil)
+	require.True(t, healthMediator.CheckReadiness(context.Background()).IsHealthy())
+
+	// Act
+	healthMediator.StartDraining()
+	report := healthMediator.CheckReadiness(context.Background())
+
+	// Assert
+	require.False(t, report.IsHealthy())
+	require.Equal(t, "shutdown", report.Checks[0].Name)
+	require.Equal(t, domain_model.HealthStatusDown, report.Checks[0].Status)
+	require.True(t, healthMediator.CheckLiveness(context.Background()).IsHealthy())
+}
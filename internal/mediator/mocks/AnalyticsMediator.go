@@ -0,0 +1,114 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain_model "github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AnalyticsMediator is an autogenerated mock type for the AnalyticsMediator type
+type AnalyticsMediator struct {
+	mock.Mock
+}
+
+// RetrieveOrderSummary provides a mock function with given fields: ctx, analyticsRange
+func (_m *AnalyticsMediator) RetrieveOrderSummary(ctx context.Context, analyticsRange domain_model.AnalyticsRange) (domain_model.OrderSummary, error) {
+	ret := _m.Called(ctx, analyticsRange)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveOrderSummary")
+	}
+
+	var r0 domain_model.OrderSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.AnalyticsRange) (domain_model.OrderSummary, error)); ok {
+		return rf(ctx, analyticsRange)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.AnalyticsRange) domain_model.OrderSummary); ok {
+		r0 = rf(ctx, analyticsRange)
+	} else {
+		r0 = ret.Get(0).(domain_model.OrderSummary)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain_model.AnalyticsRange) error); ok {
+		r1 = rf(ctx, analyticsRange)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrievePackUsage provides a mock function with given fields: ctx, analyticsRange
+func (_m *AnalyticsMediator) RetrievePackUsage(ctx context.Context, analyticsRange domain_model.AnalyticsRange) (domain_model.PackUsageReport, error) {
+	ret := _m.Called(ctx, analyticsRange)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrievePackUsage")
+	}
+
+	var r0 domain_model.PackUsageReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.AnalyticsRange) (domain_model.PackUsageReport, error)); ok {
+		return rf(ctx, analyticsRange)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.AnalyticsRange) domain_model.PackUsageReport); ok {
+		r0 = rf(ctx, analyticsRange)
+	} else {
+		r0 = ret.Get(0).(domain_model.PackUsageReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain_model.AnalyticsRange) error); ok {
+		r1 = rf(ctx, analyticsRange)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveQuantityHistogram provides a mock function with given fields: ctx, analyticsRange, bucketSize
+func (_m *AnalyticsMediator) RetrieveQuantityHistogram(ctx context.Context, analyticsRange domain_model.AnalyticsRange, bucketSize int) (domain_model.QuantityHistogram, error) {
+	ret := _m.Called(ctx, analyticsRange, bucketSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveQuantityHistogram")
+	}
+
+	var r0 domain_model.QuantityHistogram
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.AnalyticsRange, int) (domain_model.QuantityHistogram, error)); ok {
+		return rf(ctx, analyticsRange, bucketSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.AnalyticsRange, int) domain_model.QuantityHistogram); ok {
+		r0 = rf(ctx, analyticsRange, bucketSize)
+	} else {
+		r0 = ret.Get(0).(domain_model.QuantityHistogram)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain_model.AnalyticsRange, int) error); ok {
+		r1 = rf(ctx, analyticsRange, bucketSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAnalyticsMediator creates a new instance of AnalyticsMediator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAnalyticsMediator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AnalyticsMediator {
+	mock := &AnalyticsMediator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,78 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain_model "github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuditMediator is an autogenerated mock type for the AuditMediator type
+type AuditMediator struct {
+	mock.Mock
+}
+
+// ExportPackAudits provides a mock function with given fields: ctx, filter, fn
+func (_m *AuditMediator) ExportPackAudits(ctx context.Context, filter domain_model.PackAuditFilter, fn func(domain_model.PackAuditEntry) error) error {
+	ret := _m.Called(ctx, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportPackAudits")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.PackAuditFilter, func(domain_model.PackAuditEntry) error) error); ok {
+		r0 = rf(ctx, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RetrievePackAudits provides a mock function with given fields: ctx, filter
+func (_m *AuditMediator) RetrievePackAudits(ctx context.Context, filter domain_model.PackAuditFilter) ([]domain_model.PackAuditEntry, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrievePackAudits")
+	}
+
+	var r0 []domain_model.PackAuditEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.PackAuditFilter) ([]domain_model.PackAuditEntry, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.PackAuditFilter) []domain_model.PackAuditEntry); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain_model.PackAuditEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain_model.PackAuditFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAuditMediator creates a new instance of AuditMediator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuditMediator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuditMediator {
+	mock := &AuditMediator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,161 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain_model "github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// AuthMediator is an autogenerated mock type for the AuthMediator type
+type AuthMediator struct {
+	mock.Mock
+}
+
+// Authenticate provides a mock function with given fields: ctx, credentials
+func (_m *AuthMediator) Authenticate(ctx context.Context, credentials domain_model.Credentials) (domain_model.Principal, error) {
+	ret := _m.Called(ctx, credentials)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authenticate")
+	}
+
+	var r0 domain_model.Principal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.Credentials) (domain_model.Principal, error)); ok {
+		return rf(ctx, credentials)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.Credentials) domain_model.Principal); ok {
+		r0 = rf(ctx, credentials)
+	} else {
+		r0 = ret.Get(0).(domain_model.Principal)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain_model.Credentials) error); ok {
+		r1 = rf(ctx, credentials)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateApiKey provides a mock function with given fields: ctx, name, role
+func (_m *AuthMediator) CreateApiKey(ctx context.Context, name string, role domain_model.Role) (domain_model.ApiKey, string, error) {
+	ret := _m.Called(ctx, name, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateApiKey")
+	}
+
+	var r0 domain_model.ApiKey
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain_model.Role) (domain_model.ApiKey, string, error)); ok {
+		return rf(ctx, name, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain_model.Role) domain_model.ApiKey); ok {
+		r0 = rf(ctx, name, role)
+	} else {
+		r0 = ret.Get(0).(domain_model.ApiKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain_model.Role) string); ok {
+		r1 = rf(ctx, name, role)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, domain_model.Role) error); ok {
+		r2 = rf(ctx, name, role)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// EnsureApiKey provides a mock function with given fields: ctx, name, key, role
+func (_m *AuthMediator) EnsureApiKey(ctx context.Context, name string, key string, role domain_model.Role) error {
+	ret := _m.Called(ctx, name, key, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnsureApiKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, domain_model.Role) error); ok {
+		r0 = rf(ctx, name, key, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RetrieveApiKeys provides a mock function with given fields: ctx
+func (_m *AuthMediator) RetrieveApiKeys(ctx context.Context) ([]domain_model.ApiKey, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveApiKeys")
+	}
+
+	var r0 []domain_model.ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain_model.ApiKey, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain_model.ApiKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain_model.ApiKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeApiKey provides a mock function with given fields: ctx, apiKeyId
+func (_m *AuthMediator) RevokeApiKey(ctx context.Context, apiKeyId uuid.UUID) error {
+	ret := _m.Called(ctx, apiKeyId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeApiKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, apiKeyId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewAuthMediator creates a new instance of AuthMediator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuthMediator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuthMediator {
+	mock := &AuthMediator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
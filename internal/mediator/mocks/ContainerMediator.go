@@ -0,0 +1,106 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain_model "github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ContainerMediator is an autogenerated mock type for the ContainerMediator type
+type ContainerMediator struct {
+	mock.Mock
+}
+
+// AddContainerType provides a mock function with given fields: ctx, containerType
+func (_m *ContainerMediator) AddContainerType(ctx context.Context, containerType domain_model.ContainerType) (domain_model.ContainerType, error) {
+	ret := _m.Called(ctx, containerType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddContainerType")
+	}
+
+	var r0 domain_model.ContainerType
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.ContainerType) (domain_model.ContainerType, error)); ok {
+		return rf(ctx, containerType)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.ContainerType) domain_model.ContainerType); ok {
+		r0 = rf(ctx, containerType)
+	} else {
+		r0 = ret.Get(0).(domain_model.ContainerType)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain_model.ContainerType) error); ok {
+		r1 = rf(ctx, containerType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveContainerType provides a mock function with given fields: ctx, name
+func (_m *ContainerMediator) RemoveContainerType(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveContainerType")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RetrieveContainerTypes provides a mock function with given fields: ctx
+func (_m *ContainerMediator) RetrieveContainerTypes(ctx context.Context) ([]domain_model.ContainerType, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveContainerTypes")
+	}
+
+	var r0 []domain_model.ContainerType
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain_model.ContainerType, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain_model.ContainerType); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain_model.ContainerType)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewContainerMediator creates a new instance of ContainerMediator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewContainerMediator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ContainerMediator {
+	mock := &ContainerMediator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
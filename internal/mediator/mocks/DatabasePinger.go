@@ -0,0 +1,46 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DatabasePinger is an autogenerated mock type for the DatabasePinger type
+type DatabasePinger struct {
+	mock.Mock
+}
+
+// PingContext provides a mock function with given fields: ctx
+func (_m *DatabasePinger) PingContext(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PingContext")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewDatabasePinger creates a new instance of DatabasePinger. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDatabasePinger(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DatabasePinger {
+	mock := &DatabasePinger{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
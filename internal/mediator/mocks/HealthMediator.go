@@ -0,0 +1,71 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain_model "github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// HealthMediator is an autogenerated mock type for the HealthMediator type
+type HealthMediator struct {
+	mock.Mock
+}
+
+// CheckLiveness provides a mock function with given fields: ctx
+func (_m *HealthMediator) CheckLiveness(ctx context.Context) domain_model.HealthReport {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckLiveness")
+	}
+
+	var r0 domain_model.HealthReport
+	if rf, ok := ret.Get(0).(func(context.Context) domain_model.HealthReport); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(domain_model.HealthReport)
+	}
+
+	return r0
+}
+
+// CheckReadiness provides a mock function with given fields: ctx
+func (_m *HealthMediator) CheckReadiness(ctx context.Context) domain_model.HealthReport {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckReadiness")
+	}
+
+	var r0 domain_model.HealthReport
+	if rf, ok := ret.Get(0).(func(context.Context) domain_model.HealthReport); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(domain_model.HealthReport)
+	}
+
+	return r0
+}
+
+// StartDraining provides a mock function with no fields
+func (_m *HealthMediator) StartDraining() {
+	_m.Called()
+}
+
+// NewHealthMediator creates a new instance of HealthMediator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHealthMediator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HealthMediator {
+	mock := &HealthMediator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -17,6 +17,62 @@ type OrderMediator struct {
 	mock.Mock
 }
 
+// AcceptQuote provides a mock function with given fields: ctx, quoteId
+func (_m *OrderMediator) AcceptQuote(ctx context.Context, quoteId uuid.UUID) (domain_model.OrderPacks, error) {
+	ret := _m.Called(ctx, quoteId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptQuote")
+	}
+
+	var r0 domain_model.OrderPacks
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (domain_model.OrderPacks, error)); ok {
+		return rf(ctx, quoteId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) domain_model.OrderPacks); ok {
+		r0 = rf(ctx, quoteId)
+	} else {
+		r0 = ret.Get(0).(domain_model.OrderPacks)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, quoteId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AmendOrderQuantity provides a mock function with given fields: ctx, orderId, quantity
+func (_m *OrderMediator) AmendOrderQuantity(ctx context.Context, orderId uuid.UUID, quantity int) (domain_model.OrderAmendment, error) {
+	ret := _m.Called(ctx, orderId, quantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AmendOrderQuantity")
+	}
+
+	var r0 domain_model.OrderAmendment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) (domain_model.OrderAmendment, error)); ok {
+		return rf(ctx, orderId, quantity)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) domain_model.OrderAmendment); ok {
+		r0 = rf(ctx, orderId, quantity)
+	} else {
+		r0 = ret.Get(0).(domain_model.OrderAmendment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int) error); ok {
+		r1 = rf(ctx, orderId, quantity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CalculateOrderPacks provides a mock function with given fields: ctx, orderId
 func (_m *OrderMediator) CalculateOrderPacks(ctx context.Context, orderId uuid.UUID) (domain_model.OrderPacks, error) {
 	ret := _m.Called(ctx, orderId)
@@ -63,6 +119,164 @@ func (_m *OrderMediator) CreateOrder(ctx context.Context, order domain_model.Ord
 	return r0
 }
 
+// CreateQuote provides a mock function with given fields: ctx, profile, quantity
+func (_m *OrderMediator) CreateQuote(ctx context.Context, profile string, quantity int) (domain_model.Quote, error) {
+	ret := _m.Called(ctx, profile, quantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateQuote")
+	}
+
+	var r0 domain_model.Quote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (domain_model.Quote, error)); ok {
+		return rf(ctx, profile, quantity)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) domain_model.Quote); ok {
+		r0 = rf(ctx, profile, quantity)
+	} else {
+		r0 = ret.Get(0).(domain_model.Quote)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, profile, quantity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportOrders provides a mock function with given fields: ctx, fn
+func (_m *OrderMediator) ExportOrders(ctx context.Context, fn func(domain_model.OrderExport) error) error {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportOrders")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(domain_model.OrderExport) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ImportOrder provides a mock function with given fields: ctx, profile, quantity
+func (_m *OrderMediator) ImportOrder(ctx context.Context, profile string, quantity int) (domain_model.OrderPacks, error) {
+	ret := _m.Called(ctx, profile, quantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportOrder")
+	}
+
+	var r0 domain_model.OrderPacks
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (domain_model.OrderPacks, error)); ok {
+		return rf(ctx, profile, quantity)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) domain_model.OrderPacks); ok {
+		r0 = rf(ctx, profile, quantity)
+	} else {
+		r0 = ret.Get(0).(domain_model.OrderPacks)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, profile, quantity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeExpiredQuotes provides a mock function with given fields: ctx
+func (_m *OrderMediator) PurgeExpiredQuotes(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeExpiredQuotes")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecalculateOrders provides a mock function with given fields: ctx, filter
+func (_m *OrderMediator) RecalculateOrders(ctx context.Context, filter domain_model.RecalculationFilter) (domain_model.RecalculationReport, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecalculateOrders")
+	}
+
+	var r0 domain_model.RecalculationReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.RecalculationFilter) (domain_model.RecalculationReport, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.RecalculationFilter) domain_model.RecalculationReport); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(domain_model.RecalculationReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain_model.RecalculationFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TransitionOrderStatus provides a mock function with given fields: ctx, orderId, status
+func (_m *OrderMediator) TransitionOrderStatus(ctx context.Context, orderId uuid.UUID, status domain_model.OrderStatus) (domain_model.Order, error) {
+	ret := _m.Called(ctx, orderId, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransitionOrderStatus")
+	}
+
+	var r0 domain_model.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain_model.OrderStatus) (domain_model.Order, error)); ok {
+		return rf(ctx, orderId, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, domain_model.OrderStatus) domain_model.Order); ok {
+		r0 = rf(ctx, orderId, status)
+	} else {
+		r0 = ret.Get(0).(domain_model.Order)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, domain_model.OrderStatus) error); ok {
+		r1 = rf(ctx, orderId, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewOrderMediator creates a new instance of OrderMediator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewOrderMediator(t interface {
@@ -5,6 +5,8 @@ package mocks
 import (
 	context "context"
 
+	domain_model "github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -13,17 +15,17 @@ type PackMediator struct {
 	mock.Mock
 }
 
-// AddPack provides a mock function with given fields: ctx, size
-func (_m *PackMediator) AddPack(ctx context.Context, size int) error {
-	ret := _m.Called(ctx, size)
+// AddPack provides a mock function with given fields: ctx, profile, size
+func (_m *PackMediator) AddPack(ctx context.Context, profile string, size int) error {
+	ret := _m.Called(ctx, profile, size)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddPack")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
-		r0 = rf(ctx, size)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = rf(ctx, profile, size)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -31,17 +33,119 @@ func (_m *PackMediator) AddPack(ctx context.Context, size int) error {
 	return r0
 }
 
-// RemovePack provides a mock function with given fields: ctx, size
-func (_m *PackMediator) RemovePack(ctx context.Context, size int) error {
-	ret := _m.Called(ctx, size)
+// AddPackProfile provides a mock function with given fields: ctx, name
+func (_m *PackMediator) AddPackProfile(ctx context.Context, name string) (domain_model.PackProfile, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddPackProfile")
+	}
+
+	var r0 domain_model.PackProfile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain_model.PackProfile, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain_model.PackProfile); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(domain_model.PackProfile)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AnalyzePackSet provides a mock function with given fields: ctx, request
+func (_m *PackMediator) AnalyzePackSet(ctx context.Context, request domain_model.PackSetAnalysisRequest) (domain_model.PackSetAnalysis, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AnalyzePackSet")
+	}
+
+	var r0 domain_model.PackSetAnalysis
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.PackSetAnalysisRequest) (domain_model.PackSetAnalysis, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.PackSetAnalysisRequest) domain_model.PackSetAnalysis); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Get(0).(domain_model.PackSetAnalysis)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain_model.PackSetAnalysisRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChartPacks provides a mock function with given fields: ctx, request, fn
+func (_m *PackMediator) ChartPacks(ctx context.Context, request domain_model.PackChartRequest, fn func(domain_model.PackChartRow) error) error {
+	ret := _m.Called(ctx, request, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChartPacks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.PackChartRequest, func(domain_model.PackChartRow) error) error); ok {
+		r0 = rf(ctx, request, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecommendPackSet provides a mock function with given fields: ctx, request
+func (_m *PackMediator) RecommendPackSet(ctx context.Context, request domain_model.RecommendationRequest) (domain_model.PackSetRecommendation, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecommendPackSet")
+	}
+
+	var r0 domain_model.PackSetRecommendation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.RecommendationRequest) (domain_model.PackSetRecommendation, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.RecommendationRequest) domain_model.PackSetRecommendation); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Get(0).(domain_model.PackSetRecommendation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain_model.RecommendationRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemovePack provides a mock function with given fields: ctx, profile, size
+func (_m *PackMediator) RemovePack(ctx context.Context, profile string, size int) error {
+	ret := _m.Called(ctx, profile, size)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RemovePack")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
-		r0 = rf(ctx, size)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = rf(ctx, profile, size)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -49,6 +153,180 @@ func (_m *PackMediator) RemovePack(ctx context.Context, size int) error {
 	return r0
 }
 
+// RetrievePackProfiles provides a mock function with given fields: ctx
+func (_m *PackMediator) RetrievePackProfiles(ctx context.Context) ([]domain_model.PackProfile, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrievePackProfiles")
+	}
+
+	var r0 []domain_model.PackProfile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain_model.PackProfile, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain_model.PackProfile); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain_model.PackProfile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrievePackSchedule provides a mock function with given fields: ctx, profile
+func (_m *PackMediator) RetrievePackSchedule(ctx context.Context, profile string) ([]domain_model.PackValidity, error) {
+	ret := _m.Called(ctx, profile)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrievePackSchedule")
+	}
+
+	var r0 []domain_model.PackValidity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain_model.PackValidity, error)); ok {
+		return rf(ctx, profile)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain_model.PackValidity); ok {
+		r0 = rf(ctx, profile)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain_model.PackValidity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, profile)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrievePacks provides a mock function with given fields: ctx, profile
+func (_m *PackMediator) RetrievePacks(ctx context.Context, profile string) ([]int, error) {
+	ret := _m.Called(ctx, profile)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrievePacks")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]int, error)); ok {
+		return rf(ctx, profile)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []int); ok {
+		r0 = rf(ctx, profile)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, profile)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SchedulePack provides a mock function with given fields: ctx, validity
+func (_m *PackMediator) SchedulePack(ctx context.Context, validity domain_model.PackValidity) (domain_model.PackValidity, error) {
+	ret := _m.Called(ctx, validity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SchedulePack")
+	}
+
+	var r0 domain_model.PackValidity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.PackValidity) (domain_model.PackValidity, error)); ok {
+		return rf(ctx, validity)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.PackValidity) domain_model.PackValidity); ok {
+		r0 = rf(ctx, validity)
+	} else {
+		r0 = ret.Get(0).(domain_model.PackValidity)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain_model.PackValidity) error); ok {
+		r1 = rf(ctx, validity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SimulatePackSet provides a mock function with given fields: ctx, simulation
+func (_m *PackMediator) SimulatePackSet(ctx context.Context, simulation domain_model.PackSetSimulation) (domain_model.SimulationReport, error) {
+	ret := _m.Called(ctx, simulation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SimulatePackSet")
+	}
+
+	var r0 domain_model.SimulationReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.PackSetSimulation) (domain_model.SimulationReport, error)); ok {
+		return rf(ctx, simulation)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.PackSetSimulation) domain_model.SimulationReport); ok {
+		r0 = rf(ctx, simulation)
+	} else {
+		r0 = ret.Get(0).(domain_model.SimulationReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain_model.PackSetSimulation) error); ok {
+		r1 = rf(ctx, simulation)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePackSpecification provides a mock function with given fields: ctx, specification
+func (_m *PackMediator) UpdatePackSpecification(ctx context.Context, specification domain_model.PackSpecification) (domain_model.PackSpecification, error) {
+	ret := _m.Called(ctx, specification)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePackSpecification")
+	}
+
+	var r0 domain_model.PackSpecification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.PackSpecification) (domain_model.PackSpecification, error)); ok {
+		return rf(ctx, specification)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.PackSpecification) domain_model.PackSpecification); ok {
+		r0 = rf(ctx, specification)
+	} else {
+		r0 = ret.Get(0).(domain_model.PackSpecification)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain_model.PackSpecification) error); ok {
+		r1 = rf(ctx, specification)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewPackMediator creates a new instance of PackMediator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPackMediator(t interface {
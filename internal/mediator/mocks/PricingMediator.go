@@ -0,0 +1,134 @@
+// Code generated by mockery v2.42.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain_model "github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PricingMediator is an autogenerated mock type for the PricingMediator type
+type PricingMediator struct {
+	mock.Mock
+}
+
+// AddDiscountTier provides a mock function with given fields: ctx, tier
+func (_m *PricingMediator) AddDiscountTier(ctx context.Context, tier domain_model.DiscountTier) (domain_model.DiscountTier, error) {
+	ret := _m.Called(ctx, tier)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddDiscountTier")
+	}
+
+	var r0 domain_model.DiscountTier
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.DiscountTier) (domain_model.DiscountTier, error)); ok {
+		return rf(ctx, tier)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.DiscountTier) domain_model.DiscountTier); ok {
+		r0 = rf(ctx, tier)
+	} else {
+		r0 = ret.Get(0).(domain_model.DiscountTier)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain_model.DiscountTier) error); ok {
+		r1 = rf(ctx, tier)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveDiscountTier provides a mock function with given fields: ctx, minQuantity
+func (_m *PricingMediator) RemoveDiscountTier(ctx context.Context, minQuantity int) error {
+	ret := _m.Called(ctx, minQuantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveDiscountTier")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, minQuantity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RetrieveDiscountTiers provides a mock function with given fields: ctx
+func (_m *PricingMediator) RetrieveDiscountTiers(ctx context.Context) ([]domain_model.DiscountTier, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveDiscountTiers")
+	}
+
+	var r0 []domain_model.DiscountTier
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain_model.DiscountTier, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain_model.DiscountTier); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain_model.DiscountTier)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePackPrice provides a mock function with given fields: ctx, packPrice
+func (_m *PricingMediator) UpdatePackPrice(ctx context.Context, packPrice domain_model.PackPrice) (domain_model.PackPrice, error) {
+	ret := _m.Called(ctx, packPrice)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePackPrice")
+	}
+
+	var r0 domain_model.PackPrice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.PackPrice) (domain_model.PackPrice, error)); ok {
+		return rf(ctx, packPrice)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain_model.PackPrice) domain_model.PackPrice); ok {
+		r0 = rf(ctx, packPrice)
+	} else {
+		r0 = ret.Get(0).(domain_model.PackPrice)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain_model.PackPrice) error); ok {
+		r1 = rf(ctx, packPrice)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPricingMediator creates a new instance of PricingMediator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPricingMediator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PricingMediator {
+	mock := &PricingMediator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
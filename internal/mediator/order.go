@@ -2,8 +2,10 @@ package mediator
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"math"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -12,6 +14,12 @@ import (
 	"github.com/google/uuid"
 )
 
+var (
+	ErrOrderNotFound          = errors.New("order not found")
+	ErrInvalidOrderTransition = errors.New("invalid order status transition")
+	ErrOrderNotAmendable      = errors.New("order can no longer be amended")
+)
+
 type OrderMediatorDeps func(mediator *orderMediator)
 
 func WithOrderRepository(repository repository.Querier) OrderMediatorDeps {
@@ -20,17 +28,46 @@ func WithOrderRepository(repository repository.Querier) OrderMediatorDeps {
 	}
 }
 
+// Calculations and amendments write the order packs, shipments, price and status in a single transaction
+func WithOrderTransactor(transactor repository.Transactor) OrderMediatorDeps {
+	return func(mediator *orderMediator) {
+		mediator.orderTransactor = transactor
+	}
+}
+
+// Orders are calculated with the cached grid of their pack set instead of solving every quantity again
+func WithOrderSolverCache(cache *SolverCache) OrderMediatorDeps {
+	return func(mediator *orderMediator) {
+		mediator.solverCache = cache
+	}
+}
+
 type OrderMediator interface {
 	CreateOrder(ctx context.Context, order domain_model.Order) error
 	CalculateOrderPacks(ctx context.Context, orderId uuid.UUID) (domain_model.OrderPacks, error)
+	TransitionOrderStatus(ctx context.Context, orderId uuid.UUID, status domain_model.OrderStatus) (domain_model.Order, error)
+	AmendOrderQuantity(ctx context.Context, orderId uuid.UUID, quantity int) (domain_model.OrderAmendment, error)
+	RecalculateOrders(ctx context.Context, filter domain_model.RecalculationFilter) (domain_model.RecalculationReport, error)
+	ExportOrders(ctx context.Context, fn func(order domain_model.OrderExport) error) error
+	ImportOrder(ctx context.Context, profile string, quantity int) (domain_model.OrderPacks, error)
+	CreateQuote(ctx context.Context, profile string, quantity int) (domain_model.Quote, error)
+	AcceptQuote(ctx context.Context, quoteId uuid.UUID) (domain_model.OrderPacks, error)
+	PurgeExpiredQuotes(ctx context.Context) (int, error)
 }
 
 type orderMediator struct {
 	orderRepository repository.Querier
+	orderTransactor repository.Transactor
+	solverCache     *SolverCache
+
+	shippingConstraints domain_model.ShippingConstraints
+	currency            string
+	quoteTtl            time.Duration
+	defaultProfile      string
 }
 
 func NewOrderMediator(deps ...OrderMediatorDeps) OrderMediator {
-	orderMediator := orderMediator{}
+	orderMediator := orderMediator{defaultProfile: domain_model.DefaultPackProfile}
 	for _, opt := range deps {
 		opt(&orderMediator)
 	}
@@ -43,41 +80,219 @@ func (om orderMediator) CreateOrder(ctx context.Context, order domain_model.Orde
 		return errors.New(fmt.Sprintf("order quantity [%v] must be greater than 0", order.Quantity))
 	}
 
-	// Create order in db
-	params := repository.AddOrderParams{OrderID: order.OrderId, OrderQuantity: int32(order.Quantity)}
+	// Create order in db for the tenant of the request, calculated later with the packs of its profile
+	params := repository.AddOrderParams{
+		OrderID:       order.OrderId,
+		OrderQuantity: int32(order.Quantity),
+		Profile:       resolvePackProfile(order.Profile, om.defaultProfile),
+		TenantID:      domain_model.TenantFromContext(ctx),
+		OrderDate:     sql.NullTime{Time: order.OrderDate, Valid: !order.OrderDate.IsZero()},
+	}
 	if addErr := om.orderRepository.AddOrder(ctx, params); addErr != nil {
+		if isForeignKeyViolation(addErr) {
+			return errors.Wrap(ErrPackProfileNotFound, fmt.Sprintf("could not add order for [%v] items to profile [%v]", params.OrderQuantity, params.Profile))
+		}
 		return errors.Wrap(addErr, fmt.Sprintf("could not add order for [%v] items", params.OrderQuantity))
 	}
 	return nil
 }
 
 func (om orderMediator) CalculateOrderPacks(ctx context.Context, orderId uuid.UUID) (domain_model.OrderPacks, error) {
-	// Retrieve order info
-	order, retrieveOrderErr := om.orderRepository.RetrieveOrderById(ctx, orderId)
+	// Retrieve order info, orders of other tenants are never found
+	tenant := domain_model.TenantFromContext(ctx)
+	order, retrieveOrderErr := om.orderRepository.RetrieveOrderById(ctx, repository.RetrieveOrderByIdParams{OrderID: orderId, TenantID: tenant})
 	if retrieveOrderErr != nil {
 		return domain_model.OrderPacks{}, errors.Wrap(retrieveOrderErr, fmt.Sprintf("could not retrieve order [%v]", orderId))
 	}
 
-	// Retrieve packs info
-	packs, retrievePacksErr := om.orderRepository.RetrievePacks(ctx)
+	// Only new orders can be calculated
+	if !domain_model.OrderStatus(order.Status).CanTransitionTo(domain_model.OrderStatusCalculated) {
+		return domain_model.OrderPacks{}, errors.Wrap(ErrInvalidOrderTransition, fmt.Sprintf("could not calculate order [%v] in status [%v]", orderId, order.Status))
+	}
+
+	// Retrieve packs info of the order profile, leaving out the packs too heavy to ship
+	packs, retrievePacksErr := om.retrieveShippablePacks(ctx, om.orderRepository, order.Profile, order.OrderDate)
 	if retrievePacksErr != nil {
 		return domain_model.OrderPacks{}, errors.Wrap(retrievePacksErr, "could not retrieve available packs")
 	}
 
+	// Retrieve the containers the packs are shipped in
+	containerTypes, retrieveContainerTypesErr := om.orderRepository.RetrieveContainerTypes(ctx, tenant)
+	if retrieveContainerTypesErr != nil {
+		return domain_model.OrderPacks{}, errors.Wrap(retrieveContainerTypesErr, "could not retrieve container types")
+	}
+
 	// Translate to domain models
 	orderPacks := translateToDomainModel(order, packs)
 
-	// Make pack calculations
-	orderPacksResult := calculateOrderPacks(orderPacks)
+	// Make pack calculations, nest the packs into their containers and split them into shipments
+	orderPacksResult := om.calculateOrderPacks(orderPacks)
+	orderPacksResult.Packaging = planOrderPackaging(orderPacksResult.OptimalOrderPack, containerTypes)
+	orderPacksResult.Shipments = splitIntoShipments(orderPacksResult.OptimalOrderPack, om.shippingConstraints)
 
-	// Save OrderPacks in db
-	if saveOrderPackersErr := om.saveEachOrderPack(ctx, orderPacksResult); saveOrderPackersErr != nil {
-		return domain_model.OrderPacks{}, errors.Wrap(retrieveOrderErr, fmt.Sprintf("could not save order packs for order [%v]", orderId))
+	// Claim the order first and save its packs in the same transaction, so a concurrent calculation of the same order
+	// fails to mark it as calculated and writes nothing
+	txErr := om.orderTransactor.WithinTransaction(ctx, func(querier repository.Querier) error {
+		if _, transitionErr := om.updateOrderStatus(ctx, querier, order, domain_model.OrderStatusCalculated); transitionErr != nil {
+			return transitionErr
+		}
+
+		// Price the packs with the current prices, which the order keeps from now on
+		orderPrice, priceErr := om.priceOrder(ctx, querier, order.Profile, orderPacksResult.OrderQuantity, orderPacksResult.OptimalOrderPack)
+		if priceErr != nil {
+			return errors.Wrap(priceErr, "could not price order")
+		}
+		orderPacksResult.Price = orderPrice
+
+		// Save OrderPacks in db, along with their shipments and the pack set they were calculated with
+		if saveOrderPackersErr := saveEachOrderPack(ctx, querier, orderPacksResult); saveOrderPackersErr != nil {
+			return errors.Wrap(saveOrderPackersErr, "could not save order packs")
+		}
+		if saveShipmentsErr := saveEachOrderShipment(ctx, querier, orderId, orderPacksResult.Shipments); saveShipmentsErr != nil {
+			return saveShipmentsErr
+		}
+		if savePriceErr := saveOrderPrice(ctx, querier, orderId, orderPacksResult.Price); savePriceErr != nil {
+			return savePriceErr
+		}
+		packSetParams := repository.UpdateOrderPackSetParams{OrderID: orderId, PackSet: packs, TenantID: tenant}
+		if packSetErr := querier.UpdateOrderPackSet(ctx, packSetParams); packSetErr != nil {
+			return errors.Wrap(packSetErr, "could not save pack set")
+		}
+		return nil
+	})
+	if txErr != nil {
+		return domain_model.OrderPacks{}, errors.Wrap(txErr, fmt.Sprintf("could not calculate order [%v]", orderId))
 	}
 
 	return orderPacksResult, nil
 }
 
+// Move the order to the given status, if allowed from its current one
+func (om orderMediator) TransitionOrderStatus(ctx context.Context, orderId uuid.UUID, status domain_model.OrderStatus) (domain_model.Order, error) {
+	params := repository.RetrieveOrderByIdParams{OrderID: orderId, TenantID: domain_model.TenantFromContext(ctx)}
+	order, retrieveOrderErr := om.orderRepository.RetrieveOrderById(ctx, params)
+	if errors.Is(retrieveOrderErr, sql.ErrNoRows) {
+		return domain_model.Order{}, errors.Wrap(ErrOrderNotFound, fmt.Sprintf("could not retrieve order [%v]", orderId))
+	}
+	if retrieveOrderErr != nil {
+		return domain_model.Order{}, errors.Wrap(retrieveOrderErr, fmt.Sprintf("could not retrieve order [%v]", orderId))
+	}
+
+	if !domain_model.OrderStatus(order.Status).CanTransitionTo(status) {
+		return domain_model.Order{}, errors.Wrap(ErrInvalidOrderTransition, fmt.Sprintf("order [%v] cannot move from [%v] to [%v]", orderId, order.Status, status))
+	}
+
+	return om.updateOrderStatus(ctx, om.orderRepository, order, status)
+}
+
+// Change the quantity of an order not picked yet, replacing its packs by the ones calculated with the order's pack set
+func (om orderMediator) AmendOrderQuantity(ctx context.Context, orderId uuid.UUID, quantity int) (domain_model.OrderAmendment, error) {
+	// Validate order quantity is a natural number
+	if quantity <= 0 {
+		return domain_model.OrderAmendment{}, errors.New(fmt.Sprintf("order quantity [%v] must be greater than 0", quantity))
+	}
+
+	var amendment domain_model.OrderAmendment
+	tenant := domain_model.TenantFromContext(ctx)
+	txErr := om.orderTransactor.WithinTransaction(ctx, func(querier repository.Querier) error {
+		order, retrieveOrderErr := querier.RetrieveOrderById(ctx, repository.RetrieveOrderByIdParams{OrderID: orderId, TenantID: tenant})
+		if errors.Is(retrieveOrderErr, sql.ErrNoRows) {
+			return ErrOrderNotFound
+		}
+		if retrieveOrderErr != nil {
+			return errors.Wrap(retrieveOrderErr, "could not retrieve order")
+		}
+		if !domain_model.OrderStatus(order.Status).IsAmendable() {
+			return errors.Wrap(ErrOrderNotAmendable, fmt.Sprintf("order is [%v]", order.Status))
+		}
+
+		orderPacksParams := repository.RetrieveOrderPacksByOrderParams{OrderID: orderId, TenantID: tenant}
+		previousOrderPacks, retrieveOrderPacksErr := querier.RetrieveOrderPacksByOrder(ctx, orderPacksParams)
+		if retrieveOrderPacksErr != nil {
+			return errors.Wrap(retrieveOrderPacksErr, "could not retrieve order packs")
+		}
+
+		// Orders never calculated have no pack set yet, they take the one of their profile shippable at their date
+		packSet := order.PackSet
+		if len(packSet) == 0 {
+			packs, retrievePacksErr := om.retrieveShippablePacks(ctx, querier, order.Profile, order.OrderDate)
+			if retrievePacksErr != nil {
+				return errors.Wrap(retrievePacksErr, "could not retrieve available packs")
+			}
+			packSet = packs
+			packSetParams := repository.UpdateOrderPackSetParams{OrderID: orderId, PackSet: packSet, TenantID: tenant}
+			if packSetErr := querier.UpdateOrderPackSet(ctx, packSetParams); packSetErr != nil {
+				return errors.Wrap(packSetErr, "could not save pack set")
+			}
+		}
+
+		// Recalculate and replace the order packs, their shipments and their price, taken with the current prices
+		order.OrderQuantity = int32(quantity)
+		orderPacksResult := om.calculateOrderPacks(translateToDomainModel(order, packSet))
+		shipments := splitIntoShipments(orderPacksResult.OptimalOrderPack, om.shippingConstraints)
+		orderPrice, priceErr := om.priceOrder(ctx, querier, order.Profile, quantity, orderPacksResult.OptimalOrderPack)
+		if priceErr != nil {
+			return priceErr
+		}
+		removeParams := repository.RemoveOrderPacksByOrderParams{OrderID: orderId, TenantID: tenant}
+		if removeErr := querier.RemoveOrderPacksByOrder(ctx, removeParams); removeErr != nil {
+			return errors.Wrap(removeErr, "could not remove order packs")
+		}
+		if saveErr := saveEachOrderPack(ctx, querier, orderPacksResult); saveErr != nil {
+			return saveErr
+		}
+		if shipmentsErr := replaceOrderShipments(ctx, querier, orderId, shipments); shipmentsErr != nil {
+			return shipmentsErr
+		}
+		if priceErr := replaceOrderPrice(ctx, querier, orderId, orderPrice); priceErr != nil {
+			return priceErr
+		}
+
+		// The status is checked again, so an order picked meanwhile is not amended
+		quantityParams := repository.UpdateOrderQuantityParams{OrderID: orderId, OrderQuantity: int32(quantity), Status: order.Status, TenantID: tenant}
+		updatedOrder, updateErr := querier.UpdateOrderQuantity(ctx, quantityParams)
+		if errors.Is(updateErr, sql.ErrNoRows) {
+			return errors.Wrap(ErrOrderNotAmendable, fmt.Sprintf("order is no longer [%v]", order.Status))
+		}
+		if updateErr != nil {
+			return errors.Wrap(updateErr, "could not update order quantity")
+		}
+
+		previousPacks := make(domain_model.OrderPack)
+		for _, orderPack := range previousOrderPacks {
+			previousPacks[int(orderPack.PackSize)] += int(orderPack.PackQuantity)
+		}
+		added, removed := orderPacksResult.OptimalOrderPack.Diff(previousPacks)
+		amendment = domain_model.OrderAmendment{
+			Order:   translateOrderToDomainModel(updatedOrder),
+			Packs:   orderPacksResult.OptimalOrderPack,
+			Added:   added,
+			Removed: removed,
+
+			Shipments: shipments,
+			Price:     orderPrice,
+		}
+		return nil
+	})
+	if txErr != nil {
+		return domain_model.OrderAmendment{}, errors.Wrap(txErr, fmt.Sprintf("could not amend order [%v] to [%v] items", orderId, quantity))
+	}
+	return amendment, nil
+}
+
+// Update the status only if it did not change since the order was read, so concurrent transitions cannot both apply
+func (om orderMediator) updateOrderStatus(ctx context.Context, querier repository.Querier, order repository.Order, status domain_model.OrderStatus) (domain_model.Order, error) {
+	params := repository.UpdateOrderStatusParams{ToStatus: string(status), OrderID: order.OrderID, FromStatus: order.Status, TenantID: domain_model.TenantFromContext(ctx)}
+	updatedOrder, updateErr := querier.UpdateOrderStatus(ctx, params)
+	if errors.Is(updateErr, sql.ErrNoRows) {
+		return domain_model.Order{}, errors.Wrap(ErrInvalidOrderTransition, fmt.Sprintf("order [%v] is no longer in status [%v]", order.OrderID, order.Status))
+	}
+	if updateErr != nil {
+		return domain_model.Order{}, errors.Wrap(updateErr, fmt.Sprintf("could not move order [%v] to [%v]", order.OrderID, status))
+	}
+	return translateOrderToDomainModel(updatedOrder), nil
+}
+
 // Translate from repository models to domain models
 func translateToDomainModel(order repository.Order, packs []int32) domain_model.OrderPacks {
 	orderPacks := domain_model.OrderPacks{
@@ -95,56 +310,104 @@ func translateToDomainModel(order repository.Order, packs []int32) domain_model.
 	return orderPacks
 }
 
+// Translate from repository models to domain models
+func translateOrderToDomainModel(order repository.Order) domain_model.Order {
+	return domain_model.Order{
+		OrderId:      order.OrderID,
+		Quantity:     int(order.OrderQuantity),
+		Status:       domain_model.OrderStatus(order.Status),
+		CreatedAt:    order.CreatedAt,
+		UpdatedAt:    order.UpdatedAt,
+		CalculatedAt: nullTimeToPointer(order.CalculatedAt),
+		PickedAt:     nullTimeToPointer(order.PickedAt),
+		PackedAt:     nullTimeToPointer(order.PackedAt),
+		ShippedAt:    nullTimeToPointer(order.ShippedAt),
+		CancelledAt:  nullTimeToPointer(order.CancelledAt),
+		PackSet:      packSetToDomainModel(order.PackSet),
+		Profile:      order.Profile,
+		OrderDate:    order.OrderDate,
+	}
+}
+
+func packSetToDomainModel(packSet []int32) []int {
+	sizes := make([]int, 0, len(packSet))
+	for _, packSize := range packSet {
+		sizes = append(sizes, int(packSize))
+	}
+	return sizes
+}
+
+func nullTimeToPointer(nullTime sql.NullTime) *time.Time {
+	if !nullTime.Valid {
+		return nil
+	}
+	return &nullTime.Time
+}
+
+// Calculate the order packs with the solver cache, when configured
+func (om orderMediator) calculateOrderPacks(orderPacks domain_model.OrderPacks) domain_model.OrderPacks {
+	if om.solverCache == nil {
+		return calculateOrderPacks(orderPacks)
+	}
+	return om.solverCache.CalculateOrderPacks(orderPacks)
+}
+
 // calculate the optimal way to package the order quantity, based on the packs configured.
 func calculateOrderPacks(orderPacks domain_model.OrderPacks) domain_model.OrderPacks {
 	// Loop through all quantities until we reach the desired
 	for gridIndex := 1; gridIndex <= orderPacks.OrderQuantity; gridIndex++ {
-		orderPacks.ResetItemsAndPackageQuantities() // Set the package quantities to the maximum amount.
-
-		for _, pack := range orderPacks.AvailablePacks {
-			currentPackArrangement := make(domain_model.OrderPack)
-
-			// If iteration is less than package size, default to 1 pack
-			// If iteration is greater than package size, use previous answers and add one more pack
-			if gridIndex <= pack {
-				for _, packSize := range orderPacks.AvailablePacks {
-					currentPackArrangement[packSize] = 0
-				}
-				currentPackArrangement[pack] = 1
-			} else {
-				for packSize, packValue := range orderPacks.ResultGrid[gridIndex-pack] {
-					currentPackArrangement[packSize] = packValue
-				}
-				currentPackArrangement[pack]++
-			}
+		solveQuantity(&orderPacks, gridIndex)
+	}
+
+	return orderPacks
+}
 
-			// Calculate total number of items and packs to enforce business rules.
-			totalItemsPackaged, totalPackages := currentPackArrangement.TotalItemsAndPackages()
-			if totalItemsPackaged < orderPacks.BestItemQuantity {
-				orderPacks.UseAsOptimalSolution(currentPackArrangement)
-			} else if totalItemsPackaged == orderPacks.BestItemQuantity && totalPackages < orderPacks.BestPackQuantity {
-				orderPacks.UseAsOptimalSolution(currentPackArrangement)
+// Solve one quantity of the grid, all the smaller quantities down to the largest pack size below it must be solved
+func solveQuantity(orderPacks *domain_model.OrderPacks, gridIndex int) {
+	orderPacks.ResetItemsAndPackageQuantities() // Set the package quantities to the maximum amount.
+
+	for _, pack := range orderPacks.AvailablePacks {
+		currentPackArrangement := make(domain_model.OrderPack)
+
+		// If iteration is less than package size, default to 1 pack
+		// If iteration is greater than package size, use previous answers and add one more pack
+		if gridIndex <= pack {
+			for _, packSize := range orderPacks.AvailablePacks {
+				currentPackArrangement[packSize] = 0
+			}
+			currentPackArrangement[pack] = 1
+		} else {
+			for packSize, packValue := range orderPacks.ResultGrid[gridIndex-pack] {
+				currentPackArrangement[packSize] = packValue
 			}
+			currentPackArrangement[pack]++
 		}
 
-		// Save optimal packaging for this specific iteration in the result grid
-		orderPacks.ResultGrid[gridIndex] = orderPacks.OptimalOrderPack
+		// Calculate total number of items and packs to enforce business rules.
+		totalItemsPackaged, totalPackages := currentPackArrangement.TotalItemsAndPackages()
+		if totalItemsPackaged < orderPacks.BestItemQuantity {
+			orderPacks.UseAsOptimalSolution(currentPackArrangement)
+		} else if totalItemsPackaged == orderPacks.BestItemQuantity && totalPackages < orderPacks.BestPackQuantity {
+			orderPacks.UseAsOptimalSolution(currentPackArrangement)
+		}
 	}
 
-	return orderPacks
+	// Save optimal packaging for this specific iteration in the result grid
+	orderPacks.ResultGrid[gridIndex] = orderPacks.OptimalOrderPack
 }
 
 // Save each of the order packs in the database
-func (om orderMediator) saveEachOrderPack(ctx context.Context, orderPacks domain_model.OrderPacks) error {
+func saveEachOrderPack(ctx context.Context, querier repository.Querier, orderPacks domain_model.OrderPacks) error {
 	for orderPackSize, orderPackQuantity := range orderPacks.OptimalOrderPack {
 		addOrderPackParams := repository.AddOrderPackParams{
 			OrderPacksID: uuid.New(),
 			OrderID:      orderPacks.OrderId,
 			PackSize:     int32(orderPackSize),
 			PackQuantity: int32(orderPackQuantity),
+			TenantID:     domain_model.TenantFromContext(ctx),
 		}
 
-		if addOrderPackErr := om.orderRepository.AddOrderPack(ctx, addOrderPackParams); addOrderPackErr != nil {
+		if addOrderPackErr := querier.AddOrderPack(ctx, addOrderPackParams); addOrderPackErr != nil {
 			return errors.Wrap(addOrderPackErr, fmt.Sprintf("could not save amount of packs of size [%v] for order [%v]", addOrderPackParams.PackSize, orderPacks.OrderId))
 		}
 	}
@@ -2,6 +2,7 @@ package mediator_test
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"testing"
 
@@ -28,6 +29,8 @@ func Test_CreateOrder_OK(t *testing.T) {
 	orderRepositoryParams := repository.AddOrderParams{
 		OrderID:       order.OrderId,
 		OrderQuantity: int32(order.Quantity),
+		Profile:       "default",
+		TenantID:      "default",
 	}
 	repositoryMock.On("AddOrder", mock.Anything, orderRepositoryParams).Return(nil)
 
@@ -85,86 +88,99 @@ func Test_CreateOrder_InvalidOrderQuantity(t *testing.T) {
 }
 
 func Test_CalculateOrderPacks_OK(t *testing.T) {
-	// Set Up
+	// Set Up, every use case is calculated from scratch and with the grids cached by the previous ones
 	repositoryMock := repository_mocks.NewQuerier(t)
-	orderMediator := mediator.NewOrderMediator(mediator.WithOrderRepository(repositoryMock))
+	orderMediators := map[string]mediator.OrderMediator{
+		"Uncached": mediator.NewOrderMediator(mediator.WithOrderRepository(repositoryMock), mediator.WithOrderTransactor(newTransactorMock(t, repositoryMock))),
+		"Cached":   mediator.NewOrderMediator(mediator.WithOrderRepository(repositoryMock), mediator.WithOrderTransactor(newTransactorMock(t, repositoryMock)), mediator.WithOrderSolverCache(mediator.NewSolverCache(1<<20))),
+	}
 	useCases := []struct {
 		order          repository.Order
 		availablePacks []int32
 		optimalResult  domain_model.OrderPack
 	}{
 		{
-			order:          repository.Order{OrderID: uuid.New(), OrderQuantity: 8},
+			order:          repository.Order{OrderID: uuid.New(), OrderQuantity: 8, Profile: "default"},
 			availablePacks: []int32{2, 5},
 			optimalResult:  domain_model.OrderPack{2: 4, 5: 0},
 		},
 		{
-			order:          repository.Order{OrderID: uuid.New(), OrderQuantity: 5},
+			order:          repository.Order{OrderID: uuid.New(), OrderQuantity: 5, Profile: "default"},
 			availablePacks: []int32{2, 5},
 			optimalResult:  domain_model.OrderPack{2: 0, 5: 1},
 		},
 		{
-			order:          repository.Order{OrderID: uuid.New(), OrderQuantity: 12},
+			order:          repository.Order{OrderID: uuid.New(), OrderQuantity: 12, Profile: "default"},
 			availablePacks: []int32{2, 5},
 			optimalResult:  domain_model.OrderPack{2: 1, 5: 2},
 		},
 		{
-			order:          repository.Order{OrderID: uuid.New(), OrderQuantity: 19},
+			order:          repository.Order{OrderID: uuid.New(), OrderQuantity: 19, Profile: "default"},
 			availablePacks: []int32{2, 5},
 			optimalResult:  domain_model.OrderPack{2: 2, 5: 3},
 		},
 		{
-			order:          repository.Order{OrderID: uuid.New(), OrderQuantity: 21},
+			order:          repository.Order{OrderID: uuid.New(), OrderQuantity: 21, Profile: "default"},
 			availablePacks: []int32{2, 5},
 			optimalResult:  domain_model.OrderPack{2: 3, 5: 3},
 		},
 		{
-			order:          repository.Order{OrderID: uuid.New(), OrderQuantity: 8},
+			order:          repository.Order{OrderID: uuid.New(), OrderQuantity: 8, Profile: "default"},
 			availablePacks: []int32{15, 33, 50},
 			optimalResult:  domain_model.OrderPack{15: 1, 33: 0, 50: 0},
 		},
 		{
-			order:          repository.Order{OrderID: uuid.New(), OrderQuantity: 100},
+			order:          repository.Order{OrderID: uuid.New(), OrderQuantity: 100, Profile: "default"},
 			availablePacks: []int32{15, 33, 50},
 			optimalResult:  domain_model.OrderPack{15: 0, 33: 0, 50: 2},
 		},
 		{
-			order:          repository.Order{OrderID: uuid.New(), OrderQuantity: 111},
+			order:          repository.Order{OrderID: uuid.New(), OrderQuantity: 111, Profile: "default"},
 			availablePacks: []int32{15, 33, 50},
 			optimalResult:  domain_model.OrderPack{15: 3, 33: 2, 50: 0},
 		},
 		{
-			order:          repository.Order{OrderID: uuid.New(), OrderQuantity: 82},
+			order:          repository.Order{OrderID: uuid.New(), OrderQuantity: 82, Profile: "default"},
 			availablePacks: []int32{15, 33, 50},
 			optimalResult:  domain_model.OrderPack{15: 0, 33: 1, 50: 1},
 		},
 		{
-			order:          repository.Order{OrderID: uuid.New(), OrderQuantity: 333},
+			order:          repository.Order{OrderID: uuid.New(), OrderQuantity: 333, Profile: "default"},
 			availablePacks: []int32{15, 33, 50},
 			optimalResult:  domain_model.OrderPack{15: 0, 33: 1, 50: 6},
 		},
 	}
 
-	for _, useCase := range useCases {
-		t.Run(fmt.Sprintf("Packages: [%+v], Quantity: [%+v]", useCase.availablePacks, useCase.order.OrderQuantity), func(t *testing.T) {
-			// Arrange
-			repositoryMock.
-				On("RetrieveOrderById", mock.Anything, useCase.order.OrderID).
-				Return(repository.Order{OrderID: useCase.order.OrderID, OrderQuantity: useCase.order.OrderQuantity}, nil)
-			repositoryMock.On("RetrievePacks", mock.Anything).Return(useCase.availablePacks, nil)
-			repositoryMock.On("AddOrderPack", mock.Anything, mock.Anything).Return(nil)
+	for name, orderMediator := range orderMediators {
+		for _, useCase := range useCases {
+			t.Run(fmt.Sprintf("%v, Packages: [%+v], Quantity: [%+v]", name, useCase.availablePacks, useCase.order.OrderQuantity), func(t *testing.T) {
+				// Arrange
+				repositoryMock.
+					On("RetrieveOrderById", mock.Anything, repository.RetrieveOrderByIdParams{OrderID: useCase.order.OrderID, TenantID: "default"}).
+					Return(repository.Order{OrderID: useCase.order.OrderID, OrderQuantity: useCase.order.OrderQuantity, Status: "created", Profile: "default"}, nil)
+				repositoryMock.On("RetrievePacks", mock.Anything, repository.RetrievePacksParams{Profile: "default", TenantID: "default"}).Return(useCase.availablePacks, nil)
+				repositoryMock.On("RetrieveContainerTypes", mock.Anything, "default").Return(nil, nil)
+				repositoryMock.On("RetrievePackPrices", mock.Anything, repository.RetrievePackPricesParams{Profile: "default", TenantID: "default"}).Return(nil, nil)
+				repositoryMock.On("AddOrderPack", mock.Anything, mock.Anything).Return(nil)
+				repositoryMock.
+					On("UpdateOrderPackSet", mock.Anything, repository.UpdateOrderPackSetParams{OrderID: useCase.order.OrderID, PackSet: useCase.availablePacks, TenantID: "default"}).
+					Return(nil)
+				repositoryMock.
+					On("UpdateOrderStatus", mock.Anything, repository.UpdateOrderStatusParams{ToStatus: "calculated", OrderID: useCase.order.OrderID, FromStatus: "created", TenantID: "default"}).
+					Return(repository.Order{OrderID: useCase.order.OrderID, OrderQuantity: useCase.order.OrderQuantity, Status: "calculated", Profile: "default"}, nil)
 
-			// Act
-			orderPacks, calculationErr := orderMediator.CalculateOrderPacks(context.Background(), useCase.order.OrderID)
+				// Act
+				orderPacks, calculationErr := orderMediator.CalculateOrderPacks(context.Background(), useCase.order.OrderID)
 
-			// Assert
-			repositoryMock.AssertExpectations(t)
-			require.NoError(t, calculationErr)
-			require.Equal(t, useCase.optimalResult, orderPacks.OptimalOrderPack)
+				// Assert
+				repositoryMock.AssertExpectations(t)
+				require.NoError(t, calculationErr)
+				require.Equal(t, useCase.optimalResult, orderPacks.OptimalOrderPack)
 
-			// Clean up
-			repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
-		})
+				// Clean up
+				repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+			})
+		}
 	}
 }
 
@@ -181,6 +197,8 @@ func Test_CreateOrder_Errors(t *testing.T) {
 	orderRepositoryParams := repository.AddOrderParams{
 		OrderID:       order.OrderId,
 		OrderQuantity: int32(order.Quantity),
+		Profile:       "default",
+		TenantID:      "default",
 	}
 	repositoryMock.On("AddOrder", mock.Anything, orderRepositoryParams).Return(nil)
 
@@ -194,3 +212,202 @@ func Test_CreateOrder_Errors(t *testing.T) {
 	// Clean up
 	repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
 }
+
+func Test_TransitionOrderStatus(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	orderMediator := mediator.NewOrderMediator(mediator.WithOrderRepository(repositoryMock))
+	orderId := uuid.New()
+
+	t.Run("Allowed transition", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrieveOrderById", mock.Anything, repository.RetrieveOrderByIdParams{OrderID: orderId, TenantID: "default"}).Return(repository.Order{OrderID: orderId, Status: "calculated"}, nil)
+		repositoryMock.
+			On("UpdateOrderStatus", mock.Anything, repository.UpdateOrderStatusParams{ToStatus: "picked", OrderID: orderId, FromStatus: "calculated", TenantID: "default"}).
+			Return(repository.Order{OrderID: orderId, Status: "picked", PickedAt: sql.NullTime{Valid: true}}, nil)
+
+		// Act
+		order, transitionErr := orderMediator.TransitionOrderStatus(context.Background(), orderId, domain_model.OrderStatusPicked)
+
+		// Assert
+		repositoryMock.AssertExpectations(t)
+		require.NoError(t, transitionErr)
+		require.Equal(t, domain_model.OrderStatusPicked, order.Status)
+		require.NotNil(t, order.PickedAt)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Invalid transitions", func(t *testing.T) {
+		invalidTransitions := map[domain_model.OrderStatus]domain_model.OrderStatus{
+			domain_model.OrderStatusCreated:   domain_model.OrderStatusShipped,
+			domain_model.OrderStatusPicked:    domain_model.OrderStatusCalculated,
+			domain_model.OrderStatusShipped:   domain_model.OrderStatusCancelled,
+			domain_model.OrderStatusCancelled: domain_model.OrderStatusPicked,
+		}
+		for from, to := range invalidTransitions {
+			// Arrange
+			repositoryMock.On("RetrieveOrderById", mock.Anything, repository.RetrieveOrderByIdParams{OrderID: orderId, TenantID: "default"}).Return(repository.Order{OrderID: orderId, Status: string(from)}, nil)
+
+			// Act
+			_, transitionErr := orderMediator.TransitionOrderStatus(context.Background(), orderId, to)
+
+			// Assert
+			require.ErrorIs(t, transitionErr, mediator.ErrInvalidOrderTransition, "%v to %v", from, to)
+
+			// Clean up
+			repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+		}
+	})
+
+	t.Run("Status changed concurrently", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrieveOrderById", mock.Anything, repository.RetrieveOrderByIdParams{OrderID: orderId, TenantID: "default"}).Return(repository.Order{OrderID: orderId, Status: "packed"}, nil)
+		repositoryMock.On("UpdateOrderStatus", mock.Anything, mock.Anything).Return(repository.Order{}, sql.ErrNoRows)
+
+		// Act
+		_, transitionErr := orderMediator.TransitionOrderStatus(context.Background(), orderId, domain_model.OrderStatusShipped)
+
+		// Assert
+		require.ErrorIs(t, transitionErr, mediator.ErrInvalidOrderTransition)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Order not found", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrieveOrderById", mock.Anything, repository.RetrieveOrderByIdParams{OrderID: orderId, TenantID: "default"}).Return(repository.Order{}, sql.ErrNoRows)
+
+		// Act
+		_, transitionErr := orderMediator.TransitionOrderStatus(context.Background(), orderId, domain_model.OrderStatusCancelled)
+
+		// Assert
+		require.ErrorIs(t, transitionErr, mediator.ErrOrderNotFound)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+}
+
+func Test_CalculateOrderPacks_AlreadyCalculated(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	orderMediator := mediator.NewOrderMediator(mediator.WithOrderRepository(repositoryMock))
+	orderId := uuid.New()
+
+	// Arrange
+	repositoryMock.On("RetrieveOrderById", mock.Anything, repository.RetrieveOrderByIdParams{OrderID: orderId, TenantID: "default"}).Return(repository.Order{OrderID: orderId, OrderQuantity: 10, Status: "calculated", Profile: "default"}, nil)
+
+	// Act
+	_, calculationErr := orderMediator.CalculateOrderPacks(context.Background(), orderId)
+
+	// Assert
+	repositoryMock.AssertExpectations(t)
+	require.ErrorIs(t, calculationErr, mediator.ErrInvalidOrderTransition)
+}
+
+func Test_CalculateOrderPacks_CalculatedConcurrently(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	orderMediator := mediator.NewOrderMediator(
+		mediator.WithOrderRepository(repositoryMock),
+		mediator.WithOrderTransactor(newTransactorMock(t, repositoryMock)),
+	)
+	orderId := uuid.New()
+
+	// Arrange, the order is claimed by another calculation before its packs are saved
+	repositoryMock.On("RetrieveOrderById", mock.Anything, repository.RetrieveOrderByIdParams{OrderID: orderId, TenantID: "default"}).Return(repository.Order{OrderID: orderId, OrderQuantity: 10, Status: "created", Profile: "default"}, nil)
+	repositoryMock.On("RetrievePacks", mock.Anything, repository.RetrievePacksParams{Profile: "default", TenantID: "default"}).Return([]int32{5}, nil)
+	repositoryMock.On("RetrieveContainerTypes", mock.Anything, "default").Return(nil, nil)
+	repositoryMock.On("UpdateOrderStatus", mock.Anything, mock.Anything).Return(repository.Order{}, sql.ErrNoRows).Once()
+
+	// Act
+	_, calculationErr := orderMediator.CalculateOrderPacks(context.Background(), orderId)
+
+	// Assert
+	repositoryMock.AssertExpectations(t)
+	repositoryMock.AssertNotCalled(t, "AddOrderPack", mock.Anything, mock.Anything)
+	require.ErrorIs(t, calculationErr, mediator.ErrInvalidOrderTransition)
+}
+
+func Test_AmendOrderQuantity(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	orderMediator := mediator.NewOrderMediator(
+		mediator.WithOrderRepository(repositoryMock),
+		mediator.WithOrderTransactor(newTransactorMock(t, repositoryMock)),
+	)
+	orderId := uuid.New()
+
+	t.Run("Packs recalculated with the order pack set", func(t *testing.T) {
+		// Arrange
+		repositoryMock.
+			On("RetrieveOrderById", mock.Anything, repository.RetrieveOrderByIdParams{OrderID: orderId, TenantID: "default"}).
+			Return(repository.Order{OrderID: orderId, OrderQuantity: 12, Status: "calculated", PackSet: []int32{2, 5}, Profile: "default"}, nil)
+		repositoryMock.
+			On("RetrieveOrderPacksByOrder", mock.Anything, repository.RetrieveOrderPacksByOrderParams{OrderID: orderId, TenantID: "default"}).
+			Return([]repository.RetrieveOrderPacksByOrderRow{{PackSize: 2, PackQuantity: 1}, {PackSize: 5, PackQuantity: 2}}, nil)
+		repositoryMock.On("RemoveOrderPacksByOrder", mock.Anything, repository.RemoveOrderPacksByOrderParams{OrderID: orderId, TenantID: "default"}).Return(nil)
+		repositoryMock.On("RemoveOrderShipmentsByOrder", mock.Anything, repository.RemoveOrderShipmentsByOrderParams{OrderID: orderId, TenantID: "default"}).Return(nil)
+		repositoryMock.On("RetrievePackPrices", mock.Anything, repository.RetrievePackPricesParams{Profile: "default", TenantID: "default"}).Return(nil, nil)
+		repositoryMock.On("RemoveOrderPriceByOrder", mock.Anything, repository.RemoveOrderPriceByOrderParams{OrderID: orderId, TenantID: "default"}).Return(nil)
+		repositoryMock.On("AddOrderPack", mock.Anything, mock.Anything).Return(nil)
+		repositoryMock.
+			On("UpdateOrderQuantity", mock.Anything, repository.UpdateOrderQuantityParams{OrderID: orderId, OrderQuantity: 8, Status: "calculated", TenantID: "default"}).
+			Return(repository.Order{OrderID: orderId, OrderQuantity: 8, Status: "calculated", PackSet: []int32{2, 5}, Profile: "default"}, nil)
+
+		// Act
+		amendment, amendErr := orderMediator.AmendOrderQuantity(context.Background(), orderId, 8)
+
+		// Assert
+		repositoryMock.AssertExpectations(t)
+		require.NoError(t, amendErr)
+		require.Equal(t, 8, amendment.Order.Quantity)
+		require.Equal(t, domain_model.OrderPack{2: 4, 5: 0}, amendment.Packs)
+		require.Equal(t, domain_model.OrderPack{2: 3}, amendment.Added)
+		require.Equal(t, domain_model.OrderPack{5: 2}, amendment.Removed)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Order already picked", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrieveOrderById", mock.Anything, repository.RetrieveOrderByIdParams{OrderID: orderId, TenantID: "default"}).Return(repository.Order{OrderID: orderId, Status: "picked"}, nil)
+
+		// Act
+		_, amendErr := orderMediator.AmendOrderQuantity(context.Background(), orderId, 8)
+
+		// Assert
+		repositoryMock.AssertExpectations(t)
+		require.ErrorIs(t, amendErr, mediator.ErrOrderNotAmendable)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Order not found", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrieveOrderById", mock.Anything, repository.RetrieveOrderByIdParams{OrderID: orderId, TenantID: "default"}).Return(repository.Order{}, sql.ErrNoRows)
+
+		// Act
+		_, amendErr := orderMediator.AmendOrderQuantity(context.Background(), orderId, 8)
+
+		// Assert
+		require.ErrorIs(t, amendErr, mediator.ErrOrderNotFound)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Invalid quantity", func(t *testing.T) {
+		// Act
+		_, amendErr := orderMediator.AmendOrderQuantity(context.Background(), orderId, 0)
+
+		// Assert
+		repositoryMock.AssertExpectations(t)
+		require.Error(t, amendErr)
+	})
+}
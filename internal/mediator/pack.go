@@ -4,9 +4,20 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
 	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+	"github.com/lib/pq"
+)
+
+// Postgres error code raised when a unique constraint is violated
+const uniqueViolationCode = "23505"
+
+var (
+	ErrPackAlreadyExists = errors.New("pack already exists")
+	ErrPackNotFound      = errors.New("pack not found")
 )
 
 type PackMediatorDeps func(mediator *packMediator)
@@ -17,40 +28,149 @@ func WithPackRepository(repository repository.Querier) PackMediatorDeps {
 	}
 }
 
+// Changes to the pack set and their audit entries are written in the same transaction
+func WithPackTransactor(transactor repository.Transactor) PackMediatorDeps {
+	return func(mediator *packMediator) {
+		mediator.packTransactor = transactor
+	}
+}
+
+// Cached grids are dropped once the pack set changes
+func WithPackSolverCache(cache *SolverCache) PackMediatorDeps {
+	return func(mediator *packMediator) {
+		mediator.solverCache = cache
+	}
+}
+
 type PackMediator interface {
-	AddPack(ctx context.Context, size int) error
-	RemovePack(ctx context.Context, size int) error
+	AddPack(ctx context.Context, profile string, size int) error
+	RemovePack(ctx context.Context, profile string, size int) error
+	RetrievePacks(ctx context.Context, profile string) ([]int, error)
+	AddPackProfile(ctx context.Context, name string) (domain_model.PackProfile, error)
+	RetrievePackProfiles(ctx context.Context) ([]domain_model.PackProfile, error)
+	SchedulePack(ctx context.Context, validity domain_model.PackValidity) (domain_model.PackValidity, error)
+	RetrievePackSchedule(ctx context.Context, profile string) ([]domain_model.PackValidity, error)
+	UpdatePackSpecification(ctx context.Context, specification domain_model.PackSpecification) (domain_model.PackSpecification, error)
+	SimulatePackSet(ctx context.Context, simulation domain_model.PackSetSimulation) (domain_model.SimulationReport, error)
+	RecommendPackSet(ctx context.Context, request domain_model.RecommendationRequest) (domain_model.PackSetRecommendation, error)
+	AnalyzePackSet(ctx context.Context, request domain_model.PackSetAnalysisRequest) (domain_model.PackSetAnalysis, error)
+	ChartPacks(ctx context.Context, request domain_model.PackChartRequest, fn func(row domain_model.PackChartRow) error) error
 }
 
 type packMediator struct {
 	packRepository repository.Querier
+	packTransactor repository.Transactor
+	solverCache    *SolverCache
+	defaultProfile string
 }
 
 func NewPackMediator(deps ...PackMediatorDeps) PackMediator {
-	packMediator := packMediator{}
+	packMediator := packMediator{defaultProfile: domain_model.DefaultPackProfile}
 	for _, opt := range deps {
 		opt(&packMediator)
 	}
 	return packMediator
 }
 
-func (pm packMediator) AddPack(ctx context.Context, size int) error {
+func (pm packMediator) AddPack(ctx context.Context, profile string, size int) error {
 	// Validate pack is a natural number
 	if size <= 0 {
 		return errors.New(fmt.Sprintf("pack size [%v] must be bigger than 0", size))
 	}
+	profile = resolvePackProfile(profile, pm.defaultProfile)
+	tenant := domain_model.TenantFromContext(ctx)
 
-	// Add pack in db
-	if addErr := pm.packRepository.AddPack(ctx, int32(size)); addErr != nil {
-		return errors.Wrap(addErr, fmt.Sprintf("could not add pack of size [%v]", size))
+	// Add pack and its audit entry in db
+	txErr := pm.packTransactor.WithinTransaction(ctx, func(querier repository.Querier) error {
+		if addErr := querier.AddPack(ctx, repository.AddPackParams{Profile: profile, PackSize: int32(size), TenantID: tenant}); addErr != nil {
+			return addErr
+		}
+		if auditErr := addPackAudit(ctx, querier, domain_model.PackAuditActionAdd, profile, size); auditErr != nil {
+			return auditErr
+		}
+		return notifyPackSetChanged(ctx, querier)
+	})
+	if txErr != nil {
+		if isUniqueViolation(txErr) {
+			return errors.Wrap(ErrPackAlreadyExists, fmt.Sprintf("could not add pack of size [%v] to profile [%v]", size, profile))
+		}
+		if isForeignKeyViolation(txErr) {
+			return errors.Wrap(ErrPackProfileNotFound, fmt.Sprintf("could not add pack of size [%v] to profile [%v]", size, profile))
+		}
+		return errors.Wrap(txErr, fmt.Sprintf("could not add pack of size [%v] to profile [%v]", size, profile))
 	}
+	pm.invalidateSolverCache()
 	return nil
 }
 
-func (pm packMediator) RemovePack(ctx context.Context, size int) error {
-	// Remove pack from db
-	if removeErr := pm.packRepository.RemovePackBySize(ctx, int32(size)); removeErr != nil {
-		return errors.Wrap(removeErr, fmt.Sprintf("could not remove pack of size [%v]", size))
+func (pm packMediator) RemovePack(ctx context.Context, profile string, size int) error {
+	profile = resolvePackProfile(profile, pm.defaultProfile)
+	params := repository.RemovePackBySizeParams{Profile: profile, PackSize: int32(size), TenantID: domain_model.TenantFromContext(ctx)}
+
+	// Remove pack from db and audit it, only when the pack existed
+	txErr := pm.packTransactor.WithinTransaction(ctx, func(querier repository.Querier) error {
+		removed, removeErr := querier.RemovePackBySize(ctx, params)
+		if removeErr != nil {
+			return removeErr
+		}
+		if removed == 0 {
+			return ErrPackNotFound
+		}
+		if auditErr := addPackAudit(ctx, querier, domain_model.PackAuditActionRemove, profile, size); auditErr != nil {
+			return auditErr
+		}
+		return notifyPackSetChanged(ctx, querier)
+	})
+	if txErr != nil {
+		return errors.Wrap(txErr, fmt.Sprintf("could not remove pack of size [%v] from profile [%v]", size, profile))
 	}
+	pm.invalidateSolverCache()
 	return nil
 }
+
+// Retrieve the pack sizes of the profile, biggest first
+func (pm packMediator) RetrievePacks(ctx context.Context, profile string) ([]int, error) {
+	profile = resolvePackProfile(profile, pm.defaultProfile)
+	packs, retrieveErr := pm.packRepository.RetrievePacks(ctx, repository.RetrievePacksParams{Profile: profile, TenantID: domain_model.TenantFromContext(ctx)})
+	if retrieveErr != nil {
+		return nil, errors.Wrap(retrieveErr, fmt.Sprintf("could not retrieve packs of profile [%v]", profile))
+	}
+	return packSetToDomainModel(packs), nil
+}
+
+// Record who changed the pack set, and in which request and tenant, from the request context
+func addPackAudit(ctx context.Context, querier repository.Querier, action domain_model.PackAuditAction, profile string, size int) error {
+	params := repository.AddPackAuditParams{
+		AuditID:   uuid.New(),
+		Actor:     domain_model.ActorFromContext(ctx),
+		Action:    string(action),
+		PackSize:  int32(size),
+		RequestID: domain_model.RequestIdFromContext(ctx),
+		Profile:   profile,
+		TenantID:  domain_model.TenantFromContext(ctx),
+	}
+	if auditErr := querier.AddPackAudit(ctx, params); auditErr != nil {
+		return errors.Wrap(auditErr, fmt.Sprintf("could not audit [%v] of pack of size [%v]", action, size))
+	}
+	return nil
+}
+
+// Let every instance know the pack set changed, the notification is only delivered if the transaction commits
+func notifyPackSetChanged(ctx context.Context, querier repository.Querier) error {
+	if notifyErr := querier.NotifyPackSetChanged(ctx); notifyErr != nil {
+		return errors.Wrap(notifyErr, "could not notify pack set change")
+	}
+	return nil
+}
+
+// Drop the grids cached by this instance, the others drop theirs when notified
+func (pm packMediator) invalidateSolverCache() {
+	if pm.solverCache != nil {
+		pm.solverCache.Invalidate()
+	}
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode
+}
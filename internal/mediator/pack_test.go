@@ -6,23 +6,41 @@ import (
 	"testing"
 
 	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
 	repository_mocks "github.com/felipevillarrealdaza/go-service-template/internal/repository/mocks"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
+// Transactor mock running the function against the repository mock, returning its error as the transaction would
+func newTransactorMock(t *testing.T, repositoryMock *repository_mocks.Querier) *repository_mocks.Transactor {
+	transactorMock := repository_mocks.NewTransactor(t)
+	transactorMock.On("WithinTransaction", mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, fn func(repository.Querier) error) error { return fn(repositoryMock) },
+	).Maybe()
+	return transactorMock
+}
+
 func Test_AddPack_OK(t *testing.T) {
 	// Set Up
 	repositoryMock := repository_mocks.NewQuerier(t)
-	packMediator := mediator.NewPackMediator(mediator.WithPackRepository(repositoryMock))
+	packMediator := mediator.NewPackMediator(mediator.WithPackTransactor(newTransactorMock(t, repositoryMock)))
+	principal := domain_model.Principal{Subject: "ops", Role: domain_model.RoleAdmin, Method: domain_model.AuthMethodApiKey}
+	ctx := domain_model.ContextWithRequestId(domain_model.ContextWithPrincipal(context.Background(), principal), "request-1")
 
 	// Arrange
-	repositoryMock.On("AddPack", mock.Anything, int32(10)).Return(nil)
+	repositoryMock.On("AddPack", mock.Anything, repository.AddPackParams{Profile: "default", PackSize: 10, TenantID: "default"}).Return(nil)
+	repositoryMock.On("AddPackAudit", mock.Anything, mock.MatchedBy(func(params repository.AddPackAuditParams) bool {
+		return params.Actor == "api_key:ops" && params.Action == "add" && params.PackSize == 10 && params.RequestID == "request-1"
+	})).Return(nil)
+	repositoryMock.On("NotifyPackSetChanged", mock.Anything).Return(nil)
 
 	// Act
-	addPackErr := packMediator.AddPack(context.Background(), 10)
+	addPackErr := packMediator.AddPack(ctx, "", 10)
 
 	// Assert
 	repositoryMock.AssertExpectations(t)
@@ -35,11 +53,11 @@ func Test_AddPack_OK(t *testing.T) {
 func Test_AddPack_Errors(t *testing.T) {
 	// Set Up
 	repositoryMock := repository_mocks.NewQuerier(t)
-	packMediator := mediator.NewPackMediator(mediator.WithPackRepository(repositoryMock))
+	packMediator := mediator.NewPackMediator(mediator.WithPackTransactor(newTransactorMock(t, repositoryMock)))
 
 	t.Run("Pack of size zero", func(t *testing.T) {
 		// Act
-		creationErr := packMediator.AddPack(context.Background(), 0)
+		creationErr := packMediator.AddPack(context.Background(), "", 0)
 
 		// Assert
 		repositoryMock.AssertExpectations(t)
@@ -51,7 +69,7 @@ func Test_AddPack_Errors(t *testing.T) {
 
 	t.Run("Pack of negative size", func(t *testing.T) {
 		// Act
-		creationErr := packMediator.AddPack(context.Background(), -5)
+		creationErr := packMediator.AddPack(context.Background(), "", -5)
 
 		// Assert
 		repositoryMock.AssertExpectations(t)
@@ -61,12 +79,60 @@ func Test_AddPack_Errors(t *testing.T) {
 		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
 	})
 
+	t.Run("Pack already exists", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("AddPack", mock.Anything, repository.AddPackParams{Profile: "default", PackSize: 10, TenantID: "default"}).Return(&pq.Error{Code: "23505"})
+
+		// Act
+		creationErr := packMediator.AddPack(context.Background(), "", 10)
+
+		// Assert
+		repositoryMock.AssertExpectations(t)
+		require.ErrorIs(t, creationErr, mediator.ErrPackAlreadyExists)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
 	t.Run("Error saving the pack", func(t *testing.T) {
 		// Arrange
-		repositoryMock.On("AddPack", mock.Anything, int32(10)).Return(errors.New(fmt.Sprintf("could not add pack of size [%v]", 10)))
+		repositoryMock.On("AddPack", mock.Anything, repository.AddPackParams{Profile: "default", PackSize: 10, TenantID: "default"}).Return(errors.New(fmt.Sprintf("could not add pack of size [%v]", 10)))
+
+		// Act
+		creationErr := packMediator.AddPack(context.Background(), "", 10)
+
+		// Assert
+		repositoryMock.AssertExpectations(t)
+		require.Error(t, creationErr)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Error auditing the pack", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("AddPack", mock.Anything, repository.AddPackParams{Profile: "default", PackSize: 10, TenantID: "default"}).Return(nil)
+		repositoryMock.On("AddPackAudit", mock.Anything, mock.Anything).Return(errors.New("could not add pack audit"))
 
 		// Act
-		creationErr := packMediator.AddPack(context.Background(), 10)
+		creationErr := packMediator.AddPack(context.Background(), "", 10)
+
+		// Assert
+		repositoryMock.AssertExpectations(t)
+		require.Error(t, creationErr)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Error notifying the pack set change", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("AddPack", mock.Anything, repository.AddPackParams{Profile: "default", PackSize: 10, TenantID: "default"}).Return(nil)
+		repositoryMock.On("AddPackAudit", mock.Anything, mock.Anything).Return(nil)
+		repositoryMock.On("NotifyPackSetChanged", mock.Anything).Return(errors.New("could not notify"))
+
+		// Act
+		creationErr := packMediator.AddPack(context.Background(), "", 10)
 
 		// Assert
 		repositoryMock.AssertExpectations(t)
@@ -77,16 +143,44 @@ func Test_AddPack_Errors(t *testing.T) {
 	})
 }
 
+func Test_AddPack_InvalidatesSolverCache(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	solverCache := mediator.NewSolverCache(1 << 20)
+	packMediator := mediator.NewPackMediator(mediator.WithPackTransactor(newTransactorMock(t, repositoryMock)), mediator.WithPackSolverCache(solverCache))
+
+	// Arrange
+	solverCache.CalculateOrderPacks(newOrderPacks(100, 250, 500))
+	repositoryMock.On("AddPack", mock.Anything, repository.AddPackParams{Profile: "default", PackSize: 10, TenantID: "default"}).Return(nil)
+	repositoryMock.On("AddPackAudit", mock.Anything, mock.Anything).Return(nil)
+	repositoryMock.On("NotifyPackSetChanged", mock.Anything).Return(nil)
+
+	// Act
+	addPackErr := packMediator.AddPack(context.Background(), "", 10)
+
+	// Assert
+	repositoryMock.AssertExpectations(t)
+	require.NoError(t, addPackErr)
+	require.Equal(t, mediator.SolverCacheUsage{}, solverCache.Usage())
+
+	// Clean up
+	repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+}
+
 func Test_RemovePack_OK(t *testing.T) {
 	// Set Up
 	repositoryMock := repository_mocks.NewQuerier(t)
-	packMediator := mediator.NewPackMediator(mediator.WithPackRepository(repositoryMock))
+	packMediator := mediator.NewPackMediator(mediator.WithPackTransactor(newTransactorMock(t, repositoryMock)))
 
 	// Arrange
-	repositoryMock.On("RemovePackBySize", mock.Anything, int32(10)).Return(nil)
+	repositoryMock.On("RemovePackBySize", mock.Anything, repository.RemovePackBySizeParams{Profile: "default", PackSize: 10, TenantID: "default"}).Return(int64(1), nil)
+	repositoryMock.On("AddPackAudit", mock.Anything, mock.MatchedBy(func(params repository.AddPackAuditParams) bool {
+		return params.Actor == domain_model.AnonymousActor && params.Action == "remove" && params.PackSize == 10
+	})).Return(nil)
+	repositoryMock.On("NotifyPackSetChanged", mock.Anything).Return(nil)
 
 	// Act
-	removePackErr := packMediator.RemovePack(context.Background(), 10)
+	removePackErr := packMediator.RemovePack(context.Background(), "", 10)
 
 	// Assert
 	repositoryMock.AssertExpectations(t)
@@ -99,14 +193,14 @@ func Test_RemovePack_OK(t *testing.T) {
 func Test_RemovePack_Errors(t *testing.T) {
 	// Set Up
 	repositoryMock := repository_mocks.NewQuerier(t)
-	packMediator := mediator.NewPackMediator(mediator.WithPackRepository(repositoryMock))
+	packMediator := mediator.NewPackMediator(mediator.WithPackTransactor(newTransactorMock(t, repositoryMock)))
 
 	t.Run("Error removing the pack", func(t *testing.T) {
 		// Arrange
-		repositoryMock.On("RemovePackBySize", mock.Anything, int32(10)).Return(errors.New(fmt.Sprintf("could not remove pack of size [%v]", 10)))
+		repositoryMock.On("RemovePackBySize", mock.Anything, repository.RemovePackBySizeParams{Profile: "default", PackSize: 10, TenantID: "default"}).Return(int64(0), errors.New(fmt.Sprintf("could not remove pack of size [%v]", 10)))
 
 		// Act
-		creationErr := packMediator.RemovePack(context.Background(), 10)
+		creationErr := packMediator.RemovePack(context.Background(), "", 10)
 
 		// Assert
 		repositoryMock.AssertExpectations(t)
@@ -115,4 +209,19 @@ func Test_RemovePack_Errors(t *testing.T) {
 		// Clean up
 		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
 	})
+
+	t.Run("Pack not found", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RemovePackBySize", mock.Anything, repository.RemovePackBySizeParams{Profile: "default", PackSize: 10, TenantID: "default"}).Return(int64(0), nil)
+
+		// Act
+		removeErr := packMediator.RemovePack(context.Background(), "", 10)
+
+		// Assert
+		repositoryMock.AssertExpectations(t)
+		require.ErrorIs(t, removeErr, mediator.ErrPackNotFound)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
 }
@@ -0,0 +1,215 @@
+package mediator
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+)
+
+const (
+	// Postgres error code raised when a foreign key is violated
+	foreignKeyViolationCode = "23503"
+	// Constraint violated when the container type name is taken
+	containerTypePrimaryKey = "container_type_pkey"
+)
+
+var (
+	ErrContainerTypeAlreadyExists = errors.New("container type already exists")
+	ErrContainerTypeNotFound      = errors.New("container type not found")
+	ErrContainerContentsNotFound  = errors.New("container contents not found")
+	ErrContainerContentsTaken     = errors.New("container contents already held by another container type")
+)
+
+type ContainerMediatorDeps func(mediator *containerMediator)
+
+func WithContainerRepository(repository repository.Querier) ContainerMediatorDeps {
+	return func(mediator *containerMediator) {
+		mediator.containerRepository = repository
+	}
+}
+
+type ContainerMediator interface {
+	AddContainerType(ctx context.Context, containerType domain_model.ContainerType) (domain_model.ContainerType, error)
+	RemoveContainerType(ctx context.Context, name string) error
+	RetrieveContainerTypes(ctx context.Context) ([]domain_model.ContainerType, error)
+}
+
+type containerMediator struct {
+	containerRepository repository.Querier
+}
+
+func NewContainerMediator(deps ...ContainerMediatorDeps) ContainerMediator {
+	containerMediator := containerMediator{}
+	for _, opt := range deps {
+		opt(&containerMediator)
+	}
+	return containerMediator
+}
+
+// Add a container type holding an existing pack size or container type, which no other container type holds
+func (cm containerMediator) AddContainerType(ctx context.Context, containerType domain_model.ContainerType) (domain_model.ContainerType, error) {
+	if (containerType.PackSize > 0) == (containerType.Container != "") {
+		return domain_model.ContainerType{}, errors.New(fmt.Sprintf("container type [%v] must hold either packs or containers", containerType.Name))
+	}
+	params := repository.AddContainerTypeParams{
+		Name:      containerType.Name,
+		PackSize:  sql.NullInt32{Int32: int32(containerType.PackSize), Valid: containerType.PackSize > 0},
+		ChildName: sql.NullString{String: containerType.Container, Valid: containerType.Container != ""},
+		TenantID:  domain_model.TenantFromContext(ctx),
+	}
+	for _, capacity := range containerType.Capacities {
+		if capacity <= 0 {
+			return domain_model.ContainerType{}, errors.New(fmt.Sprintf("capacity [%v] of container type [%v] must be greater than 0", capacity, containerType.Name))
+		}
+		params.Capacities = append(params.Capacities, int32(capacity))
+	}
+
+	added, addErr := cm.containerRepository.AddContainerType(ctx, params)
+	if addErr != nil {
+		return domain_model.ContainerType{}, errors.Wrap(translateContainerTypeError(addErr), fmt.Sprintf("could not add container type [%v]", containerType.Name))
+	}
+	return translateContainerTypeToDomainModel(added), nil
+}
+
+// Remove the container type, along with the container types holding it
+func (cm containerMediator) RemoveContainerType(ctx context.Context, name string) error {
+	params := repository.RemoveContainerTypeByNameParams{Name: name, TenantID: domain_model.TenantFromContext(ctx)}
+	removed, removeErr := cm.containerRepository.RemoveContainerTypeByName(ctx, params)
+	if removeErr != nil {
+		return errors.Wrap(removeErr, fmt.Sprintf("could not remove container type [%v]", name))
+	}
+	if removed == 0 {
+		return errors.Wrap(ErrContainerTypeNotFound, fmt.Sprintf("could not remove container type [%v]", name))
+	}
+	return nil
+}
+
+func (cm containerMediator) RetrieveContainerTypes(ctx context.Context) ([]domain_model.ContainerType, error) {
+	containerTypes, retrieveErr := cm.containerRepository.RetrieveContainerTypes(ctx, domain_model.TenantFromContext(ctx))
+	if retrieveErr != nil {
+		return nil, errors.Wrap(retrieveErr, "could not retrieve container types")
+	}
+	result := make([]domain_model.ContainerType, 0, len(containerTypes))
+	for _, containerType := range containerTypes {
+		result = append(result, translateContainerTypeToDomainModel(containerType))
+	}
+	return result, nil
+}
+
+func translateContainerTypeError(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+	switch {
+	case pqErr.Code == uniqueViolationCode && pqErr.Constraint == containerTypePrimaryKey:
+		return ErrContainerTypeAlreadyExists
+	case pqErr.Code == uniqueViolationCode:
+		return ErrContainerContentsTaken
+	case pqErr.Code == foreignKeyViolationCode:
+		return ErrContainerContentsNotFound
+	default:
+		return err
+	}
+}
+
+// Translate from repository models to domain models, capacities biggest first
+func translateContainerTypeToDomainModel(containerType repository.ContainerType) domain_model.ContainerType {
+	capacities := packSetToDomainModel(containerType.Capacities)
+	sort.Sort(sort.Reverse(sort.IntSlice(capacities)))
+	return domain_model.ContainerType{
+		Name:       containerType.Name,
+		PackSize:   int(containerType.PackSize.Int32),
+		Container:  containerType.ChildName.String,
+		Capacities: capacities,
+		CreatedAt:  containerType.CreatedAt,
+	}
+}
+
+// Plan the packaging of the order packs, only when container types are configured
+func planOrderPackaging(packs domain_model.OrderPack, containerTypes []repository.ContainerType) []domain_model.PackagingUnit {
+	if len(containerTypes) == 0 {
+		return nil
+	}
+	domainContainerTypes := make([]domain_model.ContainerType, 0, len(containerTypes))
+	for _, containerType := range containerTypes {
+		domainContainerTypes = append(domainContainerTypes, translateContainerTypeToDomainModel(containerType))
+	}
+	return planPackaging(packs, domainContainerTypes)
+}
+
+// Nest the packs into the chain of container types of their size, biggest packs first and outermost containers
+// first. Each level is filled with full containers, biggest capacity first, and what does not fill a container is
+// left at its level, e.g. 65 packs into cartons of 5 and pallets of 4 give 3 pallets of 4 cartons of 5 packs and
+// 1 carton of 5 packs.
+func planPackaging(packs domain_model.OrderPack, containerTypes []domain_model.ContainerType) []domain_model.PackagingUnit {
+	packContainers := make(map[int]domain_model.ContainerType)
+	parentContainers := make(map[string]domain_model.ContainerType)
+	for _, containerType := range containerTypes {
+		if containerType.Container != "" {
+			parentContainers[containerType.Container] = containerType
+		} else {
+			packContainers[containerType.PackSize] = containerType
+		}
+	}
+
+	var units []domain_model.PackagingUnit
+	for _, packSize := range packSizesBiggestFirst(packs) {
+		candidates := []domain_model.PackagingUnit{{PackSize: packSize, Quantity: packs[packSize]}}
+		var remainders []domain_model.PackagingUnit
+
+		// Climb the chain, the containers filled at each level are the candidates of the next one. Chains cannot
+		// loop, as container types can only hold existing ones, but they are bounded all the same.
+		containerType, contained := packContainers[packSize]
+		for level := 0; contained && level < len(containerTypes); level++ {
+			var filled, levelRemainders []domain_model.PackagingUnit
+			for _, candidate := range candidates {
+				containers, remainder := fillContainers(containerType, candidate)
+				filled = append(filled, containers...)
+				if remainder.Quantity > 0 {
+					levelRemainders = append(levelRemainders, remainder)
+				}
+			}
+			candidates = filled
+			remainders = append(levelRemainders, remainders...)
+			containerType, contained = parentContainers[containerType.Name]
+		}
+
+		units = append(units, candidates...)
+		units = append(units, remainders...)
+	}
+	return units
+}
+
+// Pack sizes with a quantity, biggest first
+func packSizesBiggestFirst(packs domain_model.OrderPack) []int {
+	packSizes := make([]int, 0, len(packs))
+	for packSize, packQuantity := range packs {
+		if packQuantity > 0 {
+			packSizes = append(packSizes, packSize)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(packSizes)))
+	return packSizes
+}
+
+// Fill as many containers as possible with the units, biggest capacity first, returning the units left over
+func fillContainers(containerType domain_model.ContainerType, units domain_model.PackagingUnit) ([]domain_model.PackagingUnit, domain_model.PackagingUnit) {
+	var containers []domain_model.PackagingUnit
+	for _, capacity := range containerType.Capacities {
+		if count := units.Quantity / capacity; count > 0 {
+			contents := units
+			contents.Quantity = capacity
+			containers = append(containers, domain_model.PackagingUnit{Container: containerType.Name, Quantity: count, Contents: &contents})
+			units.Quantity -= count * capacity
+		}
+	}
+	return containers, units
+}
@@ -0,0 +1,219 @@
+package mediator_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+	repository_mocks "github.com/felipevillarrealdaza/go-service-template/internal/repository/mocks"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AddContainerType(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	containerMediator := mediator.NewContainerMediator(mediator.WithContainerRepository(repositoryMock))
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("Container of packs", func(t *testing.T) {
+		// Arrange
+		params := repository.AddContainerTypeParams{Name: "carton", PackSize: sql.NullInt32{Int32: 1000, Valid: true}, Capacities: []int32{5, 10}, TenantID: "default"}
+		repositoryMock.On("AddContainerType", mock.Anything, params).
+			Return(repository.ContainerType{Name: "carton", PackSize: params.PackSize, Capacities: []int32{5, 10}, CreatedAt: createdAt}, nil).Once()
+
+		// Act
+		added, addErr := containerMediator.AddContainerType(context.Background(), domain_model.ContainerType{Name: "carton", PackSize: 1000, Capacities: []int{5, 10}})
+
+		// Assert
+		require.NoError(t, addErr)
+		require.Equal(t, domain_model.ContainerType{Name: "carton", PackSize: 1000, Capacities: []int{10, 5}, CreatedAt: createdAt}, added)
+	})
+
+	t.Run("Container of containers", func(t *testing.T) {
+		// Arrange
+		params := repository.AddContainerTypeParams{Name: "pallet", ChildName: sql.NullString{String: "carton", Valid: true}, Capacities: []int32{4}, TenantID: "default"}
+		repositoryMock.On("AddContainerType", mock.Anything, params).
+			Return(repository.ContainerType{Name: "pallet", ChildName: params.ChildName, Capacities: []int32{4}, CreatedAt: createdAt}, nil).Once()
+
+		// Act
+		added, addErr := containerMediator.AddContainerType(context.Background(), domain_model.ContainerType{Name: "pallet", Container: "carton", Capacities: []int{4}})
+
+		// Assert
+		require.NoError(t, addErr)
+		require.Equal(t, "carton", added.Container)
+		require.Zero(t, added.PackSize)
+	})
+
+	t.Run("Container of packs and containers", func(t *testing.T) {
+		// Act
+		_, addErr := containerMediator.AddContainerType(context.Background(), domain_model.ContainerType{Name: "box", PackSize: 250, Container: "carton", Capacities: []int{4}})
+
+		// Assert
+		require.ErrorContains(t, addErr, "must hold either packs or containers")
+	})
+
+	t.Run("Capacity of zero", func(t *testing.T) {
+		// Act
+		_, addErr := containerMediator.AddContainerType(context.Background(), domain_model.ContainerType{Name: "box", PackSize: 250, Capacities: []int{4, 0}})
+
+		// Assert
+		require.ErrorContains(t, addErr, "must be greater than 0")
+	})
+
+	useCases := []struct {
+		name        string
+		repoErr     error
+		expectedErr error
+	}{
+		{name: "Name taken", repoErr: &pq.Error{Code: "23505", Constraint: "container_type_pkey"}, expectedErr: mediator.ErrContainerTypeAlreadyExists},
+		{name: "Contents already held", repoErr: &pq.Error{Code: "23505", Constraint: "container_type_pack_size_key"}, expectedErr: mediator.ErrContainerContentsTaken},
+		{name: "Contents not found", repoErr: &pq.Error{Code: "23503"}, expectedErr: mediator.ErrContainerContentsNotFound},
+	}
+	for _, useCase := range useCases {
+		t.Run(useCase.name, func(t *testing.T) {
+			// Arrange
+			repositoryMock.On("AddContainerType", mock.Anything, mock.Anything).Return(repository.ContainerType{}, useCase.repoErr).Once()
+
+			// Act
+			_, addErr := containerMediator.AddContainerType(context.Background(), domain_model.ContainerType{Name: "carton", PackSize: 1000, Capacities: []int{5}})
+
+			// Assert
+			require.ErrorIs(t, addErr, useCase.expectedErr)
+		})
+	}
+}
+
+func Test_RemoveContainerType(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	containerMediator := mediator.NewContainerMediator(mediator.WithContainerRepository(repositoryMock))
+
+	t.Run("Removed", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RemoveContainerTypeByName", mock.Anything, repository.RemoveContainerTypeByNameParams{Name: "carton", TenantID: "default"}).Return(int64(1), nil).Once()
+
+		// Act
+		removeErr := containerMediator.RemoveContainerType(context.Background(), "carton")
+
+		// Assert
+		require.NoError(t, removeErr)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RemoveContainerTypeByName", mock.Anything, repository.RemoveContainerTypeByNameParams{Name: "crate", TenantID: "default"}).Return(int64(0), nil).Once()
+
+		// Act
+		removeErr := containerMediator.RemoveContainerType(context.Background(), "crate")
+
+		// Assert
+		require.ErrorIs(t, removeErr, mediator.ErrContainerTypeNotFound)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RemoveContainerTypeByName", mock.Anything, repository.RemoveContainerTypeByNameParams{Name: "carton", TenantID: "default"}).Return(int64(0), errors.New("connection refused")).Once()
+
+		// Act
+		removeErr := containerMediator.RemoveContainerType(context.Background(), "carton")
+
+		// Assert
+		require.ErrorContains(t, removeErr, "connection refused")
+	})
+}
+
+func Test_CalculateOrderPacks_Packaging(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	orderMediator := mediator.NewOrderMediator(mediator.WithOrderRepository(repositoryMock), mediator.WithOrderTransactor(newTransactorMock(t, repositoryMock)))
+	carton := repository.ContainerType{Name: "carton", PackSize: sql.NullInt32{Int32: 1000, Valid: true}, Capacities: []int32{5}}
+	pallet := repository.ContainerType{Name: "pallet", ChildName: sql.NullString{String: "carton", Valid: true}, Capacities: []int32{4}}
+	cartonOfFive := domain_model.PackagingUnit{Container: "carton", Quantity: 5, Contents: &domain_model.PackagingUnit{PackSize: 1000, Quantity: 5}}
+	useCases := []struct {
+		name              string
+		quantity          int32
+		containerTypes    []repository.ContainerType
+		expectedPackaging []domain_model.PackagingUnit
+	}{
+		{
+			name:           "Full pallets and a carton",
+			quantity:       65000,
+			containerTypes: []repository.ContainerType{carton, pallet},
+			expectedPackaging: []domain_model.PackagingUnit{
+				{Container: "pallet", Quantity: 3, Contents: &domain_model.PackagingUnit{Container: "carton", Quantity: 4, Contents: cartonOfFive.Contents}},
+				{Container: "carton", Quantity: 1, Contents: cartonOfFive.Contents},
+			},
+		},
+		{
+			name:           "Loose packs of every size",
+			quantity:       67250,
+			containerTypes: []repository.ContainerType{carton, pallet},
+			expectedPackaging: []domain_model.PackagingUnit{
+				{Container: "pallet", Quantity: 3, Contents: &domain_model.PackagingUnit{Container: "carton", Quantity: 4, Contents: cartonOfFive.Contents}},
+				{Container: "carton", Quantity: 1, Contents: cartonOfFive.Contents},
+				{PackSize: 1000, Quantity: 2},
+				{PackSize: 250, Quantity: 1},
+			},
+		},
+		{
+			name:     "Cartons of several capacities",
+			quantity: 67000,
+			containerTypes: []repository.ContainerType{
+				{Name: "carton", PackSize: sql.NullInt32{Int32: 1000, Valid: true}, Capacities: []int32{5, 10}},
+				pallet,
+			},
+			expectedPackaging: []domain_model.PackagingUnit{
+				{Container: "pallet", Quantity: 1, Contents: &domain_model.PackagingUnit{Container: "carton", Quantity: 4, Contents: &domain_model.PackagingUnit{PackSize: 1000, Quantity: 10}}},
+				{Container: "carton", Quantity: 2, Contents: &domain_model.PackagingUnit{PackSize: 1000, Quantity: 10}},
+				{Container: "carton", Quantity: 1, Contents: cartonOfFive.Contents},
+				{PackSize: 1000, Quantity: 2},
+			},
+		},
+		{
+			name:              "No container types",
+			quantity:          65000,
+			expectedPackaging: nil,
+		},
+	}
+
+	for _, useCase := range useCases {
+		t.Run(useCase.name, func(t *testing.T) {
+			// Arrange
+			orderId := uuid.New()
+			repositoryMock.On("RetrieveOrderById", mock.Anything, repository.RetrieveOrderByIdParams{OrderID: orderId, TenantID: "default"}).Return(repository.Order{OrderID: orderId, OrderQuantity: useCase.quantity, Status: "created", Profile: "default"}, nil)
+			repositoryMock.On("RetrievePacks", mock.Anything, repository.RetrievePacksParams{Profile: "default", TenantID: "default"}).Return([]int32{1000, 250}, nil)
+			repositoryMock.On("RetrieveContainerTypes", mock.Anything, "default").Return(useCase.containerTypes, nil)
+			repositoryMock.On("RetrievePackPrices", mock.Anything, repository.RetrievePackPricesParams{Profile: "default", TenantID: "default"}).Return(nil, nil)
+			repositoryMock.On("AddOrderPack", mock.Anything, mock.Anything).Return(nil)
+			repositoryMock.On("UpdateOrderPackSet", mock.Anything, mock.Anything).Return(nil)
+			repositoryMock.On("UpdateOrderStatus", mock.Anything, mock.Anything).Return(repository.Order{OrderID: orderId, Status: "calculated"}, nil)
+
+			// Act
+			orderPacks, calculationErr := orderMediator.CalculateOrderPacks(context.Background(), orderId)
+
+			// Assert
+			require.NoError(t, calculationErr)
+			require.Equal(t, useCase.expectedPackaging, orderPacks.Packaging)
+			totalPacks := 0
+			for _, unit := range orderPacks.Packaging {
+				totalPacks += unit.TotalPacks()
+			}
+			if useCase.expectedPackaging != nil {
+				_, expectedPacks := orderPacks.OptimalOrderPack.TotalItemsAndPackages()
+				require.Equal(t, expectedPacks, totalPacks)
+			}
+
+			// Clean up
+			repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package mediator
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+)
+
+var (
+	ErrDiscountTierAlreadyExists = errors.New("discount tier already exists")
+	ErrDiscountTierNotFound      = errors.New("discount tier not found")
+	ErrOrderPriceTooHigh         = errors.New("order price too high to be saved")
+)
+
+var oneHundred = decimal.NewFromInt(100)
+
+// Highest pack price, as prices are stored as numeric(12,2)
+var MaxPackPrice = decimal.RequireFromString("9999999999.99")
+
+// Highest line total and subtotal of an order price, as they are stored as numeric(14,2)
+var maxOrderAmount = decimal.RequireFromString("999999999999.99")
+
+type PricingMediatorDeps func(mediator *pricingMediator)
+
+func WithPricingRepository(repository repository.Querier) PricingMediatorDeps {
+	return func(mediator *pricingMediator) {
+		mediator.pricingRepository = repository
+	}
+}
+
+// Pack prices set without a currency are set in the given one
+func WithPricingCurrency(currency string) PricingMediatorDeps {
+	return func(mediator *pricingMediator) {
+		mediator.currency = currency
+	}
+}
+
+// Orders are priced in the given currency when their pack prices carry none
+func WithOrderCurrency(currency string) OrderMediatorDeps {
+	return func(mediator *orderMediator) {
+		mediator.currency = currency
+	}
+}
+
+type PricingMediator interface {
+	UpdatePackPrice(ctx context.Context, packPrice domain_model.PackPrice) (domain_model.PackPrice, error)
+	AddDiscountTier(ctx context.Context, tier domain_model.DiscountTier) (domain_model.DiscountTier, error)
+	RemoveDiscountTier(ctx context.Context, minQuantity int) error
+	RetrieveDiscountTiers(ctx context.Context) ([]domain_model.DiscountTier, error)
+}
+
+type pricingMediator struct {
+	pricingRepository repository.Querier
+	defaultProfile    string
+	currency          string
+}
+
+func NewPricingMediator(deps ...PricingMediatorDeps) PricingMediator {
+	pricingMediator := pricingMediator{defaultProfile: domain_model.DefaultPackProfile}
+	for _, opt := range deps {
+		opt(&pricingMediator)
+	}
+	return pricingMediator
+}
+
+// Set the price of an existing pack size of the profile, used by the orders calculated from now on
+func (pm pricingMediator) UpdatePackPrice(ctx context.Context, packPrice domain_model.PackPrice) (domain_model.PackPrice, error) {
+	if packPrice.Price.IsNegative() {
+		return domain_model.PackPrice{}, errors.New(fmt.Sprintf("price [%v] of pack size [%v] must not be negative", packPrice.Price, packPrice.PackSize))
+	}
+	if packPrice.Price.GreaterThan(MaxPackPrice) {
+		return domain_model.PackPrice{}, errors.New(fmt.Sprintf("price [%v] of pack size [%v] must be at most [%v]", packPrice.Price, packPrice.PackSize, MaxPackPrice))
+	}
+	currency := packPrice.Currency
+	if currency == "" {
+		currency = pm.currency
+	}
+	params := repository.UpdatePackPriceParams{
+		PackSize:      int32(packPrice.PackSize),
+		Price:         decimal.NullDecimal{Decimal: packPrice.Price, Valid: true},
+		Profile:       resolvePackProfile(packPrice.Profile, pm.defaultProfile),
+		TenantID:      domain_model.TenantFromContext(ctx),
+		PriceCurrency: sql.NullString{String: currency, Valid: currency != ""},
+	}
+	pack, updateErr := pm.pricingRepository.UpdatePackPrice(ctx, params)
+	if errors.Is(updateErr, sql.ErrNoRows) {
+		return domain_model.PackPrice{}, errors.Wrap(ErrPackNotFound, fmt.Sprintf("could not update price of pack size [%v] in profile [%v]", packPrice.PackSize, params.Profile))
+	}
+	if updateErr != nil {
+		return domain_model.PackPrice{}, errors.Wrap(updateErr, fmt.Sprintf("could not update price of pack size [%v] in profile [%v]", packPrice.PackSize, params.Profile))
+	}
+	return domain_model.PackPrice{PackSize: int(pack.PackSize), Price: pack.Price.Decimal, Currency: pack.PriceCurrency.String, Profile: pack.Profile}, nil
+}
+
+func (pm pricingMediator) AddDiscountTier(ctx context.Context, tier domain_model.DiscountTier) (domain_model.DiscountTier, error) {
+	if tier.MinQuantity <= 0 {
+		return domain_model.DiscountTier{}, errors.New(fmt.Sprintf("minimum quantity [%v] must be greater than 0", tier.MinQuantity))
+	}
+	if !tier.Percent.IsPositive() || tier.Percent.GreaterThan(oneHundred) {
+		return domain_model.DiscountTier{}, errors.New(fmt.Sprintf("discount [%v] must be greater than 0 and at most 100 percent", tier.Percent))
+	}
+
+	params := repository.AddDiscountTierParams{MinQuantity: int32(tier.MinQuantity), Percent: tier.Percent, TenantID: domain_model.TenantFromContext(ctx)}
+	added, addErr := pm.pricingRepository.AddDiscountTier(ctx, params)
+	var pqErr *pq.Error
+	if errors.As(addErr, &pqErr) && pqErr.Code == uniqueViolationCode {
+		return domain_model.DiscountTier{}, errors.Wrap(ErrDiscountTierAlreadyExists, fmt.Sprintf("could not add discount tier from [%v] items", tier.MinQuantity))
+	}
+	if addErr != nil {
+		return domain_model.DiscountTier{}, errors.Wrap(addErr, fmt.Sprintf("could not add discount tier from [%v] items", tier.MinQuantity))
+	}
+	return translateDiscountTierToDomainModel(added), nil
+}
+
+func (pm pricingMediator) RemoveDiscountTier(ctx context.Context, minQuantity int) error {
+	params := repository.RemoveDiscountTierByMinQuantityParams{MinQuantity: int32(minQuantity), TenantID: domain_model.TenantFromContext(ctx)}
+	removed, removeErr := pm.pricingRepository.RemoveDiscountTierByMinQuantity(ctx, params)
+	if removeErr != nil {
+		return errors.Wrap(removeErr, fmt.Sprintf("could not remove discount tier from [%v] items", minQuantity))
+	}
+	if removed == 0 {
+		return errors.Wrap(ErrDiscountTierNotFound, fmt.Sprintf("could not remove discount tier from [%v] items", minQuantity))
+	}
+	return nil
+}
+
+func (pm pricingMediator) RetrieveDiscountTiers(ctx context.Context) ([]domain_model.DiscountTier, error) {
+	tiers, retrieveErr := pm.pricingRepository.RetrieveDiscountTiers(ctx, domain_model.TenantFromContext(ctx))
+	if retrieveErr != nil {
+		return nil, errors.Wrap(retrieveErr, "could not retrieve discount tiers")
+	}
+	result := make([]domain_model.DiscountTier, 0, len(tiers))
+	for _, tier := range tiers {
+		result = append(result, translateDiscountTierToDomainModel(tier))
+	}
+	return result, nil
+}
+
+// Price the order packs with the current prices of the profile, nil when some of its packs have no price or their prices
+// are in different currencies
+func (om orderMediator) priceOrder(ctx context.Context, querier repository.Querier, profile string, quantity int, packs domain_model.OrderPack) (*domain_model.OrderPrice, error) {
+	tenant := domain_model.TenantFromContext(ctx)
+	packPrices, retrievePricesErr := querier.RetrievePackPrices(ctx, repository.RetrievePackPricesParams{Profile: profile, TenantID: tenant})
+	if retrievePricesErr != nil {
+		return nil, errors.Wrap(retrievePricesErr, "could not retrieve pack prices")
+	}
+	if len(packPrices) == 0 {
+		return nil, nil
+	}
+	tiers, retrieveTiersErr := querier.RetrieveDiscountTiers(ctx, tenant)
+	if retrieveTiersErr != nil {
+		return nil, errors.Wrap(retrieveTiersErr, "could not retrieve discount tiers")
+	}
+
+	prices := make(map[int]decimal.Decimal, len(packPrices))
+	currencies := make(map[int]string, len(packPrices))
+	for _, packPrice := range packPrices {
+		prices[int(packPrice.PackSize)] = packPrice.Price
+		currencies[int(packPrice.PackSize)] = om.currency
+		if packPrice.PriceCurrency.Valid {
+			currencies[int(packPrice.PackSize)] = packPrice.PriceCurrency.String
+		}
+	}
+	// Amounts in different currencies cannot be added up
+	currency := ""
+	for packSize, quantity := range packs {
+		if quantity == 0 {
+			continue
+		}
+		if packCurrency, priced := currencies[packSize]; priced {
+			if currency != "" && currency != packCurrency {
+				return nil, nil
+			}
+			currency = packCurrency
+		}
+	}
+	discountPercent := decimal.Zero
+	for _, tier := range tiers {
+		if int(tier.MinQuantity) <= quantity && tier.Percent.GreaterThan(discountPercent) {
+			discountPercent = tier.Percent
+		}
+	}
+	orderPrice := priceOrderPacks(packs, prices, discountPercent, currency)
+
+	// Prices are never negative, so no line total, discount or total is above the subtotal
+	if orderPrice != nil && orderPrice.Subtotal.GreaterThan(maxOrderAmount) {
+		return nil, errors.Wrap(ErrOrderPriceTooHigh, fmt.Sprintf("subtotal [%v] of [%v] items must be at most [%v]", orderPrice.Subtotal, quantity, maxOrderAmount))
+	}
+	return orderPrice, nil
+}
+
+// Add up the lines, biggest packs first, and take the discount off, rounded to the cent
+func priceOrderPacks(packs domain_model.OrderPack, prices map[int]decimal.Decimal, discountPercent decimal.Decimal, currency string) *domain_model.OrderPrice {
+	orderPrice := domain_model.OrderPrice{Currency: currency, Subtotal: decimal.Zero, DiscountPercent: discountPercent}
+	for _, packSize := range packSizesBiggestFirst(packs) {
+		if packs[packSize] == 0 {
+			continue
+		}
+		unitPrice, priced := prices[packSize]
+		if !priced {
+			return nil
+		}
+		lineTotal := unitPrice.Mul(decimal.NewFromInt(int64(packs[packSize])))
+		orderPrice.Lines = append(orderPrice.Lines, domain_model.PriceLine{
+			PackSize:  packSize,
+			Quantity:  packs[packSize],
+			UnitPrice: unitPrice,
+			LineTotal: lineTotal,
+		})
+		orderPrice.Subtotal = orderPrice.Subtotal.Add(lineTotal)
+	}
+	if len(orderPrice.Lines) == 0 {
+		return nil
+	}
+	orderPrice.Discount = orderPrice.Subtotal.Mul(discountPercent).Div(oneHundred).Round(2)
+	orderPrice.Total = orderPrice.Subtotal.Sub(orderPrice.Discount)
+	return &orderPrice
+}
+
+// Replace the price saved for the order by the given one, if any
+func replaceOrderPrice(ctx context.Context, querier repository.Querier, orderId uuid.UUID, orderPrice *domain_model.OrderPrice) error {
+	params := repository.RemoveOrderPriceByOrderParams{OrderID: orderId, TenantID: domain_model.TenantFromContext(ctx)}
+	if removeErr := querier.RemoveOrderPriceByOrder(ctx, params); removeErr != nil {
+		return errors.Wrap(removeErr, fmt.Sprintf("could not remove price of order [%v]", orderId))
+	}
+	return saveOrderPrice(ctx, querier, orderId, orderPrice)
+}
+
+// Save the order price along with each of its lines
+func saveOrderPrice(ctx context.Context, querier repository.Querier, orderId uuid.UUID, orderPrice *domain_model.OrderPrice) error {
+	if orderPrice == nil {
+		return nil
+	}
+	params := repository.AddOrderPriceParams{
+		OrderID:         orderId,
+		Currency:        orderPrice.Currency,
+		Subtotal:        orderPrice.Subtotal,
+		DiscountPercent: orderPrice.DiscountPercent,
+		Discount:        orderPrice.Discount,
+		Total:           orderPrice.Total,
+		TenantID:        domain_model.TenantFromContext(ctx),
+	}
+	if addErr := querier.AddOrderPrice(ctx, params); addErr != nil {
+		return errors.Wrap(addErr, fmt.Sprintf("could not save price of order [%v]", orderId))
+	}
+	for _, line := range orderPrice.Lines {
+		lineParams := repository.AddOrderPriceLineParams{
+			OrderID:      orderId,
+			PackSize:     int32(line.PackSize),
+			PackQuantity: int32(line.Quantity),
+			UnitPrice:    line.UnitPrice,
+			LineTotal:    line.LineTotal,
+			TenantID:     domain_model.TenantFromContext(ctx),
+		}
+		if addErr := querier.AddOrderPriceLine(ctx, lineParams); addErr != nil {
+			return errors.Wrap(addErr, fmt.Sprintf("could not save price of packs of size [%v] for order [%v]", line.PackSize, orderId))
+		}
+	}
+	return nil
+}
+
+// Translate from repository models to domain models
+func translateDiscountTierToDomainModel(tier repository.DiscountTier) domain_model.DiscountTier {
+	return domain_model.DiscountTier{MinQuantity: int(tier.MinQuantity), Percent: tier.Percent, CreatedAt: tier.CreatedAt}
+}
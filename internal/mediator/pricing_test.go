@@ -0,0 +1,278 @@
+package mediator_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+	repository_mocks "github.com/felipevillarrealdaza/go-service-template/internal/repository/mocks"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UpdatePackPrice(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	pricingMediator := mediator.NewPricingMediator(mediator.WithPricingRepository(repositoryMock), mediator.WithPricingCurrency("EUR"))
+	price := decimal.RequireFromString("49.99")
+
+	useCases := []struct {
+		name             string
+		currency         string
+		expectedCurrency string
+	}{
+		{name: "Updated in the service currency", currency: "", expectedCurrency: "EUR"},
+		{name: "Updated in another currency", currency: "USD", expectedCurrency: "USD"},
+	}
+	for _, useCase := range useCases {
+		t.Run(useCase.name, func(t *testing.T) {
+			// Arrange
+			params := repository.UpdatePackPriceParams{
+				PackSize:      1000,
+				Price:         decimal.NullDecimal{Decimal: price, Valid: true},
+				Profile:       "default",
+				TenantID:      "default",
+				PriceCurrency: sql.NullString{String: useCase.expectedCurrency, Valid: true},
+			}
+			repositoryMock.On("UpdatePackPrice", mock.Anything, params).Return(repository.Pack{PackSize: 1000, Price: params.Price, PriceCurrency: params.PriceCurrency}, nil).Once()
+
+			// Act
+			updated, updateErr := pricingMediator.UpdatePackPrice(context.Background(), domain_model.PackPrice{PackSize: 1000, Price: price, Currency: useCase.currency})
+
+			// Assert
+			require.NoError(t, updateErr)
+			require.Equal(t, "49.99", updated.ToViewModel().Price)
+			require.Equal(t, useCase.expectedCurrency, updated.ToViewModel().Currency)
+		})
+	}
+
+	t.Run("Pack not found", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("UpdatePackPrice", mock.Anything, mock.Anything).Return(repository.Pack{}, sql.ErrNoRows).Once()
+
+		// Act
+		_, updateErr := pricingMediator.UpdatePackPrice(context.Background(), domain_model.PackPrice{PackSize: 750, Price: price})
+
+		// Assert
+		require.ErrorIs(t, updateErr, mediator.ErrPackNotFound)
+	})
+
+	t.Run("Negative price", func(t *testing.T) {
+		// Act
+		_, updateErr := pricingMediator.UpdatePackPrice(context.Background(), domain_model.PackPrice{PackSize: 1000, Price: price.Neg()})
+
+		// Assert
+		require.ErrorContains(t, updateErr, "must not be negative")
+	})
+
+	t.Run("Price above the column range", func(t *testing.T) {
+		// Act
+		_, updateErr := pricingMediator.UpdatePackPrice(context.Background(), domain_model.PackPrice{PackSize: 1000, Price: decimal.RequireFromString("10000000000")})
+
+		// Assert
+		require.ErrorContains(t, updateErr, "must be at most")
+	})
+}
+
+func Test_AddDiscountTier(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	pricingMediator := mediator.NewPricingMediator(mediator.WithPricingRepository(repositoryMock))
+	percent := decimal.RequireFromString("7.5")
+
+	t.Run("Added", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("AddDiscountTier", mock.Anything, repository.AddDiscountTierParams{MinQuantity: 2000, Percent: percent, TenantID: "default"}).
+			Return(repository.DiscountTier{MinQuantity: 2000, Percent: percent}, nil).Once()
+
+		// Act
+		added, addErr := pricingMediator.AddDiscountTier(context.Background(), domain_model.DiscountTier{MinQuantity: 2000, Percent: percent})
+
+		// Assert
+		require.NoError(t, addErr)
+		require.Equal(t, "7.50", added.ToViewModel().Percent)
+	})
+
+	t.Run("Already exists", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("AddDiscountTier", mock.Anything, mock.Anything).Return(repository.DiscountTier{}, &pq.Error{Code: "23505"}).Once()
+
+		// Act
+		_, addErr := pricingMediator.AddDiscountTier(context.Background(), domain_model.DiscountTier{MinQuantity: 2000, Percent: percent})
+
+		// Assert
+		require.ErrorIs(t, addErr, mediator.ErrDiscountTierAlreadyExists)
+	})
+
+	t.Run("Above 100 percent", func(t *testing.T) {
+		// Act
+		_, addErr := pricingMediator.AddDiscountTier(context.Background(), domain_model.DiscountTier{MinQuantity: 2000, Percent: decimal.NewFromInt(101)})
+
+		// Assert
+		require.ErrorContains(t, addErr, "at most 100 percent")
+	})
+}
+
+func Test_RemoveDiscountTier(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	pricingMediator := mediator.NewPricingMediator(mediator.WithPricingRepository(repositoryMock))
+
+	t.Run("Removed", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RemoveDiscountTierByMinQuantity", mock.Anything, repository.RemoveDiscountTierByMinQuantityParams{MinQuantity: int32(2000), TenantID: "default"}).Return(int64(1), nil).Once()
+
+		// Act
+		removeErr := pricingMediator.RemoveDiscountTier(context.Background(), 2000)
+
+		// Assert
+		require.NoError(t, removeErr)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RemoveDiscountTierByMinQuantity", mock.Anything, repository.RemoveDiscountTierByMinQuantityParams{MinQuantity: int32(3000), TenantID: "default"}).Return(int64(0), nil).Once()
+
+		// Act
+		removeErr := pricingMediator.RemoveDiscountTier(context.Background(), 3000)
+
+		// Assert
+		require.ErrorIs(t, removeErr, mediator.ErrDiscountTierNotFound)
+	})
+}
+
+func Test_CalculateOrderPacks_Pricing(t *testing.T) {
+	// Set Up
+	tiers := []repository.DiscountTier{
+		{MinQuantity: 2000, Percent: decimal.RequireFromString("7.5")},
+		{MinQuantity: 5000, Percent: decimal.NewFromInt(10)},
+	}
+	useCases := []struct {
+		name          string
+		packPrices    []repository.RetrievePackPricesRow
+		expectedPrice *domain_model.OrderPrice
+	}{
+		{
+			name: "Discount of the tier reached",
+			packPrices: []repository.RetrievePackPricesRow{
+				{PackSize: 1000, Price: decimal.RequireFromString("49.99")},
+				{PackSize: 250, Price: decimal.RequireFromString("14.50")},
+			},
+			expectedPrice: &domain_model.OrderPrice{
+				Currency: "EUR",
+				Lines: []domain_model.PriceLine{
+					{PackSize: 1000, Quantity: 2, UnitPrice: decimal.RequireFromString("49.99"), LineTotal: decimal.RequireFromString("99.98")},
+					{PackSize: 250, Quantity: 1, UnitPrice: decimal.RequireFromString("14.50"), LineTotal: decimal.RequireFromString("14.50")},
+				},
+				Subtotal:        decimal.RequireFromString("114.48"),
+				DiscountPercent: decimal.RequireFromString("7.5"),
+				Discount:        decimal.RequireFromString("8.59"),
+				Total:           decimal.RequireFromString("105.89"),
+			},
+		},
+		{
+			name: "Prices in their own currency",
+			packPrices: []repository.RetrievePackPricesRow{
+				{PackSize: 1000, Price: decimal.RequireFromString("54.90"), PriceCurrency: sql.NullString{String: "USD", Valid: true}},
+				{PackSize: 250, Price: decimal.RequireFromString("15.90"), PriceCurrency: sql.NullString{String: "USD", Valid: true}},
+			},
+			expectedPrice: &domain_model.OrderPrice{
+				Currency: "USD",
+				Lines: []domain_model.PriceLine{
+					{PackSize: 1000, Quantity: 2, UnitPrice: decimal.RequireFromString("54.90"), LineTotal: decimal.RequireFromString("109.80")},
+					{PackSize: 250, Quantity: 1, UnitPrice: decimal.RequireFromString("15.90"), LineTotal: decimal.RequireFromString("15.90")},
+				},
+				Subtotal:        decimal.RequireFromString("125.70"),
+				DiscountPercent: decimal.RequireFromString("7.5"),
+				Discount:        decimal.RequireFromString("9.43"),
+				Total:           decimal.RequireFromString("116.27"),
+			},
+		},
+		{
+			name: "Prices in different currencies",
+			packPrices: []repository.RetrievePackPricesRow{
+				{PackSize: 1000, Price: decimal.RequireFromString("54.90"), PriceCurrency: sql.NullString{String: "USD", Valid: true}},
+				{PackSize: 250, Price: decimal.RequireFromString("14.50"), PriceCurrency: sql.NullString{String: "EUR", Valid: true}},
+			},
+			expectedPrice: nil,
+		},
+		{
+			name:          "Pack without a price",
+			packPrices:    []repository.RetrievePackPricesRow{{PackSize: 1000, Price: decimal.RequireFromString("49.99")}},
+			expectedPrice: nil,
+		},
+	}
+
+	for _, useCase := range useCases {
+		t.Run(useCase.name, func(t *testing.T) {
+			// Arrange
+			repositoryMock := repository_mocks.NewQuerier(t)
+			orderMediator := mediator.NewOrderMediator(mediator.WithOrderRepository(repositoryMock), mediator.WithOrderTransactor(newTransactorMock(t, repositoryMock)), mediator.WithOrderCurrency("EUR"))
+			orderId := uuid.New()
+			repositoryMock.On("RetrieveOrderById", mock.Anything, repository.RetrieveOrderByIdParams{OrderID: orderId, TenantID: "default"}).Return(repository.Order{OrderID: orderId, OrderQuantity: 2250, Status: "created", Profile: "default"}, nil)
+			repositoryMock.On("RetrievePacks", mock.Anything, repository.RetrievePacksParams{Profile: "default", TenantID: "default"}).Return([]int32{1000, 250}, nil)
+			repositoryMock.On("RetrieveContainerTypes", mock.Anything, "default").Return(nil, nil)
+			repositoryMock.On("RetrievePackPrices", mock.Anything, repository.RetrievePackPricesParams{Profile: "default", TenantID: "default"}).Return(useCase.packPrices, nil)
+			repositoryMock.On("RetrieveDiscountTiers", mock.Anything, "default").Return(tiers, nil)
+			repositoryMock.On("AddOrderPack", mock.Anything, mock.Anything).Return(nil)
+			repositoryMock.On("UpdateOrderPackSet", mock.Anything, mock.Anything).Return(nil)
+			repositoryMock.On("UpdateOrderStatus", mock.Anything, mock.Anything).Return(repository.Order{OrderID: orderId, Status: "calculated"}, nil)
+			if price := useCase.expectedPrice; price != nil {
+				repositoryMock.On("AddOrderPrice", mock.Anything, repository.AddOrderPriceParams{
+					OrderID:         orderId,
+					Currency:        price.Currency,
+					Subtotal:        price.Subtotal,
+					DiscountPercent: price.DiscountPercent,
+					Discount:        price.Discount,
+					Total:           price.Total,
+					TenantID:        "default",
+				}).Return(nil).Once()
+				for _, line := range price.Lines {
+					repositoryMock.On("AddOrderPriceLine", mock.Anything, repository.AddOrderPriceLineParams{
+						OrderID:      orderId,
+						PackSize:     int32(line.PackSize),
+						PackQuantity: int32(line.Quantity),
+						UnitPrice:    line.UnitPrice,
+						LineTotal:    line.LineTotal,
+						TenantID:     "default",
+					}).Return(nil).Once()
+				}
+			}
+
+			// Act
+			orderPacks, calculationErr := orderMediator.CalculateOrderPacks(context.Background(), orderId)
+
+			// Assert
+			require.NoError(t, calculationErr)
+			require.Equal(t, useCase.expectedPrice, orderPacks.Price)
+		})
+	}
+}
+
+func Test_CalculateOrderPacks_PriceTooHigh(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	orderMediator := mediator.NewOrderMediator(mediator.WithOrderRepository(repositoryMock), mediator.WithOrderTransactor(newTransactorMock(t, repositoryMock)), mediator.WithOrderCurrency("EUR"))
+	orderId := uuid.New()
+
+	// Arrange
+	repositoryMock.On("RetrieveOrderById", mock.Anything, mock.Anything).Return(repository.Order{OrderID: orderId, OrderQuantity: 200000, Status: "created", Profile: "default"}, nil).Once()
+	repositoryMock.On("RetrievePacks", mock.Anything, mock.Anything).Return([]int32{1000}, nil).Once()
+	repositoryMock.On("RetrieveContainerTypes", mock.Anything, "default").Return(nil, nil).Once()
+	repositoryMock.On("UpdateOrderStatus", mock.Anything, mock.Anything).Return(repository.Order{OrderID: orderId, Status: "calculated"}, nil).Once()
+	repositoryMock.On("RetrievePackPrices", mock.Anything, mock.Anything).Return([]repository.RetrievePackPricesRow{{PackSize: 1000, Price: mediator.MaxPackPrice}}, nil).Once()
+	repositoryMock.On("RetrieveDiscountTiers", mock.Anything, "default").Return(nil, nil).Once()
+
+	// Act
+	_, calculationErr := orderMediator.CalculateOrderPacks(context.Background(), orderId)
+
+	// Assert
+	require.ErrorIs(t, calculationErr, mediator.ErrOrderPriceTooHigh)
+}
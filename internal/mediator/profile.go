@@ -0,0 +1,96 @@
+package mediator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+)
+
+var (
+	ErrPackProfileNotFound      = errors.New("pack profile not found")
+	ErrPackProfileAlreadyExists = errors.New("pack profile already exists")
+	ErrInvalidPackProfile       = errors.New("invalid pack profile name")
+)
+
+// Lowercase letters, digits, dashes and underscores, starting with a letter or digit
+var packProfileNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
+// Packs are managed in the given profile when the operation names none
+func WithPackDefaultProfile(profile string) PackMediatorDeps {
+	return func(mediator *packMediator) {
+		mediator.defaultProfile = profile
+	}
+}
+
+// Orders and quotes are calculated with the packs of the given profile when they name none
+func WithOrderDefaultProfile(profile string) OrderMediatorDeps {
+	return func(mediator *orderMediator) {
+		mediator.defaultProfile = profile
+	}
+}
+
+// Pack prices are set in the given profile when the operation names none
+func WithPricingDefaultProfile(profile string) PricingMediatorDeps {
+	return func(mediator *pricingMediator) {
+		mediator.defaultProfile = profile
+	}
+}
+
+// Add an empty profile to the tenant of the request, its packs are added afterwards
+func (pm packMediator) AddPackProfile(ctx context.Context, name string) (domain_model.PackProfile, error) {
+	if !packProfileNamePattern.MatchString(name) {
+		return domain_model.PackProfile{}, errors.Wrap(ErrInvalidPackProfile, fmt.Sprintf("[%v] must be lowercase letters, digits, dashes or underscores", name))
+	}
+
+	added, addErr := pm.packRepository.AddPackProfile(ctx, repository.AddPackProfileParams{Name: name, TenantID: domain_model.TenantFromContext(ctx)})
+	if addErr != nil {
+		if isUniqueViolation(addErr) {
+			return domain_model.PackProfile{}, errors.Wrap(ErrPackProfileAlreadyExists, fmt.Sprintf("could not add pack profile [%v]", name))
+		}
+		return domain_model.PackProfile{}, errors.Wrap(addErr, fmt.Sprintf("could not add pack profile [%v]", name))
+	}
+	return translatePackProfileToDomainModel(added), nil
+}
+
+func (pm packMediator) RetrievePackProfiles(ctx context.Context) ([]domain_model.PackProfile, error) {
+	profiles, retrieveErr := pm.packRepository.RetrievePackProfiles(ctx, domain_model.TenantFromContext(ctx))
+	if retrieveErr != nil {
+		return nil, errors.Wrap(retrieveErr, "could not retrieve pack profiles")
+	}
+	result := make([]domain_model.PackProfile, 0, len(profiles))
+	for _, profile := range profiles {
+		result = append(result, translatePackProfileToDomainModel(profile))
+	}
+	return result, nil
+}
+
+// The named profile, or the default one when none is named
+func resolvePackProfile(profile string, defaultProfile string) string {
+	if profile == "" {
+		return defaultProfile
+	}
+	return profile
+}
+
+// Orders calculated with the packs of the profile
+func ordersOfProfile(orders []repository.Order, profile string) []repository.Order {
+	return slices.DeleteFunc(orders, func(order repository.Order) bool { return order.Profile != profile })
+}
+
+// Translate from repository models to domain models
+func translatePackProfileToDomainModel(profile repository.PackProfile) domain_model.PackProfile {
+	return domain_model.PackProfile{Name: profile.Name, CreatedAt: profile.CreatedAt}
+}
+
+// Rows naming a profile that does not exist violate its foreign key
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolationCode
+}
@@ -0,0 +1,157 @@
+package mediator_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+	repository_mocks "github.com/felipevillarrealdaza/go-service-template/internal/repository/mocks"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AddPackProfile(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	packMediator := mediator.NewPackMediator(mediator.WithPackRepository(repositoryMock))
+
+	t.Run("Added", func(t *testing.T) {
+		// Arrange
+		createdAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+		repositoryMock.On("AddPackProfile", mock.Anything, repository.AddPackProfileParams{Name: "wholesale", TenantID: "default"}).Return(repository.PackProfile{Name: "wholesale", CreatedAt: createdAt}, nil).Once()
+
+		// Act
+		profile, addErr := packMediator.AddPackProfile(context.Background(), "wholesale")
+
+		// Assert
+		require.NoError(t, addErr)
+		require.Equal(t, domain_model.PackProfile{Name: "wholesale", CreatedAt: createdAt}, profile)
+	})
+
+	t.Run("Already exists", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("AddPackProfile", mock.Anything, repository.AddPackProfileParams{Name: "wholesale", TenantID: "default"}).Return(repository.PackProfile{}, &pq.Error{Code: "23505"}).Once()
+
+		// Act
+		_, addErr := packMediator.AddPackProfile(context.Background(), "wholesale")
+
+		// Assert
+		require.ErrorIs(t, addErr, mediator.ErrPackProfileAlreadyExists)
+	})
+
+	for _, name := range []string{"Wholesale", "-retail", "retail profile", ""} {
+		t.Run("Invalid name "+name, func(t *testing.T) {
+			// Act
+			_, addErr := packMediator.AddPackProfile(context.Background(), name)
+
+			// Assert
+			require.ErrorIs(t, addErr, mediator.ErrInvalidPackProfile)
+		})
+	}
+}
+
+func Test_RetrievePackProfiles(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	packMediator := mediator.NewPackMediator(mediator.WithPackRepository(repositoryMock))
+
+	t.Run("Retrieved", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrievePackProfiles", mock.Anything, "default").Return([]repository.PackProfile{{Name: "default"}, {Name: "wholesale"}}, nil).Once()
+
+		// Act
+		profiles, retrieveErr := packMediator.RetrievePackProfiles(context.Background())
+
+		// Assert
+		require.NoError(t, retrieveErr)
+		require.Equal(t, []domain_model.PackProfile{{Name: "default"}, {Name: "wholesale"}}, profiles)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrievePackProfiles", mock.Anything, "default").Return(nil, errors.New("connection refused")).Once()
+
+		// Act
+		_, retrieveErr := packMediator.RetrievePackProfiles(context.Background())
+
+		// Assert
+		require.ErrorContains(t, retrieveErr, "connection refused")
+	})
+}
+
+func Test_PackProfiles(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	packMediator := mediator.NewPackMediator(
+		mediator.WithPackRepository(repositoryMock),
+		mediator.WithPackTransactor(newTransactorMock(t, repositoryMock)),
+		mediator.WithPackDefaultProfile("retail"),
+	)
+	orderMediator := mediator.NewOrderMediator(
+		mediator.WithOrderRepository(repositoryMock),
+		mediator.WithOrderDefaultProfile("retail"),
+	)
+
+	t.Run("Pack added to the default profile", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("AddPack", mock.Anything, repository.AddPackParams{Profile: "retail", PackSize: 250, TenantID: "default"}).Return(nil).Once()
+		repositoryMock.On("AddPackAudit", mock.Anything, mock.MatchedBy(func(params repository.AddPackAuditParams) bool {
+			return params.Profile == "retail" && params.PackSize == 250
+		})).Return(nil).Once()
+		repositoryMock.On("NotifyPackSetChanged", mock.Anything).Return(nil).Once()
+
+		// Act
+		addErr := packMediator.AddPack(context.Background(), "", 250)
+
+		// Assert
+		require.NoError(t, addErr)
+	})
+
+	t.Run("Pack added to an unknown profile", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("AddPack", mock.Anything, repository.AddPackParams{Profile: "wholesale", PackSize: 250, TenantID: "default"}).Return(&pq.Error{Code: "23503"}).Once()
+
+		// Act
+		addErr := packMediator.AddPack(context.Background(), "wholesale", 250)
+
+		// Assert
+		require.ErrorIs(t, addErr, mediator.ErrPackProfileNotFound)
+	})
+
+	t.Run("Order of an unknown profile", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("AddOrder", mock.Anything, mock.MatchedBy(func(params repository.AddOrderParams) bool {
+			return params.Profile == "wholesale"
+		})).Return(&pq.Error{Code: "23503"}).Once()
+
+		// Act
+		createErr := orderMediator.CreateOrder(context.Background(), domain_model.Order{OrderId: uuid.New(), Quantity: 500, Profile: "wholesale"})
+
+		// Assert
+		require.ErrorIs(t, createErr, mediator.ErrPackProfileNotFound)
+	})
+
+	t.Run("Simulation with the orders of the profile only", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrievePacks", mock.Anything, repository.RetrievePacksParams{Profile: "wholesale", TenantID: "default"}).Return([]int32{5}, nil).Once()
+		repositoryMock.On("RetrieveOrders", mock.Anything, "default").Return([]repository.Order{
+			{OrderQuantity: 10, Status: "shipped", Profile: "wholesale"},
+			{OrderQuantity: 7, Status: "shipped", Profile: "retail"},
+		}, nil).Once()
+
+		// Act
+		report, simulateErr := packMediator.SimulatePackSet(context.Background(), domain_model.PackSetSimulation{PackSizes: []int{10}, Profile: "wholesale"})
+
+		// Assert
+		require.NoError(t, simulateErr)
+		require.Equal(t, 1, report.Orders)
+		require.Equal(t, 10, report.Current.ItemsShipped)
+	})
+}
@@ -0,0 +1,237 @@
+package mediator
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+)
+
+var (
+	ErrQuoteNotFound        = errors.New("quote not found")
+	ErrQuoteExpired         = errors.New("quote expired")
+	ErrQuoteAlreadyAccepted = errors.New("quote already accepted")
+)
+
+// Quotes can be accepted for the given time after they are created
+func WithQuoteTtl(ttl time.Duration) OrderMediatorDeps {
+	return func(mediator *orderMediator) {
+		mediator.quoteTtl = ttl
+	}
+}
+
+// Calculate and price the packs of the profile for the quantity, keeping them until the quote expires without
+// creating an order
+func (om orderMediator) CreateQuote(ctx context.Context, profile string, quantity int) (domain_model.Quote, error) {
+	// Validate quote quantity is a natural number
+	if quantity <= 0 {
+		return domain_model.Quote{}, errors.New(fmt.Sprintf("quote quantity [%v] must be greater than 0", quantity))
+	}
+	profile = resolvePackProfile(profile, om.defaultProfile)
+
+	packs, retrievePacksErr := om.retrieveShippablePacks(ctx, om.orderRepository, profile, time.Time{})
+	if retrievePacksErr != nil {
+		return domain_model.Quote{}, errors.Wrap(retrievePacksErr, "could not retrieve available packs")
+	}
+	quoteId := uuid.New()
+	orderPacksResult := om.calculateOrderPacks(translateToDomainModel(repository.Order{OrderID: quoteId, OrderQuantity: int32(quantity)}, packs))
+	orderPrice, priceErr := om.priceOrder(ctx, om.orderRepository, profile, quantity, orderPacksResult.OptimalOrderPack)
+	if priceErr != nil {
+		return domain_model.Quote{}, errors.Wrap(priceErr, fmt.Sprintf("could not price quote for [%v] items", quantity))
+	}
+
+	quote := domain_model.Quote{
+		QuoteId:   quoteId,
+		Quantity:  quantity,
+		Packs:     make(domain_model.OrderPack),
+		Price:     orderPrice,
+		ExpiresAt: time.Now().Add(om.quoteTtl).UTC(),
+		Profile:   profile,
+	}
+	for packSize, packQuantity := range orderPacksResult.OptimalOrderPack {
+		if packQuantity > 0 {
+			quote.Packs[packSize] = packQuantity
+		}
+	}
+
+	txErr := om.orderTransactor.WithinTransaction(ctx, func(querier repository.Querier) error {
+		return saveQuote(ctx, querier, quote, packs)
+	})
+	if txErr != nil {
+		if isForeignKeyViolation(txErr) {
+			return domain_model.Quote{}, errors.Wrap(ErrPackProfileNotFound, fmt.Sprintf("could not save quote for [%v] items of profile [%v]", quantity, profile))
+		}
+		return domain_model.Quote{}, errors.Wrap(txErr, fmt.Sprintf("could not save quote for [%v] items", quantity))
+	}
+	return quote, nil
+}
+
+// Create a calculated order with exactly the quoted packs and price, whatever the current pack set and prices are
+func (om orderMediator) AcceptQuote(ctx context.Context, quoteId uuid.UUID) (domain_model.OrderPacks, error) {
+	var orderPacks domain_model.OrderPacks
+	tenant := domain_model.TenantFromContext(ctx)
+	txErr := om.orderTransactor.WithinTransaction(ctx, func(querier repository.Querier) error {
+		// Quotes of other tenants are never found
+		quote, retrieveErr := querier.RetrieveQuoteById(ctx, repository.RetrieveQuoteByIdParams{QuoteID: quoteId, TenantID: tenant})
+		if errors.Is(retrieveErr, sql.ErrNoRows) {
+			return ErrQuoteNotFound
+		}
+		if retrieveErr != nil {
+			return errors.Wrap(retrieveErr, "could not retrieve quote")
+		}
+		if quote.OrderID.Valid {
+			return errors.Wrap(ErrQuoteAlreadyAccepted, fmt.Sprintf("order [%v] was created from it", quote.OrderID.UUID))
+		}
+		if !quote.ExpiresAt.After(time.Now()) {
+			return errors.Wrap(ErrQuoteExpired, fmt.Sprintf("expired at [%v]", quote.ExpiresAt))
+		}
+		quotePacks, retrievePacksErr := querier.RetrieveQuotePacksByQuote(ctx, repository.RetrieveQuotePacksByQuoteParams{QuoteID: quoteId, TenantID: tenant})
+		if retrievePacksErr != nil {
+			return errors.Wrap(retrievePacksErr, "could not retrieve quote packs")
+		}
+		containerTypes, retrieveContainerTypesErr := querier.RetrieveContainerTypes(ctx, tenant)
+		if retrieveContainerTypesErr != nil {
+			return errors.Wrap(retrieveContainerTypesErr, "could not retrieve container types")
+		}
+
+		// Claim the quote first, so it is accepted once even when accepted concurrently or just expiring
+		orderId := uuid.New()
+		orderParams := repository.AddOrderParams{OrderID: orderId, OrderQuantity: quote.QuoteQuantity, Profile: quote.Profile, TenantID: tenant}
+		if addErr := querier.AddOrder(ctx, orderParams); addErr != nil {
+			return errors.Wrap(addErr, "could not add order")
+		}
+		acceptParams := repository.AcceptQuoteParams{QuoteID: quoteId, OrderID: uuid.NullUUID{UUID: orderId, Valid: true}, TenantID: tenant}
+		accepted, acceptErr := querier.AcceptQuote(ctx, acceptParams)
+		if acceptErr != nil {
+			return errors.Wrap(acceptErr, "could not accept quote")
+		}
+		if accepted == 0 {
+			return errors.Wrap(ErrQuoteAlreadyAccepted, "accepted or expired meanwhile")
+		}
+
+		orderPacks = translateQuoteToDomainModel(orderId, quote, quotePacks)
+		orderPacks.Packaging = planOrderPackaging(orderPacks.OptimalOrderPack, containerTypes)
+		orderPacks.Shipments = splitIntoShipments(orderPacks.OptimalOrderPack, om.shippingConstraints)
+
+		// Save the order as if it was calculated with the quoted pack set
+		if saveErr := saveEachOrderPack(ctx, querier, orderPacks); saveErr != nil {
+			return saveErr
+		}
+		if saveShipmentsErr := saveEachOrderShipment(ctx, querier, orderId, orderPacks.Shipments); saveShipmentsErr != nil {
+			return saveShipmentsErr
+		}
+		if savePriceErr := saveOrderPrice(ctx, querier, orderId, orderPacks.Price); savePriceErr != nil {
+			return savePriceErr
+		}
+		packSetParams := repository.UpdateOrderPackSetParams{OrderID: orderId, PackSet: quote.PackSet, TenantID: tenant}
+		if packSetErr := querier.UpdateOrderPackSet(ctx, packSetParams); packSetErr != nil {
+			return errors.Wrap(packSetErr, "could not save pack set")
+		}
+		statusParams := repository.UpdateOrderStatusParams{
+			ToStatus:   string(domain_model.OrderStatusCalculated),
+			OrderID:    orderId,
+			FromStatus: string(domain_model.OrderStatusCreated),
+			TenantID:   tenant,
+		}
+		if _, statusErr := querier.UpdateOrderStatus(ctx, statusParams); statusErr != nil {
+			return errors.Wrap(statusErr, "could not mark order as calculated")
+		}
+		return nil
+	})
+	if txErr != nil {
+		return domain_model.OrderPacks{}, errors.Wrap(txErr, fmt.Sprintf("could not accept quote [%v]", quoteId))
+	}
+	return orderPacks, nil
+}
+
+// Remove the quotes past their expiry, along with their packs
+func (om orderMediator) PurgeExpiredQuotes(ctx context.Context) (int, error) {
+	purged, removeErr := om.orderRepository.RemoveExpiredQuotes(ctx, time.Now())
+	if removeErr != nil {
+		return 0, errors.Wrap(removeErr, "could not remove expired quotes")
+	}
+	return int(purged), nil
+}
+
+// Save the quote along with each of its packs and their prices
+func saveQuote(ctx context.Context, querier repository.Querier, quote domain_model.Quote, packSet []int32) error {
+	params := repository.AddQuoteParams{
+		QuoteID:       quote.QuoteId,
+		QuoteQuantity: int32(quote.Quantity),
+		PackSet:       packSet,
+		ExpiresAt:     quote.ExpiresAt,
+		Profile:       quote.Profile,
+		TenantID:      domain_model.TenantFromContext(ctx),
+	}
+	unitPrices := make(map[int]decimal.Decimal)
+	if quote.Price != nil {
+		params.Currency = sql.NullString{String: quote.Price.Currency, Valid: true}
+		params.Subtotal = decimal.NewNullDecimal(quote.Price.Subtotal)
+		params.DiscountPercent = decimal.NewNullDecimal(quote.Price.DiscountPercent)
+		params.Discount = decimal.NewNullDecimal(quote.Price.Discount)
+		params.Total = decimal.NewNullDecimal(quote.Price.Total)
+		for _, line := range quote.Price.Lines {
+			unitPrices[line.PackSize] = line.UnitPrice
+		}
+	}
+	if addErr := querier.AddQuote(ctx, params); addErr != nil {
+		return errors.Wrap(addErr, "could not add quote")
+	}
+
+	for _, packSize := range packSizesBiggestFirst(quote.Packs) {
+		packParams := repository.AddQuotePackParams{
+			QuoteID:      quote.QuoteId,
+			PackSize:     int32(packSize),
+			PackQuantity: int32(quote.Packs[packSize]),
+			TenantID:     params.TenantID,
+		}
+		if unitPrice, priced := unitPrices[packSize]; priced {
+			packParams.UnitPrice = decimal.NewNullDecimal(unitPrice)
+			packParams.LineTotal = decimal.NewNullDecimal(unitPrice.Mul(decimal.NewFromInt(int64(quote.Packs[packSize]))))
+		}
+		if addErr := querier.AddQuotePack(ctx, packParams); addErr != nil {
+			return errors.Wrap(addErr, fmt.Sprintf("could not add packs of size [%v] to quote", packSize))
+		}
+	}
+	return nil
+}
+
+// Translate the quote to the packs of the order created from it
+func translateQuoteToDomainModel(orderId uuid.UUID, quote repository.Quote, quotePacks []repository.QuotePack) domain_model.OrderPacks {
+	quantity := int(quote.QuoteQuantity)
+	packs := make(domain_model.OrderPack, len(quotePacks))
+	for _, quotePack := range quotePacks {
+		packs[int(quotePack.PackSize)] = int(quotePack.PackQuantity)
+	}
+	orderPacks := domain_model.OrderPacks{
+		OrderId:          orderId,
+		OrderQuantity:    quantity,
+		ResultGrid:       map[int]domain_model.OrderPack{quantity: packs},
+		OptimalOrderPack: packs,
+	}
+	if quote.Total.Valid {
+		orderPacks.Price = &domain_model.OrderPrice{
+			Currency:        quote.Currency.String,
+			Subtotal:        quote.Subtotal.Decimal,
+			DiscountPercent: quote.DiscountPercent.Decimal,
+			Discount:        quote.Discount.Decimal,
+			Total:           quote.Total.Decimal,
+		}
+		for _, quotePack := range quotePacks {
+			orderPacks.Price.Lines = append(orderPacks.Price.Lines, domain_model.PriceLine{
+				PackSize:  int(quotePack.PackSize),
+				Quantity:  int(quotePack.PackQuantity),
+				UnitPrice: quotePack.UnitPrice.Decimal,
+				LineTotal: quotePack.LineTotal.Decimal,
+			})
+		}
+	}
+	return orderPacks
+}
@@ -0,0 +1,212 @@
+package mediator_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+	repository_mocks "github.com/felipevillarrealdaza/go-service-template/internal/repository/mocks"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateQuote(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	orderMediator := mediator.NewOrderMediator(
+		mediator.WithOrderRepository(repositoryMock),
+		mediator.WithOrderTransactor(newTransactorMock(t, repositoryMock)),
+		mediator.WithOrderCurrency("EUR"),
+		mediator.WithQuoteTtl(time.Hour),
+	)
+
+	t.Run("Priced quote", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrievePacks", mock.Anything, repository.RetrievePacksParams{Profile: "default", TenantID: "default"}).Return([]int32{1000, 250}, nil).Once()
+		repositoryMock.On("RetrievePackPrices", mock.Anything, repository.RetrievePackPricesParams{Profile: "default", TenantID: "default"}).Return([]repository.RetrievePackPricesRow{
+			{PackSize: 1000, Price: decimal.RequireFromString("49.99")},
+			{PackSize: 250, Price: decimal.RequireFromString("14.50")},
+		}, nil).Once()
+		repositoryMock.On("RetrieveDiscountTiers", mock.Anything, "default").Return(nil, nil).Once()
+		var savedQuote repository.AddQuoteParams
+		repositoryMock.On("AddQuote", mock.Anything, mock.Anything).Return(nil).Once().Run(func(args mock.Arguments) {
+			savedQuote = args.Get(1).(repository.AddQuoteParams)
+		})
+		repositoryMock.On("AddQuotePack", mock.Anything, mock.MatchedBy(func(params repository.AddQuotePackParams) bool {
+			return params.PackSize == 1000 && params.PackQuantity == 2 && params.LineTotal.Decimal.Equal(decimal.RequireFromString("99.98"))
+		})).Return(nil).Once()
+		repositoryMock.On("AddQuotePack", mock.Anything, mock.MatchedBy(func(params repository.AddQuotePackParams) bool {
+			return params.PackSize == 250 && params.PackQuantity == 1 && params.UnitPrice.Decimal.Equal(decimal.RequireFromString("14.50"))
+		})).Return(nil).Once()
+
+		// Act
+		quote, quoteErr := orderMediator.CreateQuote(context.Background(), "", 2250)
+
+		// Assert
+		require.NoError(t, quoteErr)
+		require.Equal(t, domain_model.OrderPack{1000: 2, 250: 1}, quote.Packs)
+		require.Equal(t, "114.48", quote.Price.Total.StringFixed(2))
+		require.WithinDuration(t, time.Now().Add(time.Hour), quote.ExpiresAt, time.Minute)
+		require.Equal(t, quote.QuoteId, savedQuote.QuoteID)
+		require.Equal(t, []int32{1000, 250}, savedQuote.PackSet)
+		require.Equal(t, "EUR", savedQuote.Currency.String)
+		require.True(t, savedQuote.Total.Decimal.Equal(quote.Price.Total))
+	})
+
+	t.Run("Quantity of zero", func(t *testing.T) {
+		// Act
+		_, quoteErr := orderMediator.CreateQuote(context.Background(), "", 0)
+
+		// Assert
+		require.ErrorContains(t, quoteErr, "must be greater than 0")
+	})
+}
+
+func Test_AcceptQuote_OK(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	orderMediator := mediator.NewOrderMediator(
+		mediator.WithOrderRepository(repositoryMock),
+		mediator.WithOrderTransactor(newTransactorMock(t, repositoryMock)),
+	)
+	quoteId := uuid.New()
+	quote := repository.Quote{
+		QuoteID:         quoteId,
+		QuoteQuantity:   1200,
+		PackSet:         []int32{1000, 500},
+		Currency:        sql.NullString{String: "EUR", Valid: true},
+		Subtotal:        decimal.NewNullDecimal(decimal.RequireFromString("70.00")),
+		DiscountPercent: decimal.NewNullDecimal(decimal.Zero),
+		Discount:        decimal.NewNullDecimal(decimal.Zero),
+		Total:           decimal.NewNullDecimal(decimal.RequireFromString("70.00")),
+		ExpiresAt:       time.Now().Add(time.Hour),
+	}
+	quotePacks := []repository.QuotePack{
+		{QuoteID: quoteId, PackSize: 1000, PackQuantity: 1, UnitPrice: decimal.NewNullDecimal(decimal.RequireFromString("45.00")), LineTotal: decimal.NewNullDecimal(decimal.RequireFromString("45.00"))},
+		{QuoteID: quoteId, PackSize: 500, PackQuantity: 1, UnitPrice: decimal.NewNullDecimal(decimal.RequireFromString("25.00")), LineTotal: decimal.NewNullDecimal(decimal.RequireFromString("25.00"))},
+	}
+
+	// Arrange
+	var orderId uuid.UUID
+	repositoryMock.On("RetrieveQuoteById", mock.Anything, repository.RetrieveQuoteByIdParams{QuoteID: quoteId, TenantID: "default"}).Return(quote, nil)
+	repositoryMock.On("RetrieveQuotePacksByQuote", mock.Anything, repository.RetrieveQuotePacksByQuoteParams{QuoteID: quoteId, TenantID: "default"}).Return(quotePacks, nil)
+	repositoryMock.On("RetrieveContainerTypes", mock.Anything, "default").Return(nil, nil)
+	repositoryMock.On("AddOrder", mock.Anything, mock.MatchedBy(func(params repository.AddOrderParams) bool { return params.OrderQuantity == 1200 })).
+		Return(nil).Run(func(args mock.Arguments) { orderId = args.Get(1).(repository.AddOrderParams).OrderID })
+	repositoryMock.On("AcceptQuote", mock.Anything, mock.MatchedBy(func(params repository.AcceptQuoteParams) bool {
+		return params.QuoteID == quoteId && params.OrderID.UUID == orderId
+	})).Return(int64(1), nil)
+	repositoryMock.On("AddOrderPack", mock.Anything, mock.Anything).Return(nil).Times(2)
+	repositoryMock.On("AddOrderPrice", mock.Anything, mock.MatchedBy(func(params repository.AddOrderPriceParams) bool {
+		return params.OrderID == orderId && params.Total.Equal(decimal.RequireFromString("70"))
+	})).Return(nil)
+	repositoryMock.On("AddOrderPriceLine", mock.Anything, mock.Anything).Return(nil).Times(2)
+	repositoryMock.On("UpdateOrderPackSet", mock.Anything, mock.MatchedBy(func(params repository.UpdateOrderPackSetParams) bool {
+		return params.OrderID == orderId && len(params.PackSet) == 2
+	})).Return(nil)
+	repositoryMock.On("UpdateOrderStatus", mock.Anything, mock.MatchedBy(func(params repository.UpdateOrderStatusParams) bool {
+		return params.OrderID == orderId && params.FromStatus == "created" && params.ToStatus == "calculated"
+	})).Return(repository.Order{}, nil)
+
+	// Act
+	orderPacks, acceptErr := orderMediator.AcceptQuote(context.Background(), quoteId)
+
+	// Assert
+	require.NoError(t, acceptErr)
+	require.Equal(t, orderId, orderPacks.OrderId)
+	require.Equal(t, domain_model.OrderPack{1000: 1, 500: 1}, orderPacks.OptimalOrderPack)
+	require.Equal(t, "70.00", orderPacks.Price.Total.StringFixed(2))
+	require.Len(t, orderPacks.ToViewModel().Packs, 2)
+}
+
+func Test_AcceptQuote_Errors(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	orderMediator := mediator.NewOrderMediator(
+		mediator.WithOrderRepository(repositoryMock),
+		mediator.WithOrderTransactor(newTransactorMock(t, repositoryMock)),
+	)
+	useCases := []struct {
+		name        string
+		quote       repository.Quote
+		retrieveErr error
+		expectedErr error
+	}{
+		{name: "Not found", retrieveErr: sql.ErrNoRows, expectedErr: mediator.ErrQuoteNotFound},
+		{name: "Expired", quote: repository.Quote{ExpiresAt: time.Now().Add(-time.Minute)}, expectedErr: mediator.ErrQuoteExpired},
+		{
+			name:        "Already accepted",
+			quote:       repository.Quote{OrderID: uuid.NullUUID{UUID: uuid.New(), Valid: true}, ExpiresAt: time.Now().Add(time.Hour)},
+			expectedErr: mediator.ErrQuoteAlreadyAccepted,
+		},
+	}
+
+	for _, useCase := range useCases {
+		t.Run(useCase.name, func(t *testing.T) {
+			// Arrange
+			quoteId := uuid.New()
+			repositoryMock.On("RetrieveQuoteById", mock.Anything, repository.RetrieveQuoteByIdParams{QuoteID: quoteId, TenantID: "default"}).Return(useCase.quote, useCase.retrieveErr).Once()
+
+			// Act
+			_, acceptErr := orderMediator.AcceptQuote(context.Background(), quoteId)
+
+			// Assert
+			require.ErrorIs(t, acceptErr, useCase.expectedErr)
+		})
+	}
+
+	t.Run("Accepted meanwhile", func(t *testing.T) {
+		// Arrange
+		quoteId := uuid.New()
+		repositoryMock.On("RetrieveQuoteById", mock.Anything, repository.RetrieveQuoteByIdParams{QuoteID: quoteId, TenantID: "default"}).Return(repository.Quote{QuoteID: quoteId, QuoteQuantity: 500, ExpiresAt: time.Now().Add(time.Hour)}, nil).Once()
+		repositoryMock.On("RetrieveQuotePacksByQuote", mock.Anything, repository.RetrieveQuotePacksByQuoteParams{QuoteID: quoteId, TenantID: "default"}).Return([]repository.QuotePack{{PackSize: 500, PackQuantity: 1}}, nil).Once()
+		repositoryMock.On("RetrieveContainerTypes", mock.Anything, "default").Return(nil, nil).Once()
+		repositoryMock.On("AddOrder", mock.Anything, mock.Anything).Return(nil).Once()
+		repositoryMock.On("AcceptQuote", mock.Anything, mock.Anything).Return(int64(0), nil).Once()
+
+		// Act
+		_, acceptErr := orderMediator.AcceptQuote(context.Background(), quoteId)
+
+		// Assert
+		require.ErrorIs(t, acceptErr, mediator.ErrQuoteAlreadyAccepted)
+	})
+}
+
+func Test_PurgeExpiredQuotes(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	orderMediator := mediator.NewOrderMediator(mediator.WithOrderRepository(repositoryMock))
+
+	t.Run("Purged", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RemoveExpiredQuotes", mock.Anything, mock.MatchedBy(func(expiredBefore time.Time) bool {
+			return time.Since(expiredBefore) < time.Minute
+		})).Return(int64(3), nil).Once()
+
+		// Act
+		purged, purgeErr := orderMediator.PurgeExpiredQuotes(context.Background())
+
+		// Assert
+		require.NoError(t, purgeErr)
+		require.Equal(t, 3, purged)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RemoveExpiredQuotes", mock.Anything, mock.Anything).Return(int64(0), errors.New("connection refused")).Once()
+
+		// Act
+		_, purgeErr := orderMediator.PurgeExpiredQuotes(context.Background())
+
+		// Assert
+		require.ErrorContains(t, purgeErr, "connection refused")
+	})
+}
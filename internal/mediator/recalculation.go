@@ -0,0 +1,189 @@
+package mediator
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+)
+
+const defaultRecalculationBatchSize = 500
+
+// Re-plan the open orders of the profile matching the filter with the pack set valid at their date, one transaction
+// per batch. On dry runs the report is built but nothing is written.
+func (om orderMediator) RecalculateOrders(ctx context.Context, filter domain_model.RecalculationFilter) (domain_model.RecalculationReport, error) {
+	statuses := filter.Statuses
+	if len(statuses) == 0 {
+		statuses = []domain_model.OrderStatus{domain_model.OrderStatusCreated, domain_model.OrderStatusCalculated}
+	}
+	params := repository.RetrieveOrdersForRecalculationParams{
+		CreatedFrom: sql.NullTime{Time: filter.From, Valid: !filter.From.IsZero()},
+		CreatedTo:   sql.NullTime{Time: filter.To, Valid: !filter.To.IsZero()},
+		BatchSize:   int32(filter.BatchSize),
+		Profile:     resolvePackProfile(filter.Profile, om.defaultProfile),
+		TenantID:    domain_model.TenantFromContext(ctx),
+	}
+	for _, status := range statuses {
+		if !status.IsAmendable() {
+			return domain_model.RecalculationReport{}, errors.Wrap(ErrOrderNotAmendable, fmt.Sprintf("could not recalculate orders in status [%v]", status))
+		}
+		params.Statuses = append(params.Statuses, string(status))
+	}
+	if params.BatchSize <= 0 {
+		params.BatchSize = defaultRecalculationBatchSize
+	}
+
+	packs, retrievePacksErr := om.retrieveShippablePacks(ctx, om.orderRepository, params.Profile, time.Time{})
+	if retrievePacksErr != nil {
+		return domain_model.RecalculationReport{}, errors.Wrap(retrievePacksErr, "could not retrieve available packs")
+	}
+	report := domain_model.RecalculationReport{DryRun: filter.DryRun, Profile: params.Profile, PackSet: packSetToDomainModel(packs)}
+
+	// Orders share the pack set of their date, so it is only retrieved once per date
+	packsByDate := make(map[int64][]int32)
+	packsAt := func(querier repository.Querier, orderDate time.Time) ([]int32, error) {
+		if datePacks, found := packsByDate[orderDate.UnixNano()]; found {
+			return datePacks, nil
+		}
+		datePacks, retrieveErr := om.retrieveShippablePacks(ctx, querier, params.Profile, orderDate)
+		if retrieveErr != nil {
+			return nil, errors.Wrap(retrieveErr, fmt.Sprintf("could not retrieve packs valid at [%v]", orderDate))
+		}
+		packsByDate[orderDate.UnixNano()] = datePacks
+		return datePacks, nil
+	}
+
+	for {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return report, errors.Wrap(ctxErr, fmt.Sprintf("recalculation interrupted after [%v] orders", report.Examined))
+		}
+
+		// Changes are only reported once their batch is committed
+		var batch []repository.Order
+		var batchChanged []domain_model.OrderRecalculation
+		var batchSkipped []uuid.UUID
+		txErr := om.orderTransactor.WithinTransaction(ctx, func(querier repository.Querier) error {
+			batchChanged, batchSkipped = nil, nil
+			var retrieveErr error
+			batch, retrieveErr = querier.RetrieveOrdersForRecalculation(ctx, params)
+			if retrieveErr != nil {
+				return errors.Wrap(retrieveErr, "could not retrieve orders")
+			}
+			for _, order := range batch {
+				orderPacks, retrievePacksErr := packsAt(querier, order.OrderDate)
+				if retrievePacksErr != nil {
+					return retrievePacksErr
+				}
+				// Without packs the order would lose the ones it has, so it is left as it is
+				if len(orderPacks) == 0 {
+					batchSkipped = append(batchSkipped, order.OrderID)
+					continue
+				}
+				recalculation, changed, recalculateErr := om.recalculateOrder(ctx, querier, order, orderPacks, filter.DryRun)
+				if recalculateErr != nil {
+					return recalculateErr
+				}
+				if changed {
+					batchChanged = append(batchChanged, recalculation)
+				}
+			}
+			return nil
+		})
+		if txErr != nil {
+			return report, errors.Wrap(txErr, fmt.Sprintf("recalculation failed after [%v] orders", report.Examined))
+		}
+
+		report.Examined += len(batch)
+		report.Changed = append(report.Changed, batchChanged...)
+		report.Skipped = append(report.Skipped, batchSkipped...)
+		if len(batch) < int(params.BatchSize) {
+			return report, nil
+		}
+		params.AfterOrderID = batch[len(batch)-1].OrderID
+	}
+}
+
+// Calculate the order with the given packs and replace its packs when they changed. Orders never calculated are marked
+// as calculated along with their new packs.
+func (om orderMediator) recalculateOrder(ctx context.Context, querier repository.Querier, order repository.Order, packs []int32, dryRun bool) (domain_model.OrderRecalculation, bool, error) {
+	tenant := domain_model.TenantFromContext(ctx)
+	orderPacksParams := repository.RetrieveOrderPacksByOrderParams{OrderID: order.OrderID, TenantID: tenant}
+	previousOrderPacks, retrieveErr := querier.RetrieveOrderPacksByOrder(ctx, orderPacksParams)
+	if retrieveErr != nil {
+		return domain_model.OrderRecalculation{}, false, errors.Wrap(retrieveErr, fmt.Sprintf("could not retrieve packs of order [%v]", order.OrderID))
+	}
+	previousPacks := make(domain_model.OrderPack)
+	for _, orderPack := range previousOrderPacks {
+		previousPacks[int(orderPack.PackSize)] += int(orderPack.PackQuantity)
+	}
+
+	orderPacksResult := om.calculateOrderPacks(translateToDomainModel(order, packs))
+	added, removed := orderPacksResult.OptimalOrderPack.Diff(previousPacks)
+	packsChanged := len(added) > 0 || len(removed) > 0
+	packSetChanged := !samePackSet(order.PackSet, packs)
+
+	if !dryRun && packsChanged {
+		removeParams := repository.RemoveOrderPacksByOrderParams{OrderID: order.OrderID, TenantID: tenant}
+		if removeErr := querier.RemoveOrderPacksByOrder(ctx, removeParams); removeErr != nil {
+			return domain_model.OrderRecalculation{}, false, errors.Wrap(removeErr, fmt.Sprintf("could not remove packs of order [%v]", order.OrderID))
+		}
+		if saveErr := saveEachOrderPack(ctx, querier, orderPacksResult); saveErr != nil {
+			return domain_model.OrderRecalculation{}, false, saveErr
+		}
+		shipments := splitIntoShipments(orderPacksResult.OptimalOrderPack, om.shippingConstraints)
+		if shipmentsErr := replaceOrderShipments(ctx, querier, order.OrderID, shipments); shipmentsErr != nil {
+			return domain_model.OrderRecalculation{}, false, shipmentsErr
+		}
+		orderPrice, priceErr := om.priceOrder(ctx, querier, order.Profile, int(order.OrderQuantity), orderPacksResult.OptimalOrderPack)
+		if priceErr != nil {
+			return domain_model.OrderRecalculation{}, false, errors.Wrap(priceErr, fmt.Sprintf("could not price order [%v]", order.OrderID))
+		}
+		if priceErr := replaceOrderPrice(ctx, querier, order.OrderID, orderPrice); priceErr != nil {
+			return domain_model.OrderRecalculation{}, false, priceErr
+		}
+	}
+	// Orders keep track of the pack set they are planned with, even when their packs stay the same
+	if !dryRun && packSetChanged {
+		packSetParams := repository.UpdateOrderPackSetParams{OrderID: order.OrderID, PackSet: packs, TenantID: tenant}
+		if packSetErr := querier.UpdateOrderPackSet(ctx, packSetParams); packSetErr != nil {
+			return domain_model.OrderRecalculation{}, false, errors.Wrap(packSetErr, fmt.Sprintf("could not save pack set of order [%v]", order.OrderID))
+		}
+	}
+	if !dryRun && domain_model.OrderStatus(order.Status).CanTransitionTo(domain_model.OrderStatusCalculated) {
+		if _, transitionErr := om.updateOrderStatus(ctx, querier, order, domain_model.OrderStatusCalculated); transitionErr != nil {
+			return domain_model.OrderRecalculation{}, false, transitionErr
+		}
+	}
+
+	// Orders never calculated had no packs, so nothing was wasted yet
+	quantity := int(order.OrderQuantity)
+	previousWaste := 0
+	if previousItems, _ := previousPacks.TotalItemsAndPackages(); previousItems > 0 {
+		previousWaste = previousItems - quantity
+	}
+	items, _ := orderPacksResult.OptimalOrderPack.TotalItemsAndPackages()
+	return domain_model.OrderRecalculation{
+		OrderId:       order.OrderID,
+		Quantity:      quantity,
+		PreviousWaste: previousWaste,
+		Waste:         items - quantity,
+		Added:         added,
+		Removed:       removed,
+		PackSet:       packSetToDomainModel(packs),
+	}, packsChanged, nil
+}
+
+// Whether both pack sets hold the same sizes, regardless of their order
+func samePackSet(packSet []int32, otherPackSet []int32) bool {
+	sorted, otherSorted := slices.Clone(packSet), slices.Clone(otherPackSet)
+	slices.Sort(sorted)
+	slices.Sort(otherSorted)
+	return slices.Equal(sorted, otherSorted)
+}
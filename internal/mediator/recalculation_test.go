@@ -0,0 +1,229 @@
+package mediator_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+	repository_mocks "github.com/felipevillarrealdaza/go-service-template/internal/repository/mocks"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RecalculateOrders(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	orderMediator := mediator.NewOrderMediator(
+		mediator.WithOrderRepository(repositoryMock),
+		mediator.WithOrderTransactor(newTransactorMock(t, repositoryMock)),
+	)
+	// Planned with 2 and 5 before 5 was replaced by 4
+	changedOrder := repository.Order{OrderID: uuid.New(), OrderQuantity: 8, Status: "calculated", PackSet: []int32{5, 2}, Profile: "default"}
+	unchangedOrder := repository.Order{OrderID: uuid.New(), OrderQuantity: 2, Status: "calculated", PackSet: []int32{5, 2}, Profile: "default"}
+	currentPacks := []int32{4, 2}
+
+	arrange := func() {
+		repositoryMock.On("RetrievePacks", mock.Anything, repository.RetrievePacksParams{Profile: "default", TenantID: "default"}).Return(currentPacks, nil)
+		repositoryMock.
+			On("RetrieveOrdersForRecalculation", mock.Anything, mock.MatchedBy(func(params repository.RetrieveOrdersForRecalculationParams) bool {
+				return params.AfterOrderID == uuid.Nil && params.BatchSize == 2
+			})).
+			Return([]repository.Order{changedOrder, unchangedOrder}, nil).Once()
+		repositoryMock.
+			On("RetrieveOrdersForRecalculation", mock.Anything, mock.MatchedBy(func(params repository.RetrieveOrdersForRecalculationParams) bool {
+				return params.AfterOrderID == unchangedOrder.OrderID
+			})).
+			Return([]repository.Order{}, nil).Once()
+		repositoryMock.
+			On("RetrieveOrderPacksByOrder", mock.Anything, repository.RetrieveOrderPacksByOrderParams{OrderID: changedOrder.OrderID, TenantID: "default"}).
+			Return([]repository.RetrieveOrderPacksByOrderRow{{PackSize: 5, PackQuantity: 2}}, nil)
+		repositoryMock.
+			On("RetrieveOrderPacksByOrder", mock.Anything, repository.RetrieveOrderPacksByOrderParams{OrderID: unchangedOrder.OrderID, TenantID: "default"}).
+			Return([]repository.RetrieveOrderPacksByOrderRow{{PackSize: 2, PackQuantity: 1}}, nil)
+	}
+
+	t.Run("Dry run only reports", func(t *testing.T) {
+		// Arrange
+		arrange()
+
+		// Act
+		report, recalculateErr := orderMediator.RecalculateOrders(context.Background(), domain_model.RecalculationFilter{BatchSize: 2, DryRun: true})
+
+		// Assert
+		repositoryMock.AssertExpectations(t)
+		require.NoError(t, recalculateErr)
+		require.True(t, report.DryRun)
+		require.Equal(t, 2, report.Examined)
+		require.Len(t, report.Changed, 1)
+		require.Equal(t, domain_model.OrderRecalculation{
+			OrderId:       changedOrder.OrderID,
+			Quantity:      8,
+			PreviousWaste: 2,
+			Waste:         0,
+			Added:         domain_model.OrderPack{4: 2},
+			Removed:       domain_model.OrderPack{5: 2},
+			PackSet:       []int{4, 2},
+		}, report.Changed[0])
+		require.Equal(t, 2, report.WasteSaved())
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Changed packs and pack sets are written", func(t *testing.T) {
+		// Arrange
+		arrange()
+		repositoryMock.On("RemoveOrderPacksByOrder", mock.Anything, repository.RemoveOrderPacksByOrderParams{OrderID: changedOrder.OrderID, TenantID: "default"}).Return(nil).Once()
+		repositoryMock.On("RemoveOrderShipmentsByOrder", mock.Anything, repository.RemoveOrderShipmentsByOrderParams{OrderID: changedOrder.OrderID, TenantID: "default"}).Return(nil).Once()
+		repositoryMock.On("RetrievePackPrices", mock.Anything, repository.RetrievePackPricesParams{Profile: "default", TenantID: "default"}).Return(nil, nil).Once()
+		repositoryMock.On("RemoveOrderPriceByOrder", mock.Anything, repository.RemoveOrderPriceByOrderParams{OrderID: changedOrder.OrderID, TenantID: "default"}).Return(nil).Once()
+		repositoryMock.
+			On("AddOrderPack", mock.Anything, mock.MatchedBy(func(params repository.AddOrderPackParams) bool {
+				return params.OrderID == changedOrder.OrderID
+			})).
+			Return(nil)
+		repositoryMock.On("UpdateOrderPackSet", mock.Anything, repository.UpdateOrderPackSetParams{OrderID: changedOrder.OrderID, PackSet: currentPacks, TenantID: "default"}).Return(nil).Once()
+		repositoryMock.On("UpdateOrderPackSet", mock.Anything, repository.UpdateOrderPackSetParams{OrderID: unchangedOrder.OrderID, PackSet: currentPacks, TenantID: "default"}).Return(nil).Once()
+
+		// Act
+		report, recalculateErr := orderMediator.RecalculateOrders(context.Background(), domain_model.RecalculationFilter{BatchSize: 2})
+
+		// Assert
+		repositoryMock.AssertExpectations(t)
+		require.NoError(t, recalculateErr)
+		require.Len(t, report.Changed, 1)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Backdated orders re-planned with the packs valid at their date", func(t *testing.T) {
+		// Arrange
+		orderDate := time.Date(2024, 11, 15, 0, 0, 0, 0, time.UTC)
+		backdatedOrder := repository.Order{OrderID: uuid.New(), OrderQuantity: 10, Status: "calculated", PackSet: []int32{4, 2}, Profile: "default", OrderDate: orderDate}
+		otherBackdatedOrder := repository.Order{OrderID: uuid.New(), OrderQuantity: 5, Status: "calculated", PackSet: []int32{4, 2}, Profile: "default", OrderDate: orderDate}
+		repositoryMock.On("RetrievePacks", mock.Anything, repository.RetrievePacksParams{Profile: "default", TenantID: "default"}).Return(currentPacks, nil).Once()
+		repositoryMock.On("RetrievePacks", mock.Anything, repository.RetrievePacksParams{
+			Profile:  "default",
+			TenantID: "default",
+			ValidAt:  sql.NullTime{Time: orderDate, Valid: true},
+		}).Return([]int32{5, 2}, nil).Once()
+		repositoryMock.On("RetrieveOrdersForRecalculation", mock.Anything, mock.Anything).Return([]repository.Order{backdatedOrder, otherBackdatedOrder}, nil).Once()
+		repositoryMock.On("RetrieveOrderPacksByOrder", mock.Anything, repository.RetrieveOrderPacksByOrderParams{OrderID: backdatedOrder.OrderID, TenantID: "default"}).
+			Return([]repository.RetrieveOrderPacksByOrderRow{{PackSize: 4, PackQuantity: 2}, {PackSize: 2, PackQuantity: 1}}, nil).Once()
+		repositoryMock.On("RetrieveOrderPacksByOrder", mock.Anything, repository.RetrieveOrderPacksByOrderParams{OrderID: otherBackdatedOrder.OrderID, TenantID: "default"}).
+			Return([]repository.RetrieveOrderPacksByOrderRow{{PackSize: 4, PackQuantity: 1}, {PackSize: 2, PackQuantity: 1}}, nil).Once()
+
+		// Act
+		report, recalculateErr := orderMediator.RecalculateOrders(context.Background(), domain_model.RecalculationFilter{DryRun: true})
+
+		// Assert
+		repositoryMock.AssertExpectations(t)
+		require.NoError(t, recalculateErr)
+		require.Equal(t, []int{4, 2}, report.PackSet)
+		require.Len(t, report.Changed, 2)
+		require.Equal(t, domain_model.OrderPack{5: 2}, report.Changed[0].Added)
+		require.Equal(t, []int{5, 2}, report.Changed[0].PackSet)
+		require.Equal(t, domain_model.OrderPack{5: 1}, report.Changed[1].Added)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Orders without packs valid at their date are skipped", func(t *testing.T) {
+		// Arrange
+		orderDate := time.Date(2020, 1, 6, 0, 0, 0, 0, time.UTC)
+		oldOrder := repository.Order{OrderID: uuid.New(), OrderQuantity: 10, Status: "calculated", PackSet: []int32{4, 2}, Profile: "default", OrderDate: orderDate}
+		repositoryMock.On("RetrievePacks", mock.Anything, repository.RetrievePacksParams{Profile: "default", TenantID: "default"}).Return(currentPacks, nil).Once()
+		repositoryMock.On("RetrievePacks", mock.Anything, repository.RetrievePacksParams{
+			Profile:  "default",
+			TenantID: "default",
+			ValidAt:  sql.NullTime{Time: orderDate, Valid: true},
+		}).Return([]int32{}, nil).Once()
+		repositoryMock.On("RetrieveOrdersForRecalculation", mock.Anything, mock.Anything).Return([]repository.Order{oldOrder}, nil).Once()
+
+		// Act
+		report, recalculateErr := orderMediator.RecalculateOrders(context.Background(), domain_model.RecalculationFilter{})
+
+		// Assert
+		repositoryMock.AssertExpectations(t)
+		require.NoError(t, recalculateErr)
+		require.Equal(t, 1, report.Examined)
+		require.Empty(t, report.Changed)
+		require.Equal(t, []uuid.UUID{oldOrder.OrderID}, report.Skipped)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Orders never calculated are marked as calculated", func(t *testing.T) {
+		// Arrange
+		createdOrder := repository.Order{OrderID: uuid.New(), OrderQuantity: 8, Status: "created", Profile: "default"}
+		repositoryMock.On("RetrievePacks", mock.Anything, repository.RetrievePacksParams{Profile: "default", TenantID: "default"}).Return(currentPacks, nil)
+		repositoryMock.On("RetrieveOrdersForRecalculation", mock.Anything, mock.Anything).Return([]repository.Order{createdOrder}, nil).Once()
+		repositoryMock.On("RetrieveOrderPacksByOrder", mock.Anything, mock.Anything).Return(nil, nil).Once()
+		repositoryMock.On("RemoveOrderPacksByOrder", mock.Anything, mock.Anything).Return(nil).Once()
+		repositoryMock.On("AddOrderPack", mock.Anything, mock.Anything).Return(nil)
+		repositoryMock.On("RemoveOrderShipmentsByOrder", mock.Anything, mock.Anything).Return(nil).Once()
+		repositoryMock.On("RetrievePackPrices", mock.Anything, mock.Anything).Return(nil, nil).Once()
+		repositoryMock.On("RemoveOrderPriceByOrder", mock.Anything, mock.Anything).Return(nil).Once()
+		repositoryMock.On("UpdateOrderPackSet", mock.Anything, mock.Anything).Return(nil).Once()
+		repositoryMock.On("UpdateOrderStatus", mock.Anything, repository.UpdateOrderStatusParams{
+			ToStatus:   "calculated",
+			OrderID:    createdOrder.OrderID,
+			FromStatus: "created",
+			TenantID:   "default",
+		}).Return(repository.Order{OrderID: createdOrder.OrderID, Status: "calculated"}, nil).Once()
+
+		// Act
+		report, recalculateErr := orderMediator.RecalculateOrders(context.Background(), domain_model.RecalculationFilter{})
+
+		// Assert
+		repositoryMock.AssertExpectations(t)
+		require.NoError(t, recalculateErr)
+		require.Len(t, report.Changed, 1)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Changes of a rolled back batch are not reported", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrievePacks", mock.Anything, repository.RetrievePacksParams{Profile: "default", TenantID: "default"}).Return(currentPacks, nil)
+		repositoryMock.On("RetrieveOrdersForRecalculation", mock.Anything, mock.Anything).Return([]repository.Order{changedOrder, unchangedOrder}, nil).Once()
+		repositoryMock.
+			On("RetrieveOrderPacksByOrder", mock.Anything, repository.RetrieveOrderPacksByOrderParams{OrderID: changedOrder.OrderID, TenantID: "default"}).
+			Return([]repository.RetrieveOrderPacksByOrderRow{{PackSize: 5, PackQuantity: 2}}, nil).Once()
+		repositoryMock.
+			On("RetrieveOrderPacksByOrder", mock.Anything, repository.RetrieveOrderPacksByOrderParams{OrderID: unchangedOrder.OrderID, TenantID: "default"}).
+			Return(nil, errors.New("connection reset")).Once()
+
+		// Act
+		report, recalculateErr := orderMediator.RecalculateOrders(context.Background(), domain_model.RecalculationFilter{DryRun: true})
+
+		// Assert
+		require.Error(t, recalculateErr)
+		require.Zero(t, report.Examined)
+		require.Empty(t, report.Changed)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Only open orders can be recalculated", func(t *testing.T) {
+		// Act
+		_, recalculateErr := orderMediator.RecalculateOrders(context.Background(), domain_model.RecalculationFilter{
+			Statuses: []domain_model.OrderStatus{domain_model.OrderStatusShipped},
+		})
+
+		// Assert
+		require.ErrorIs(t, recalculateErr, mediator.ErrOrderNotAmendable)
+	})
+}
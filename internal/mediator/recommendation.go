@@ -0,0 +1,233 @@
+package mediator
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+)
+
+const (
+	defaultRecommendationEvaluations = 500
+	// Order quantities added to the current sizes when no candidates are given
+	defaultFrequentQuantityCandidates = 20
+)
+
+var ErrNoOrderHistory = errors.New("no orders to learn from")
+
+// Search the candidates for the pack set that packs the order history with the least overage, then the fewest packs.
+// A greedy pick of sizes is improved by swapping, adding and removing sizes until no move helps or the evaluations
+// run out. Only the orders of the profile are learnt from.
+func (pm packMediator) RecommendPackSet(ctx context.Context, request domain_model.RecommendationRequest) (domain_model.PackSetRecommendation, error) {
+	profile := resolvePackProfile(request.Profile, pm.defaultProfile)
+	tenant := domain_model.TenantFromContext(ctx)
+	currentPacks, retrievePacksErr := pm.packRepository.RetrievePacks(ctx, repository.RetrievePacksParams{Profile: profile, TenantID: tenant})
+	if retrievePacksErr != nil {
+		return domain_model.PackSetRecommendation{}, errors.Wrap(retrievePacksErr, "could not retrieve available packs")
+	}
+	orders, retrieveOrdersErr := pm.packRepository.RetrieveOrders(ctx, tenant)
+	if retrieveOrdersErr != nil {
+		return domain_model.PackSetRecommendation{}, errors.Wrap(retrieveOrdersErr, "could not retrieve orders")
+	}
+	orders = ordersOfProfile(orders, profile)
+
+	search := newPackSetSearch(orders, request.MaxEvaluations)
+	if len(search.quantities) == 0 {
+		return domain_model.PackSetRecommendation{}, ErrNoOrderHistory
+	}
+	candidates := request.Candidates
+	if len(candidates) == 0 {
+		candidates = defaultCandidates(packSetToDomainModel(currentPacks), search.quantities)
+	}
+
+	recommendation := domain_model.PackSetRecommendation{}
+	if len(currentPacks) > 0 {
+		recommendation.Current = search.score(packSetToDomainModel(currentPacks))
+	}
+	recommended, searchErr := search.run(ctx, candidates, request.MaxSizes)
+	if searchErr != nil {
+		return domain_model.PackSetRecommendation{}, searchErr
+	}
+	recommendation.Recommended = recommended
+	recommendation.Candidates = search.candidateMetrics(candidates, recommended)
+	recommendation.Evaluations = search.evaluations
+	return recommendation, nil
+}
+
+// The current sizes along with the most frequent order quantities, which some size could fit exactly
+func defaultCandidates(currentSizes []int, quantities map[int]int) []int {
+	frequent := make([]int, 0, len(quantities))
+	for quantity := range quantities {
+		frequent = append(frequent, quantity)
+	}
+	sort.Slice(frequent, func(i, j int) bool {
+		if quantities[frequent[i]] != quantities[frequent[j]] {
+			return quantities[frequent[i]] > quantities[frequent[j]]
+		}
+		return frequent[i] < frequent[j]
+	})
+
+	candidates := slices.Clone(currentSizes)
+	for _, quantity := range frequent[:min(len(frequent), defaultFrequentQuantityCandidates)] {
+		if !slices.Contains(candidates, quantity) {
+			candidates = append(candidates, quantity)
+		}
+	}
+	return candidates
+}
+
+// Scores pack sets against the order quantities, remembering the sets already scored
+type packSetSearch struct {
+	quantities     map[int]int
+	maxQuantity    int
+	orders         int
+	scores         map[string]domain_model.PackSetScore
+	evaluations    int
+	maxEvaluations int
+}
+
+func newPackSetSearch(orders []repository.Order, maxEvaluations int) *packSetSearch {
+	if maxEvaluations <= 0 {
+		maxEvaluations = defaultRecommendationEvaluations
+	}
+	search := &packSetSearch{quantities: make(map[int]int), scores: make(map[string]domain_model.PackSetScore), maxEvaluations: maxEvaluations}
+	for _, order := range orders {
+		quantity := int(order.OrderQuantity)
+		if domain_model.OrderStatus(order.Status) == domain_model.OrderStatusCancelled || quantity <= 0 {
+			continue
+		}
+		search.quantities[quantity]++
+		search.orders++
+		search.maxQuantity = max(search.maxQuantity, quantity)
+	}
+	return search
+}
+
+func (ps *packSetSearch) exhausted() bool {
+	return ps.evaluations >= ps.maxEvaluations
+}
+
+// Pack every order quantity with the sizes, solving up to the largest quantity once
+func (ps *packSetSearch) score(sizes []int) domain_model.PackSetScore {
+	packSet := slices.Clone(sizes)
+	sort.Sort(sort.Reverse(sort.IntSlice(packSet)))
+	key := fmt.Sprint(packSet)
+	if score, scored := ps.scores[key]; scored {
+		return score
+	}
+
+	resultGrid := planUpTo(packSet, ps.maxQuantity)
+	score := domain_model.PackSetScore{PackSet: packSet, Orders: ps.orders}
+	for quantity, orders := range ps.quantities {
+		items, packs := resultGrid[quantity].TotalItemsAndPackages()
+		score.Overage += (items - quantity) * orders
+		score.Packs += packs * orders
+	}
+	ps.scores[key] = score
+	ps.evaluations++
+	return score
+}
+
+func (ps *packSetSearch) run(ctx context.Context, candidates []int, maxSizes int) (domain_model.PackSetScore, error) {
+	// Greedily add the size that helps the most, until none helps or the set is full
+	var best domain_model.PackSetScore
+	for len(best.PackSet) < maxSizes && !ps.exhausted() {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return best, errors.Wrap(ctxErr, fmt.Sprintf("recommendation interrupted after [%v] evaluations", ps.evaluations))
+		}
+		var next *domain_model.PackSetScore
+		for _, candidate := range candidates {
+			if slices.Contains(best.PackSet, candidate) || ps.exhausted() {
+				continue
+			}
+			score := ps.score(append(slices.Clone(best.PackSet), candidate))
+			if next == nil || score.Better(*next) {
+				next = &score
+			}
+		}
+		if next == nil || (len(best.PackSet) > 0 && !next.Better(best)) {
+			break
+		}
+		best = *next
+	}
+
+	// Then move to any neighbouring set that scores better, until none does
+	for improved := true; improved && !ps.exhausted(); {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return best, errors.Wrap(ctxErr, fmt.Sprintf("recommendation interrupted after [%v] evaluations", ps.evaluations))
+		}
+		improved = false
+		for _, neighbour := range neighbourPackSets(best.PackSet, candidates, maxSizes) {
+			if ps.exhausted() {
+				break
+			}
+			if score := ps.score(neighbour); score.Better(best) {
+				best, improved = score, true
+				break
+			}
+		}
+	}
+	return best, nil
+}
+
+// Sets one move away: a size swapped for a candidate, a size removed or a candidate added
+func neighbourPackSets(packSet []int, candidates []int, maxSizes int) [][]int {
+	var neighbours [][]int
+	for i := range packSet {
+		for _, candidate := range candidates {
+			if !slices.Contains(packSet, candidate) {
+				swapped := slices.Clone(packSet)
+				swapped[i] = candidate
+				neighbours = append(neighbours, swapped)
+			}
+		}
+	}
+	if len(packSet) > 1 {
+		for i := range packSet {
+			neighbours = append(neighbours, slices.Delete(slices.Clone(packSet), i, i+1))
+		}
+	}
+	if len(packSet) < maxSizes {
+		for _, candidate := range candidates {
+			if !slices.Contains(packSet, candidate) {
+				neighbours = append(neighbours, append(slices.Clone(packSet), candidate))
+			}
+		}
+	}
+	return neighbours
+}
+
+// Metrics of each candidate against the recommended set, sorted by size
+func (ps *packSetSearch) candidateMetrics(candidates []int, recommended domain_model.PackSetScore) []domain_model.CandidateMetrics {
+	resultGrid := planUpTo(recommended.PackSet, ps.maxQuantity)
+	metrics := make([]domain_model.CandidateMetrics, 0, len(candidates))
+	for _, candidate := range candidates {
+		candidateMetrics := domain_model.CandidateMetrics{Size: candidate, Selected: slices.Contains(recommended.PackSet, candidate)}
+
+		var toggled []int
+		if candidateMetrics.Selected {
+			toggled = slices.DeleteFunc(slices.Clone(recommended.PackSet), func(packSize int) bool { return packSize == candidate })
+			for quantity, orders := range ps.quantities {
+				if resultGrid[quantity][candidate] > 0 {
+					candidateMetrics.OrdersUsing += orders
+				}
+			}
+		} else {
+			toggled = append(slices.Clone(recommended.PackSet), candidate)
+		}
+		// A set needs at least one size to pack anything
+		if len(toggled) > 0 {
+			score := ps.score(toggled)
+			candidateMetrics.OverageChange = score.Overage - recommended.Overage
+			candidateMetrics.PacksChange = score.Packs - recommended.Packs
+		}
+		metrics = append(metrics, candidateMetrics)
+	}
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Size < metrics[j].Size })
+	return metrics
+}
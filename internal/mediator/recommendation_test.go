@@ -0,0 +1,91 @@
+package mediator_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+	repository_mocks "github.com/felipevillarrealdaza/go-service-template/internal/repository/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RecommendPackSet(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	packMediator := mediator.NewPackMediator(mediator.WithPackRepository(repositoryMock))
+
+	t.Run("Best pack set of the candidates", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrievePacks", mock.Anything, repository.RetrievePacksParams{Profile: "default", TenantID: "default"}).Return([]int32{500, 250}, nil).Once()
+		repositoryMock.On("RetrieveOrders", mock.Anything, "default").Return([]repository.Order{
+			{OrderQuantity: 250, Status: "shipped", Profile: "default"},
+			{OrderQuantity: 250, Status: "shipped", Profile: "default"},
+			{OrderQuantity: 250, Status: "calculated", Profile: "default"},
+			{OrderQuantity: 500, Status: "calculated", Profile: "default"},
+			{OrderQuantity: 750, Status: "picked", Profile: "default"},
+			{OrderQuantity: 750, Status: "created", Profile: "default"},
+			{OrderQuantity: 1000, Status: "cancelled", Profile: "default"},
+		}, nil).Once()
+
+		// Act
+		recommendation, recommendErr := packMediator.RecommendPackSet(context.Background(), domain_model.RecommendationRequest{
+			MaxSizes:   2,
+			Candidates: []int{250, 500, 750, 1000},
+		})
+
+		// Assert
+		require.NoError(t, recommendErr)
+		require.Equal(t, domain_model.PackSetScore{PackSet: []int{500, 250}, Orders: 6, Overage: 0, Packs: 8}, recommendation.Current)
+		require.Equal(t, domain_model.PackSetScore{PackSet: []int{750, 250}, Orders: 6, Overage: 0, Packs: 7}, recommendation.Recommended)
+		require.Equal(t, []domain_model.CandidateMetrics{
+			{Size: 250, Selected: true, OrdersUsing: 4, OverageChange: 1750, PacksChange: -1},
+			{Size: 500, PacksChange: -1},
+			{Size: 750, Selected: true, OrdersUsing: 2, PacksChange: 4},
+			{Size: 1000},
+		}, recommendation.Candidates)
+		require.Positive(t, recommendation.Evaluations)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Evaluations are capped", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrievePacks", mock.Anything, repository.RetrievePacksParams{Profile: "default", TenantID: "default"}).Return([]int32{}, nil).Once()
+		repositoryMock.On("RetrieveOrders", mock.Anything, "default").Return([]repository.Order{{OrderQuantity: 12, Status: "created", Profile: "default"}}, nil).Once()
+
+		// Act
+		recommendation, recommendErr := packMediator.RecommendPackSet(context.Background(), domain_model.RecommendationRequest{
+			MaxSizes:       3,
+			Candidates:     []int{3, 5, 7},
+			MaxEvaluations: 2,
+		})
+
+		// Assert
+		require.NoError(t, recommendErr)
+		require.NotEmpty(t, recommendation.Recommended.PackSet)
+		require.Empty(t, recommendation.Current.PackSet)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("No order history", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrievePacks", mock.Anything, repository.RetrievePacksParams{Profile: "default", TenantID: "default"}).Return([]int32{250}, nil).Once()
+		repositoryMock.On("RetrieveOrders", mock.Anything, "default").Return([]repository.Order{{OrderQuantity: 250, Status: "cancelled", Profile: "default"}}, nil).Once()
+
+		// Act
+		_, recommendErr := packMediator.RecommendPackSet(context.Background(), domain_model.RecommendationRequest{MaxSizes: 2})
+
+		// Assert
+		require.ErrorIs(t, recommendErr, mediator.ErrNoOrderHistory)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+}
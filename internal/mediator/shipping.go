@@ -0,0 +1,162 @@
+package mediator
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+)
+
+var ErrNoShippablePacks = errors.New("no pack can be shipped within the weight limits")
+
+// Orders leave out the packs too heavy to ship once filled, and are split into shipments the carrier accepts
+func WithShippingConstraints(constraints domain_model.ShippingConstraints) OrderMediatorDeps {
+	return func(mediator *orderMediator) {
+		mediator.shippingConstraints = constraints
+	}
+}
+
+// Record the maximum weight and dimensions of an existing pack size of the profile
+func (pm packMediator) UpdatePackSpecification(ctx context.Context, specification domain_model.PackSpecification) (domain_model.PackSpecification, error) {
+	params := repository.UpdatePackSpecificationParams{
+		PackSize:       int32(specification.PackSize),
+		MaxWeightGrams: positiveToNullInt32(specification.MaxWeightGrams),
+		LengthMm:       positiveToNullInt32(specification.LengthMm),
+		WidthMm:        positiveToNullInt32(specification.WidthMm),
+		HeightMm:       positiveToNullInt32(specification.HeightMm),
+		Profile:        resolvePackProfile(specification.Profile, pm.defaultProfile),
+		TenantID:       domain_model.TenantFromContext(ctx),
+	}
+	pack, updateErr := pm.packRepository.UpdatePackSpecification(ctx, params)
+	if errors.Is(updateErr, sql.ErrNoRows) {
+		return domain_model.PackSpecification{}, errors.Wrap(ErrPackNotFound, fmt.Sprintf("could not update pack of size [%v] in profile [%v]", specification.PackSize, params.Profile))
+	}
+	if updateErr != nil {
+		return domain_model.PackSpecification{}, errors.Wrap(updateErr, fmt.Sprintf("could not update pack of size [%v] in profile [%v]", specification.PackSize, params.Profile))
+	}
+	return translatePackSpecificationToDomainModel(pack), nil
+}
+
+// Retrieve the pack sizes of the profile valid at the given time, now when zero, biggest first. Once the item weight
+// is known, packs too heavy to ship are left out. Every pack set handed to the solver for an order goes through here.
+func (om orderMediator) retrieveShippablePacks(ctx context.Context, querier repository.Querier, profile string, at time.Time) ([]int32, error) {
+	tenant := domain_model.TenantFromContext(ctx)
+	if om.shippingConstraints.ItemWeightGrams == 0 {
+		return querier.RetrievePacks(ctx, repository.RetrievePacksParams{Profile: profile, TenantID: tenant, ValidAt: packsValidAt(at)})
+	}
+
+	params := repository.RetrievePackSpecificationsParams{Profile: profile, TenantID: tenant, ValidAt: packsValidAt(at)}
+	specifications, retrieveErr := querier.RetrievePackSpecifications(ctx, params)
+	if retrieveErr != nil {
+		return nil, retrieveErr
+	}
+	var packs []int32
+	for _, specification := range specifications {
+		if om.shippingConstraints.CanShip(translatePackSpecificationToDomainModel(specification)) {
+			packs = append(packs, specification.PackSize)
+		}
+	}
+	if len(specifications) > 0 && len(packs) == 0 {
+		return nil, ErrNoShippablePacks
+	}
+	return packs, nil
+}
+
+// Split the packs into shipments within the caps, biggest packs first, each going to the first shipment it fits in.
+// Balanced shipments keep that number of shipments and give each pack to the lightest one it fits in instead.
+// Without caps, every pack goes in a single shipment.
+func splitIntoShipments(packs domain_model.OrderPack, constraints domain_model.ShippingConstraints) []domain_model.Shipment {
+	if !constraints.Enabled() {
+		return nil
+	}
+
+	shipments := fillShipments(packs, constraints, 0, false)
+	if constraints.BalanceShipments && len(shipments) > 1 {
+		shipments = fillShipments(packs, constraints, len(shipments), true)
+	}
+	return shipments
+}
+
+// Place every pack in the starting shipments, opening a new one when it fits in none
+func fillShipments(packs domain_model.OrderPack, constraints domain_model.ShippingConstraints, starting int, lightestFirst bool) []domain_model.Shipment {
+	shipments := make([]domain_model.Shipment, starting)
+	for i := range shipments {
+		shipments[i] = domain_model.Shipment{Packs: make(domain_model.OrderPack), ItemWeightGrams: constraints.ItemWeightGrams}
+	}
+	items := make([]int, starting)
+
+	for _, pack := range packSizesBiggestFirst(packs) {
+		for i := 0; i < packs[pack]; i++ {
+			chosen := -1
+			for j := range shipments {
+				if !constraints.Fits(shipments[j], pack) {
+					continue
+				}
+				if chosen == -1 || lightestFirst && items[j] < items[chosen] {
+					chosen = j
+				}
+				if !lightestFirst {
+					break
+				}
+			}
+			if chosen == -1 {
+				shipments = append(shipments, domain_model.Shipment{Packs: make(domain_model.OrderPack), ItemWeightGrams: constraints.ItemWeightGrams})
+				items = append(items, 0)
+				chosen = len(shipments) - 1
+			}
+			shipments[chosen].Packs[pack]++
+			items[chosen] += pack
+		}
+	}
+	return shipments
+}
+
+// Replace the shipments saved for the order by the given ones, numbered from 1
+func replaceOrderShipments(ctx context.Context, querier repository.Querier, orderId uuid.UUID, shipments []domain_model.Shipment) error {
+	params := repository.RemoveOrderShipmentsByOrderParams{OrderID: orderId, TenantID: domain_model.TenantFromContext(ctx)}
+	if removeErr := querier.RemoveOrderShipmentsByOrder(ctx, params); removeErr != nil {
+		return errors.Wrap(removeErr, fmt.Sprintf("could not remove shipments of order [%v]", orderId))
+	}
+	return saveEachOrderShipment(ctx, querier, orderId, shipments)
+}
+
+// Save each pack size of each shipment in the database
+func saveEachOrderShipment(ctx context.Context, querier repository.Querier, orderId uuid.UUID, shipments []domain_model.Shipment) error {
+	for i, shipment := range shipments {
+		for packSize, packQuantity := range shipment.Packs {
+			params := repository.AddOrderShipmentParams{
+				OrderID:        orderId,
+				ShipmentNumber: int32(i + 1),
+				PackSize:       int32(packSize),
+				PackQuantity:   int32(packQuantity),
+				TenantID:       domain_model.TenantFromContext(ctx),
+			}
+			if addErr := querier.AddOrderShipment(ctx, params); addErr != nil {
+				return errors.Wrap(addErr, fmt.Sprintf("could not save shipment [%v] of order [%v]", params.ShipmentNumber, orderId))
+			}
+		}
+	}
+	return nil
+}
+
+// Translate from repository models to domain models
+func translatePackSpecificationToDomainModel(pack repository.Pack) domain_model.PackSpecification {
+	return domain_model.PackSpecification{
+		PackSize:       int(pack.PackSize),
+		MaxWeightGrams: int(pack.MaxWeightGrams.Int32),
+		LengthMm:       int(pack.LengthMm.Int32),
+		WidthMm:        int(pack.WidthMm.Int32),
+		HeightMm:       int(pack.HeightMm.Int32),
+		Profile:        pack.Profile,
+	}
+}
+
+func positiveToNullInt32(value int) sql.NullInt32 {
+	return sql.NullInt32{Int32: int32(value), Valid: value > 0}
+}
@@ -0,0 +1,247 @@
+package mediator_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+	repository_mocks "github.com/felipevillarrealdaza/go-service-template/internal/repository/mocks"
+	"github.com/google/uuid"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UpdatePackSpecification(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	packMediator := mediator.NewPackMediator(mediator.WithPackRepository(repositoryMock))
+
+	t.Run("Updated", func(t *testing.T) {
+		// Arrange
+		params := repository.UpdatePackSpecificationParams{PackSize: 500, MaxWeightGrams: sql.NullInt32{Int32: 15000, Valid: true}, LengthMm: sql.NullInt32{Int32: 400, Valid: true}, Profile: "default", TenantID: "default"}
+		repositoryMock.On("UpdatePackSpecification", mock.Anything, params).
+			Return(repository.Pack{PackSize: 500, MaxWeightGrams: params.MaxWeightGrams, LengthMm: params.LengthMm}, nil).Once()
+
+		// Act
+		updated, updateErr := packMediator.UpdatePackSpecification(context.Background(), domain_model.PackSpecification{PackSize: 500, MaxWeightGrams: 15000, LengthMm: 400})
+
+		// Assert
+		require.NoError(t, updateErr)
+		require.Equal(t, domain_model.PackSpecification{PackSize: 500, MaxWeightGrams: 15000, LengthMm: 400}, updated)
+	})
+
+	t.Run("Pack not found", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("UpdatePackSpecification", mock.Anything, mock.Anything).Return(repository.Pack{}, sql.ErrNoRows).Once()
+
+		// Act
+		_, updateErr := packMediator.UpdatePackSpecification(context.Background(), domain_model.PackSpecification{PackSize: 750})
+
+		// Assert
+		require.ErrorIs(t, updateErr, mediator.ErrPackNotFound)
+	})
+}
+
+func Test_CalculateOrderPacks_Shipping(t *testing.T) {
+	// Set Up
+	specifications := []repository.Pack{
+		{PackSize: 5000},
+		{PackSize: 2000, MaxWeightGrams: sql.NullInt32{Int32: 15000, Valid: true}},
+		{PackSize: 1000},
+		{PackSize: 250},
+	}
+	useCases := []struct {
+		name              string
+		constraints       domain_model.ShippingConstraints
+		quantity          int32
+		expectedPacks     domain_model.OrderPack
+		expectedShipments []domain_model.Shipment
+	}{
+		{
+			name:          "Heavy packs left out",
+			constraints:   domain_model.ShippingConstraints{ItemWeightGrams: 10, MaxParcelWeightGrams: 30000},
+			quantity:      12000,
+			expectedPacks: domain_model.OrderPack{1000: 12, 250: 0},
+			expectedShipments: []domain_model.Shipment{
+				{Packs: domain_model.OrderPack{1000: 12}, ItemWeightGrams: 10},
+			},
+		},
+		{
+			name:          "Split by parcels per shipment",
+			constraints:   domain_model.ShippingConstraints{ItemWeightGrams: 10, MaxParcelWeightGrams: 30000, MaxParcelsPerShipment: 5},
+			quantity:      12250,
+			expectedPacks: domain_model.OrderPack{1000: 12, 250: 1},
+			expectedShipments: []domain_model.Shipment{
+				{Packs: domain_model.OrderPack{1000: 5}, ItemWeightGrams: 10},
+				{Packs: domain_model.OrderPack{1000: 5}, ItemWeightGrams: 10},
+				{Packs: domain_model.OrderPack{1000: 2, 250: 1}, ItemWeightGrams: 10},
+			},
+		},
+		{
+			name:          "Split by items per shipment",
+			constraints:   domain_model.ShippingConstraints{MaxItemsPerShipment: 5000},
+			quantity:      12250,
+			expectedPacks: domain_model.OrderPack{5000: 2, 2000: 1, 1000: 0, 250: 1},
+			expectedShipments: []domain_model.Shipment{
+				{Packs: domain_model.OrderPack{5000: 1}},
+				{Packs: domain_model.OrderPack{5000: 1}},
+				{Packs: domain_model.OrderPack{2000: 1, 250: 1}},
+			},
+		},
+		{
+			name:          "Balanced shipments",
+			constraints:   domain_model.ShippingConstraints{MaxParcelsPerShipment: 3, BalanceShipments: true},
+			quantity:      12250,
+			expectedPacks: domain_model.OrderPack{5000: 2, 2000: 1, 1000: 0, 250: 1},
+			expectedShipments: []domain_model.Shipment{
+				{Packs: domain_model.OrderPack{5000: 1, 2000: 1}},
+				{Packs: domain_model.OrderPack{5000: 1, 250: 1}},
+			},
+		},
+		{
+			name:          "Pack maximum weight without a carrier limit",
+			constraints:   domain_model.ShippingConstraints{ItemWeightGrams: 10},
+			quantity:      12000,
+			expectedPacks: domain_model.OrderPack{5000: 2, 1000: 2, 250: 0},
+			expectedShipments: []domain_model.Shipment{
+				{Packs: domain_model.OrderPack{5000: 2, 1000: 2}, ItemWeightGrams: 10},
+			},
+		},
+	}
+
+	for _, useCase := range useCases {
+		t.Run(useCase.name, func(t *testing.T) {
+			// Arrange
+			repositoryMock := repository_mocks.NewQuerier(t)
+			orderMediator := mediator.NewOrderMediator(mediator.WithOrderRepository(repositoryMock), mediator.WithOrderTransactor(newTransactorMock(t, repositoryMock)), mediator.WithShippingConstraints(useCase.constraints))
+			orderId := uuid.New()
+			repositoryMock.On("RetrieveOrderById", mock.Anything, repository.RetrieveOrderByIdParams{OrderID: orderId, TenantID: "default"}).Return(repository.Order{OrderID: orderId, OrderQuantity: useCase.quantity, Status: "created", Profile: "default"}, nil)
+			repositoryMock.On("RetrievePackSpecifications", mock.Anything, repository.RetrievePackSpecificationsParams{Profile: "default", TenantID: "default"}).Return(specifications, nil).Maybe()
+			repositoryMock.On("RetrievePacks", mock.Anything, repository.RetrievePacksParams{Profile: "default", TenantID: "default"}).Return([]int32{5000, 2000, 1000, 250}, nil).Maybe()
+			repositoryMock.On("RetrieveContainerTypes", mock.Anything, "default").Return(nil, nil)
+			repositoryMock.On("RetrievePackPrices", mock.Anything, repository.RetrievePackPricesParams{Profile: "default", TenantID: "default"}).Return(nil, nil)
+			repositoryMock.On("AddOrderPack", mock.Anything, mock.Anything).Return(nil)
+			savedShipments := make(map[int32]domain_model.OrderPack)
+			repositoryMock.On("AddOrderShipment", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				params := args.Get(1).(repository.AddOrderShipmentParams)
+				require.Equal(t, orderId, params.OrderID)
+				if savedShipments[params.ShipmentNumber] == nil {
+					savedShipments[params.ShipmentNumber] = make(domain_model.OrderPack)
+				}
+				savedShipments[params.ShipmentNumber][int(params.PackSize)] = int(params.PackQuantity)
+			})
+			repositoryMock.On("UpdateOrderPackSet", mock.Anything, mock.Anything).Return(nil)
+			repositoryMock.On("UpdateOrderStatus", mock.Anything, mock.Anything).Return(repository.Order{OrderID: orderId, Status: "calculated"}, nil)
+
+			// Act
+			orderPacks, calculationErr := orderMediator.CalculateOrderPacks(context.Background(), orderId)
+
+			// Assert
+			require.NoError(t, calculationErr)
+			require.Equal(t, useCase.expectedPacks, orderPacks.OptimalOrderPack)
+			require.Equal(t, useCase.expectedShipments, orderPacks.Shipments)
+			require.Len(t, savedShipments, len(useCase.expectedShipments))
+			for i, shipment := range useCase.expectedShipments {
+				require.Equal(t, shipment.Packs, savedShipments[int32(i+1)])
+			}
+		})
+	}
+
+	t.Run("No pack light enough", func(t *testing.T) {
+		// Arrange
+		repositoryMock := repository_mocks.NewQuerier(t)
+		orderMediator := mediator.NewOrderMediator(
+			mediator.WithOrderRepository(repositoryMock),
+			mediator.WithShippingConstraints(domain_model.ShippingConstraints{ItemWeightGrams: 100, MaxParcelWeightGrams: 20000}),
+		)
+		orderId := uuid.New()
+		repositoryMock.On("RetrieveOrderById", mock.Anything, repository.RetrieveOrderByIdParams{OrderID: orderId, TenantID: "default"}).Return(repository.Order{OrderID: orderId, OrderQuantity: 500, Status: "created", Profile: "default"}, nil)
+		repositoryMock.On("RetrievePackSpecifications", mock.Anything, repository.RetrievePackSpecificationsParams{Profile: "default", TenantID: "default"}).Return(specifications, nil)
+
+		// Act
+		_, calculationErr := orderMediator.CalculateOrderPacks(context.Background(), orderId)
+
+		// Assert
+		require.ErrorIs(t, calculationErr, mediator.ErrNoShippablePacks)
+	})
+}
+
+func Test_Shipment(t *testing.T) {
+	// Arrange
+	shipment := domain_model.Shipment{Packs: domain_model.OrderPack{1000: 2, 250: 1}, ItemWeightGrams: 10}
+
+	// Act
+	response := shipment.ToViewModel()
+
+	// Assert
+	require.Equal(t, 3, response.ParcelCount)
+	require.Equal(t, 22500, response.WeightGrams)
+	require.Len(t, response.Packs, 2)
+}
+
+func Test_AmendOrderQuantity_Shipments(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	orderMediator := mediator.NewOrderMediator(
+		mediator.WithOrderRepository(repositoryMock),
+		mediator.WithOrderTransactor(newTransactorMock(t, repositoryMock)),
+		mediator.WithShippingConstraints(domain_model.ShippingConstraints{MaxItemsPerShipment: 2000, BalanceShipments: true}),
+	)
+	orderId := uuid.New()
+
+	// Arrange
+	repositoryMock.On("RetrieveOrderById", mock.Anything, repository.RetrieveOrderByIdParams{OrderID: orderId, TenantID: "default"}).
+		Return(repository.Order{OrderID: orderId, OrderQuantity: 1000, Status: "calculated", PackSet: []int32{1000, 500}, Profile: "default"}, nil)
+	repositoryMock.On("RetrieveOrderPacksByOrder", mock.Anything, repository.RetrieveOrderPacksByOrderParams{OrderID: orderId, TenantID: "default"}).Return([]repository.RetrieveOrderPacksByOrderRow{{PackSize: 1000, PackQuantity: 1}}, nil)
+	repositoryMock.On("RemoveOrderPacksByOrder", mock.Anything, repository.RemoveOrderPacksByOrderParams{OrderID: orderId, TenantID: "default"}).Return(nil)
+	repositoryMock.On("AddOrderPack", mock.Anything, mock.Anything).Return(nil)
+	repositoryMock.On("RemoveOrderShipmentsByOrder", mock.Anything, repository.RemoveOrderShipmentsByOrderParams{OrderID: orderId, TenantID: "default"}).Return(nil).Once()
+	repositoryMock.On("RetrievePackPrices", mock.Anything, repository.RetrievePackPricesParams{Profile: "default", TenantID: "default"}).Return(nil, nil).Once()
+	repositoryMock.On("RemoveOrderPriceByOrder", mock.Anything, repository.RemoveOrderPriceByOrderParams{OrderID: orderId, TenantID: "default"}).Return(nil).Once()
+	repositoryMock.On("AddOrderShipment", mock.Anything, repository.AddOrderShipmentParams{OrderID: orderId, ShipmentNumber: 1, PackSize: 1000, PackQuantity: 2, TenantID: "default"}).Return(nil).Once()
+	repositoryMock.On("AddOrderShipment", mock.Anything, repository.AddOrderShipmentParams{OrderID: orderId, ShipmentNumber: 2, PackSize: 1000, PackQuantity: 1, TenantID: "default"}).Return(nil).Once()
+	repositoryMock.On("AddOrderShipment", mock.Anything, repository.AddOrderShipmentParams{OrderID: orderId, ShipmentNumber: 2, PackSize: 500, PackQuantity: 1, TenantID: "default"}).Return(nil).Once()
+	repositoryMock.On("UpdateOrderQuantity", mock.Anything, mock.Anything).Return(repository.Order{OrderID: orderId, OrderQuantity: 3500, Status: "calculated", Profile: "default"}, nil)
+
+	// Act
+	amendment, amendErr := orderMediator.AmendOrderQuantity(context.Background(), orderId, 3500)
+
+	// Assert
+	require.NoError(t, amendErr)
+	require.Equal(t, []domain_model.Shipment{
+		{Packs: domain_model.OrderPack{1000: 2}},
+		{Packs: domain_model.OrderPack{1000: 1, 500: 1}},
+	}, amendment.Shipments)
+}
+
+func Test_RecalculateOrders_Shipping(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	orderMediator := mediator.NewOrderMediator(
+		mediator.WithOrderRepository(repositoryMock),
+		mediator.WithOrderTransactor(newTransactorMock(t, repositoryMock)),
+		mediator.WithShippingConstraints(domain_model.ShippingConstraints{ItemWeightGrams: 10, MaxParcelWeightGrams: 30000}),
+	)
+	order := repository.Order{OrderID: uuid.New(), OrderQuantity: 5000, Status: "calculated", PackSet: []int32{5000, 1000}, Profile: "default"}
+
+	// Arrange
+	repositoryMock.On("RetrievePackSpecifications", mock.Anything, repository.RetrievePackSpecificationsParams{Profile: "default", TenantID: "default"}).
+		Return([]repository.Pack{{PackSize: 5000}, {PackSize: 1000}}, nil).Twice()
+	repositoryMock.On("RetrieveOrdersForRecalculation", mock.Anything, mock.Anything).Return([]repository.Order{order}, nil).Once()
+	repositoryMock.On("RetrieveOrderPacksByOrder", mock.Anything, repository.RetrieveOrderPacksByOrderParams{OrderID: order.OrderID, TenantID: "default"}).
+		Return([]repository.RetrieveOrderPacksByOrderRow{{PackSize: 5000, PackQuantity: 1}}, nil).Once()
+
+	// Act
+	report, recalculateErr := orderMediator.RecalculateOrders(context.Background(), domain_model.RecalculationFilter{DryRun: true})
+
+	// Assert
+	require.NoError(t, recalculateErr)
+	require.Equal(t, []int{1000}, report.PackSet)
+	require.Len(t, report.Changed, 1)
+	require.Equal(t, domain_model.OrderPack{1000: 5}, report.Changed[0].Added)
+	require.Equal(t, domain_model.OrderPack{5000: 1}, report.Changed[0].Removed)
+}
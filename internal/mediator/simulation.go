@@ -0,0 +1,91 @@
+package mediator
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+)
+
+var ErrPackCostMissing = errors.New("pack cost missing")
+
+// Replay every order of the profile that was not cancelled with its current and the proposed pack sets, without
+// writing anything
+func (pm packMediator) SimulatePackSet(ctx context.Context, simulation domain_model.PackSetSimulation) (domain_model.SimulationReport, error) {
+	profile := resolvePackProfile(simulation.Profile, pm.defaultProfile)
+	tenant := domain_model.TenantFromContext(ctx)
+	currentPacks, retrievePacksErr := pm.packRepository.RetrievePacks(ctx, repository.RetrievePacksParams{Profile: profile, TenantID: tenant})
+	if retrievePacksErr != nil {
+		return domain_model.SimulationReport{}, errors.Wrap(retrievePacksErr, "could not retrieve available packs")
+	}
+	// Planned with the sizes ordered like the current set, so ties are broken the same way
+	proposedPacks := make([]int32, 0, len(simulation.PackSizes))
+	for _, packSize := range simulation.PackSizes {
+		proposedPacks = append(proposedPacks, int32(packSize))
+	}
+	slices.Sort(proposedPacks)
+	slices.Reverse(proposedPacks)
+
+	if simulation.Costed() {
+		for _, packSize := range append(slices.Clone(currentPacks), proposedPacks...) {
+			if _, priced := simulation.PackCosts[int(packSize)]; !priced {
+				return domain_model.SimulationReport{}, errors.Wrap(ErrPackCostMissing, fmt.Sprintf("no cost given for packs of size [%v]", packSize))
+			}
+		}
+	}
+
+	orders, retrieveOrdersErr := pm.packRepository.RetrieveOrders(ctx, tenant)
+	if retrieveOrdersErr != nil {
+		return domain_model.SimulationReport{}, errors.Wrap(retrieveOrdersErr, "could not retrieve orders")
+	}
+	orders = ordersOfProfile(orders, profile)
+
+	report := domain_model.SimulationReport{
+		Costed:   simulation.Costed(),
+		Current:  domain_model.PackSetOutcome{PackSet: packSetToDomainModel(currentPacks)},
+		Proposed: domain_model.PackSetOutcome{PackSet: packSetToDomainModel(proposedPacks)},
+	}
+	currentPlanner, proposedPlanner := newPackPlanner(currentPacks), newPackPlanner(proposedPacks)
+	for _, order := range orders {
+		if domain_model.OrderStatus(order.Status) == domain_model.OrderStatusCancelled {
+			continue
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return domain_model.SimulationReport{}, errors.Wrap(ctxErr, fmt.Sprintf("simulation interrupted after [%v] orders", report.Orders))
+		}
+
+		quantity := int(order.OrderQuantity)
+		currentOrderPack, proposedOrderPack := currentPlanner.plan(quantity), proposedPlanner.plan(quantity)
+		report.Current.Add(quantity, currentOrderPack, simulation.PackCosts)
+		report.Proposed.Add(quantity, proposedOrderPack, simulation.PackCosts)
+		report.Orders++
+		if added, removed := proposedOrderPack.Diff(currentOrderPack); len(added) > 0 || len(removed) > 0 {
+			report.ChangedOrders++
+		}
+	}
+	return report, nil
+}
+
+// Plans quantities with a pack set, remembering the packs of the quantities already planned since order histories
+// repeat the same quantities often
+type packPlanner struct {
+	packs   []int32
+	planned map[int]domain_model.OrderPack
+}
+
+func newPackPlanner(packs []int32) packPlanner {
+	return packPlanner{packs: packs, planned: make(map[int]domain_model.OrderPack)}
+}
+
+func (pp packPlanner) plan(quantity int) domain_model.OrderPack {
+	if orderPack, planned := pp.planned[quantity]; planned {
+		return orderPack
+	}
+	orderPacks := calculateOrderPacks(translateToDomainModel(repository.Order{OrderQuantity: int32(quantity)}, pp.packs))
+	pp.planned[quantity] = orderPacks.OptimalOrderPack
+	return orderPacks.OptimalOrderPack
+}
@@ -0,0 +1,67 @@
+package mediator_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+	repository_mocks "github.com/felipevillarrealdaza/go-service-template/internal/repository/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SimulatePackSet(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	packMediator := mediator.NewPackMediator(mediator.WithPackRepository(repositoryMock))
+	orders := []repository.Order{
+		{OrderQuantity: 7, Status: "shipped", Profile: "default"},
+		{OrderQuantity: 5, Status: "calculated", Profile: "default"},
+		{OrderQuantity: 12, Status: "cancelled", Profile: "default"},
+	}
+
+	t.Run("Current and proposed sets with costs", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrievePacks", mock.Anything, repository.RetrievePacksParams{Profile: "default", TenantID: "default"}).Return([]int32{5}, nil).Once()
+		repositoryMock.On("RetrieveOrders", mock.Anything, "default").Return(orders, nil).Once()
+
+		// Act
+		report, simulateErr := packMediator.SimulatePackSet(context.Background(), domain_model.PackSetSimulation{
+			PackSizes: []int{2, 5},
+			PackCosts: map[int]float64{2: 0.25, 5: 1},
+		})
+
+		// Assert
+		require.NoError(t, simulateErr)
+		require.Equal(t, 2, report.Orders)
+		require.Equal(t, 1, report.ChangedOrders)
+		require.Equal(t, domain_model.PackSetOutcome{PackSet: []int{5}, ItemsOrdered: 12, ItemsShipped: 15, Packs: 3, Cost: 3}, report.Current)
+		require.Equal(t, domain_model.PackSetOutcome{PackSet: []int{5, 2}, ItemsOrdered: 12, ItemsShipped: 12, Packs: 3, Cost: 2.25}, report.Proposed)
+		response := report.ToViewModel()
+		require.Equal(t, -3, response.Difference.Overage)
+		require.Equal(t, -0.75, *response.Difference.Cost)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Costs must price every size", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrievePacks", mock.Anything, repository.RetrievePacksParams{Profile: "default", TenantID: "default"}).Return([]int32{5}, nil).Once()
+
+		// Act
+		_, simulateErr := packMediator.SimulatePackSet(context.Background(), domain_model.PackSetSimulation{
+			PackSizes: []int{2},
+			PackCosts: map[int]float64{2: 0.25},
+		})
+
+		// Assert
+		require.ErrorIs(t, simulateErr, mediator.ErrPackCostMissing)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+}
@@ -0,0 +1,200 @@
+package mediator
+
+import (
+	"maps"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+)
+
+// Rough size of one solved quantity, the grid entry plus a map holding a count per pack size
+const (
+	solverEntryBytes = 256
+	solverPackBytes  = 16
+)
+
+// SolverCache keeps the solved grid of each pack set, from 1 up to the biggest quantity calculated so far, and
+// extends it on demand. It is safe for concurrent use: lookups of solved quantities share a read lock, and a
+// single goroutine extends a grid while the others wait for it.
+//
+// Grids are keyed by the pack sizes in the order they are tried, so a cached result is always the one an uncached
+// calculation would give and a stale grid is never used for another pack set. The cache is bounded by a memory
+// budget, evicting the least recently used grids first, and quantities whose grid does not fit are calculated
+// without caching.
+type SolverCache struct {
+	mutex        sync.Mutex
+	tables       map[string]*solverTable
+	memoryBudget int
+	usedMemory   int
+	clock        uint64
+}
+
+// Solved grid of a pack set. solvedUpTo is guarded by the table mutex, reservedUpTo and lastUsed by the cache mutex.
+type solverTable struct {
+	mutex        sync.RWMutex
+	orderPacks   domain_model.OrderPacks
+	solvedUpTo   int
+	reservedUpTo int
+	lastUsed     uint64
+}
+
+// Memory taken by the cached grids
+type SolverCacheUsage struct {
+	PackSets   int
+	Quantities int
+	Bytes      int
+}
+
+// Create a cache holding up to memoryBudget bytes of solved grids
+func NewSolverCache(memoryBudget int) *SolverCache {
+	return &SolverCache{tables: make(map[string]*solverTable), memoryBudget: memoryBudget}
+}
+
+// Calculate the optimal way to package the order quantity, reusing and extending the cached grid of its pack set.
+// Only the result for the order quantity is set in the returned grid.
+func (sc *SolverCache) CalculateOrderPacks(orderPacks domain_model.OrderPacks) domain_model.OrderPacks {
+	if len(orderPacks.AvailablePacks) == 0 || orderPacks.OrderQuantity <= 0 {
+		return calculateOrderPacks(orderPacks)
+	}
+
+	table := sc.table(orderPacks.AvailablePacks)
+	optimalOrderPack, solved := table.lookup(orderPacks.OrderQuantity)
+	if !solved {
+		if !sc.reserve(table, orderPacks.OrderQuantity) {
+			return calculateOrderPacks(orderPacks)
+		}
+		optimalOrderPack = table.extend(orderPacks.OrderQuantity)
+	}
+
+	orderPacks.UseAsOptimalSolution(optimalOrderPack)
+	orderPacks.ResultGrid[orderPacks.OrderQuantity] = optimalOrderPack
+	return orderPacks
+}
+
+// Drop every cached grid, used when the pack set changes so grids of pack sets no longer in use are not kept
+func (sc *SolverCache) Invalidate() {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	sc.tables = make(map[string]*solverTable)
+	sc.usedMemory = 0
+}
+
+func (sc *SolverCache) Usage() SolverCacheUsage {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	usage := SolverCacheUsage{PackSets: len(sc.tables), Bytes: sc.usedMemory}
+	for _, table := range sc.tables {
+		usage.Quantities += table.reservedUpTo
+	}
+	return usage
+}
+
+// Retrieve the grid of the pack set, creating an empty one the first time it is used
+func (sc *SolverCache) table(packs domain_model.AvailablePacks) *solverTable {
+	key := packSetKey(packs)
+
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	sc.clock++
+	table, found := sc.tables[key]
+	if !found {
+		orderPacks := translateToDomainModel(repository.Order{}, nil)
+		orderPacks.AvailablePacks = append(domain_model.AvailablePacks{}, packs...)
+		table = &solverTable{orderPacks: orderPacks}
+		sc.tables[key] = table
+	}
+	table.lastUsed = sc.clock
+	return table
+}
+
+// Account the memory needed to extend the grid up to quantity, evicting the least recently used grids when over
+// budget. It reports false when the grid cannot grow, because it was dropped meanwhile or does not fit in the budget.
+func (sc *SolverCache) reserve(table *solverTable, quantity int) bool {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	if sc.tables[packSetKey(table.orderPacks.AvailablePacks)] != table {
+		return false
+	}
+	if quantity <= table.reservedUpTo {
+		return true
+	}
+
+	// Grids bigger than the whole budget are not cached, rather than evicting every other grid for nothing
+	if quantity*table.entryBytes() > sc.memoryBudget {
+		if table.reservedUpTo == 0 {
+			delete(sc.tables, packSetKey(table.orderPacks.AvailablePacks))
+		}
+		return false
+	}
+
+	neededMemory := (quantity - table.reservedUpTo) * table.entryBytes()
+	for sc.usedMemory+neededMemory > sc.memoryBudget {
+		if !sc.evictLeastRecentlyUsed(table) {
+			return false
+		}
+	}
+	sc.usedMemory += neededMemory
+	table.reservedUpTo = quantity
+	return true
+}
+
+// Evict the least recently used grid other than the one being extended, the cache mutex must be held
+func (sc *SolverCache) evictLeastRecentlyUsed(keep *solverTable) bool {
+	var evictedKey string
+	var evicted *solverTable
+	for key, table := range sc.tables {
+		if table != keep && (evicted == nil || table.lastUsed < evicted.lastUsed) {
+			evictedKey, evicted = key, table
+		}
+	}
+	if evicted == nil {
+		return false
+	}
+	delete(sc.tables, evictedKey)
+	sc.usedMemory -= evicted.reservedUpTo * evicted.entryBytes()
+	return true
+}
+
+func (st *solverTable) entryBytes() int {
+	return solverEntryBytes + solverPackBytes*len(st.orderPacks.AvailablePacks)
+}
+
+// Copy of the solution for quantity, when already solved
+func (st *solverTable) lookup(quantity int) (domain_model.OrderPack, bool) {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+
+	if quantity > st.solvedUpTo {
+		return nil, false
+	}
+	return maps.Clone(st.orderPacks.ResultGrid[quantity]), true
+}
+
+// Solve the quantities up to quantity not solved yet and return a copy of the solution for quantity
+func (st *solverTable) extend(quantity int) domain_model.OrderPack {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	for gridIndex := st.solvedUpTo + 1; gridIndex <= quantity; gridIndex++ {
+		solveQuantity(&st.orderPacks, gridIndex)
+	}
+	if quantity > st.solvedUpTo {
+		st.solvedUpTo = quantity
+	}
+	return maps.Clone(st.orderPacks.ResultGrid[quantity])
+}
+
+func packSetKey(packs domain_model.AvailablePacks) string {
+	sizes := make([]string, 0, len(packs))
+	for _, packSize := range packs {
+		sizes = append(sizes, strconv.Itoa(packSize))
+	}
+	return strings.Join(sizes, ",")
+}
@@ -0,0 +1,175 @@
+package mediator_test
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newOrderPacks(quantity int, packs ...int) domain_model.OrderPacks {
+	return domain_model.OrderPacks{
+		OrderQuantity:    quantity,
+		AvailablePacks:   packs,
+		ResultGrid:       make(map[int]domain_model.OrderPack),
+		BestItemQuantity: math.MaxInt32,
+		BestPackQuantity: math.MaxInt32,
+	}
+}
+
+func Test_SolverCache_MatchesUncachedCalculation(t *testing.T) {
+	// Set Up
+	solverCache := mediator.NewSolverCache(1 << 20)
+	uncached := mediator.NewSolverCache(0)
+
+	// Arrange
+	quantities := []int{1, 250, 251, 100, 1200, 999, 23, 1201, 500}
+
+	for _, quantity := range quantities {
+		// Act
+		cachedResult := solverCache.CalculateOrderPacks(newOrderPacks(quantity, 53, 31, 23))
+		uncachedResult := uncached.CalculateOrderPacks(newOrderPacks(quantity, 53, 31, 23))
+
+		// Assert
+		require.Equal(t, uncachedResult.OptimalOrderPack, cachedResult.OptimalOrderPack, "quantity [%v]", quantity)
+		require.Equal(t, uncachedResult.OptimalOrderPack, cachedResult.ResultGrid[quantity])
+		require.Equal(t, uncachedResult.BestItemQuantity, cachedResult.BestItemQuantity)
+		require.Equal(t, uncachedResult.BestPackQuantity, cachedResult.BestPackQuantity)
+	}
+	require.Equal(t, mediator.SolverCacheUsage{}, uncached.Usage())
+}
+
+func Test_SolverCache_ExtendsOnDemand(t *testing.T) {
+	// Set Up
+	solverCache := mediator.NewSolverCache(1 << 20)
+
+	t.Run("Grid is solved up to the quantity", func(t *testing.T) {
+		// Act
+		solverCache.CalculateOrderPacks(newOrderPacks(100, 500, 250))
+
+		// Assert
+		require.Equal(t, mediator.SolverCacheUsage{PackSets: 1, Quantities: 100, Bytes: 100 * 288}, solverCache.Usage())
+	})
+
+	t.Run("Smaller quantities reuse the grid", func(t *testing.T) {
+		// Act
+		orderPacks := solverCache.CalculateOrderPacks(newOrderPacks(40, 500, 250))
+
+		// Assert
+		require.Equal(t, domain_model.OrderPack{500: 0, 250: 1}, orderPacks.OptimalOrderPack)
+		require.Equal(t, 100, solverCache.Usage().Quantities)
+	})
+
+	t.Run("Bigger quantities extend the grid", func(t *testing.T) {
+		// Act
+		orderPacks := solverCache.CalculateOrderPacks(newOrderPacks(501, 500, 250))
+
+		// Assert
+		require.Equal(t, domain_model.OrderPack{500: 1, 250: 1}, orderPacks.OptimalOrderPack)
+		require.Equal(t, 501, solverCache.Usage().Quantities)
+	})
+
+	t.Run("Results are copies of the cached solution", func(t *testing.T) {
+		// Arrange
+		orderPacks := solverCache.CalculateOrderPacks(newOrderPacks(40, 500, 250))
+		orderPacks.OptimalOrderPack[250] = 10
+
+		// Act
+		orderPacks = solverCache.CalculateOrderPacks(newOrderPacks(40, 500, 250))
+
+		// Assert
+		require.Equal(t, domain_model.OrderPack{500: 0, 250: 1}, orderPacks.OptimalOrderPack)
+	})
+
+	t.Run("Each pack set has its own grid", func(t *testing.T) {
+		// Act
+		orderPacks := solverCache.CalculateOrderPacks(newOrderPacks(40, 500, 250, 10))
+
+		// Assert
+		require.Equal(t, domain_model.OrderPack{500: 0, 250: 0, 10: 4}, orderPacks.OptimalOrderPack)
+		require.Equal(t, 2, solverCache.Usage().PackSets)
+	})
+
+	t.Run("Invalidating drops every grid", func(t *testing.T) {
+		// Act
+		solverCache.Invalidate()
+
+		// Assert
+		require.Equal(t, mediator.SolverCacheUsage{}, solverCache.Usage())
+	})
+}
+
+func Test_SolverCache_MemoryBudget(t *testing.T) {
+	// Set Up, room for 1000 quantities of a set of 2 packs
+	solverCache := mediator.NewSolverCache(1000 * 288)
+
+	t.Run("Least recently used grids are evicted", func(t *testing.T) {
+		// Arrange
+		solverCache.CalculateOrderPacks(newOrderPacks(400, 500, 250))
+		solverCache.CalculateOrderPacks(newOrderPacks(400, 60, 40))
+		solverCache.CalculateOrderPacks(newOrderPacks(10, 500, 250))
+
+		// Act
+		solverCache.CalculateOrderPacks(newOrderPacks(400, 7, 3))
+
+		// Assert
+		require.Equal(t, mediator.SolverCacheUsage{PackSets: 2, Quantities: 800, Bytes: 800 * 288}, solverCache.Usage())
+		solverCache.CalculateOrderPacks(newOrderPacks(400, 500, 250))
+		require.Equal(t, 800, solverCache.Usage().Quantities)
+	})
+
+	t.Run("Quantities over the budget are calculated without caching", func(t *testing.T) {
+		// Act
+		orderPacks := solverCache.CalculateOrderPacks(newOrderPacks(1001, 500, 250))
+
+		// Assert
+		require.Equal(t, domain_model.OrderPack{500: 2, 250: 1}, orderPacks.OptimalOrderPack)
+		require.Equal(t, mediator.SolverCacheUsage{PackSets: 2, Quantities: 800, Bytes: 800 * 288}, solverCache.Usage())
+	})
+
+	t.Run("Pack sets over the budget are not kept", func(t *testing.T) {
+		// Act
+		orderPacks := solverCache.CalculateOrderPacks(newOrderPacks(1001, 600, 300))
+
+		// Assert
+		require.Equal(t, domain_model.OrderPack{600: 2, 300: 0}, orderPacks.OptimalOrderPack)
+		require.Equal(t, 2, solverCache.Usage().PackSets)
+	})
+}
+
+func Test_SolverCache_ConcurrentCalculations(t *testing.T) {
+	// Set Up
+	solverCache := mediator.NewSolverCache(1 << 20)
+	uncached := mediator.NewSolverCache(0)
+
+	// Arrange
+	expected := make(map[int]domain_model.OrderPack)
+	for quantity := 1; quantity <= 2000; quantity += 97 {
+		expected[quantity] = uncached.CalculateOrderPacks(newOrderPacks(quantity, 53, 31, 23)).OptimalOrderPack
+	}
+
+	// Act
+	results := make([]map[int]domain_model.OrderPack, 8)
+	var wg sync.WaitGroup
+	for worker := range results {
+		results[worker] = make(map[int]domain_model.OrderPack)
+		wg.Add(1)
+		go func(results map[int]domain_model.OrderPack) {
+			defer wg.Done()
+			for quantity := range expected {
+				results[quantity] = solverCache.CalculateOrderPacks(newOrderPacks(quantity, 53, 31, 23)).OptimalOrderPack
+			}
+		}(results[worker])
+	}
+	wg.Wait()
+
+	// Assert
+	for _, workerResults := range results {
+		require.Equal(t, expected, workerResults)
+	}
+	require.Equal(t, mediator.SolverCacheUsage{PackSets: 1, Quantities: 1941, Bytes: 1941 * 304}, solverCache.Usage())
+}
@@ -0,0 +1,97 @@
+package mediator_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+	repository_mocks "github.com/felipevillarrealdaza/go-service-template/internal/repository/mocks"
+	"github.com/google/uuid"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TenantIsolation(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	orderMediator := mediator.NewOrderMediator(mediator.WithOrderRepository(repositoryMock))
+	packMediator := mediator.NewPackMediator(mediator.WithPackRepository(repositoryMock))
+	authMediator := mediator.NewAuthMediator(mediator.WithAuthRepository(repositoryMock))
+	ctx := domain_model.ContextWithTenant(context.Background(), "acme")
+
+	t.Run("Order created for the tenant", func(t *testing.T) {
+		// Arrange
+		order := domain_model.Order{OrderId: uuid.New(), Quantity: 500}
+		repositoryMock.On("AddOrder", mock.Anything, repository.AddOrderParams{
+			OrderID:       order.OrderId,
+			OrderQuantity: 500,
+			Profile:       "default",
+			TenantID:      "acme",
+		}).Return(nil).Once()
+
+		// Act
+		createErr := orderMediator.CreateOrder(ctx, order)
+
+		// Assert
+		require.NoError(t, createErr)
+		repositoryMock.AssertExpectations(t)
+	})
+
+	t.Run("Order of another tenant not found", func(t *testing.T) {
+		// Arrange
+		orderId := uuid.New()
+		repositoryMock.On("RetrieveOrderById", mock.Anything, repository.RetrieveOrderByIdParams{OrderID: orderId, TenantID: "acme"}).
+			Return(repository.Order{}, sql.ErrNoRows).Once()
+
+		// Act
+		_, transitionErr := orderMediator.TransitionOrderStatus(ctx, orderId, domain_model.OrderStatusPicked)
+
+		// Assert
+		require.ErrorIs(t, transitionErr, mediator.ErrOrderNotFound)
+	})
+
+	t.Run("Packs of the tenant", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrievePacks", mock.Anything, repository.RetrievePacksParams{Profile: "default", TenantID: "acme"}).Return([]int32{250}, nil).Once()
+
+		// Act
+		packs, retrieveErr := packMediator.RetrievePacks(ctx, "")
+
+		// Assert
+		require.NoError(t, retrieveErr)
+		require.Equal(t, []int{250}, packs)
+	})
+
+	t.Run("Api key bound to the tenant", func(t *testing.T) {
+		// Arrange
+		repositoryMock.
+			On("AddApiKey", mock.Anything, mock.MatchedBy(func(params repository.AddApiKeyParams) bool {
+				return params.TenantID == sql.NullString{String: "acme", Valid: true}
+			})).
+			Return(repository.ApiKey{Name: "warehouse", Role: "client", TenantID: sql.NullString{String: "acme", Valid: true}}, nil).Once()
+
+		// Act
+		apiKey, _, createErr := authMediator.CreateApiKey(ctx, "warehouse", domain_model.RoleClient)
+
+		// Assert
+		require.NoError(t, createErr)
+		require.Equal(t, "acme", apiKey.Tenant)
+	})
+
+	t.Run("Principal of a bound api key", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrieveActiveApiKeyByHash", mock.Anything, mock.Anything).
+			Return(repository.ApiKey{Name: "warehouse", Role: "client", TenantID: sql.NullString{String: "acme", Valid: true}}, nil).Once()
+
+		// Act
+		principal, authErr := authMediator.Authenticate(context.Background(), domain_model.Credentials{ApiKey: "pck_key"})
+
+		// Assert
+		require.NoError(t, authErr)
+		require.Equal(t, "acme", principal.Tenant)
+	})
+}
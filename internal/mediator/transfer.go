@@ -0,0 +1,65 @@
+package mediator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+)
+
+// Orders are read a page at a time, so exports do not hold the whole table in memory
+const exportPageSize = 1000
+
+// Call fn with every order and its packs, ordered by id, stopping at the first error
+func (om orderMediator) ExportOrders(ctx context.Context, fn func(order domain_model.OrderExport) error) error {
+	params := repository.RetrieveOrdersPageParams{PageSize: exportPageSize, TenantID: domain_model.TenantFromContext(ctx)}
+	for {
+		orders, retrieveOrdersErr := om.orderRepository.RetrieveOrdersPage(ctx, params)
+		if retrieveOrdersErr != nil {
+			return errors.Wrap(retrieveOrdersErr, fmt.Sprintf("could not retrieve orders after [%v]", params.AfterOrderID))
+		}
+		if len(orders) == 0 {
+			return nil
+		}
+
+		orderIds := make([]uuid.UUID, 0, len(orders))
+		for _, order := range orders {
+			orderIds = append(orderIds, order.OrderID)
+		}
+		orderPacks, retrieveOrderPacksErr := om.orderRepository.RetrieveOrderPacksByOrders(ctx, repository.RetrieveOrderPacksByOrdersParams{OrderIds: orderIds, TenantID: params.TenantID})
+		if retrieveOrderPacksErr != nil {
+			return errors.Wrap(retrieveOrderPacksErr, fmt.Sprintf("could not retrieve packs of orders after [%v]", params.AfterOrderID))
+		}
+		packsByOrder := make(map[uuid.UUID]domain_model.OrderPack, len(orders))
+		for _, orderPack := range orderPacks {
+			if packsByOrder[orderPack.OrderID] == nil {
+				packsByOrder[orderPack.OrderID] = make(domain_model.OrderPack)
+			}
+			packsByOrder[orderPack.OrderID][int(orderPack.PackSize)] += int(orderPack.PackQuantity)
+		}
+
+		for _, order := range orders {
+			if fnErr := fn(domain_model.OrderExport{Order: translateOrderToDomainModel(order), Packs: packsByOrder[order.OrderID]}); fnErr != nil {
+				return fnErr
+			}
+		}
+
+		if len(orders) < exportPageSize {
+			return nil
+		}
+		params.AfterOrderID = orders[len(orders)-1].OrderID
+	}
+}
+
+// Create an order of the profile for the quantity and calculate its packs, as if it was posted
+func (om orderMediator) ImportOrder(ctx context.Context, profile string, quantity int) (domain_model.OrderPacks, error) {
+	order := domain_model.Order{OrderId: uuid.New(), Quantity: quantity, Profile: profile}
+	if createErr := om.CreateOrder(ctx, order); createErr != nil {
+		return domain_model.OrderPacks{}, createErr
+	}
+	return om.CalculateOrderPacks(ctx, order.OrderId)
+}
@@ -0,0 +1,93 @@
+package mediator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator"
+	"github.com/felipevillarrealdaza/go-service-template/internal/mediator/domain_model"
+	"github.com/felipevillarrealdaza/go-service-template/internal/repository"
+	repository_mocks "github.com/felipevillarrealdaza/go-service-template/internal/repository/mocks"
+	"github.com/google/uuid"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ExportOrders(t *testing.T) {
+	// Set Up
+	repositoryMock := repository_mocks.NewQuerier(t)
+	orderMediator := mediator.NewOrderMediator(mediator.WithOrderRepository(repositoryMock))
+	calculatedOrder := repository.Order{OrderID: uuid.New(), OrderQuantity: 12, Status: "calculated"}
+	createdOrder := repository.Order{OrderID: uuid.New(), OrderQuantity: 3, Status: "created"}
+
+	t.Run("Orders with their packs", func(t *testing.T) {
+		// Arrange
+		repositoryMock.
+			On("RetrieveOrdersPage", mock.Anything, repository.RetrieveOrdersPageParams{PageSize: 1000, TenantID: "default"}).
+			Return([]repository.Order{calculatedOrder, createdOrder}, nil).Once()
+		repositoryMock.
+			On("RetrieveOrderPacksByOrders", mock.Anything, repository.RetrieveOrderPacksByOrdersParams{OrderIds: []uuid.UUID{calculatedOrder.OrderID, createdOrder.OrderID}, TenantID: "default"}).
+			Return([]repository.OrderPack{
+				{OrderID: calculatedOrder.OrderID, PackSize: 2, PackQuantity: 1},
+				{OrderID: calculatedOrder.OrderID, PackSize: 5, PackQuantity: 2},
+			}, nil).Once()
+		var exported []domain_model.OrderExport
+
+		// Act
+		exportErr := orderMediator.ExportOrders(context.Background(), func(order domain_model.OrderExport) error {
+			exported = append(exported, order)
+			return nil
+		})
+
+		// Assert
+		repositoryMock.AssertExpectations(t)
+		require.NoError(t, exportErr)
+		require.Len(t, exported, 2)
+		require.Equal(t, calculatedOrder.OrderID, exported[0].Order.OrderId)
+		require.Equal(t, domain_model.OrderPack{2: 1, 5: 2}, exported[0].Packs)
+		require.Equal(t, domain_model.OrderStatusCreated, exported[1].Order.Status)
+		require.Empty(t, exported[1].Packs)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Callback error stops the export", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrieveOrdersPage", mock.Anything, mock.Anything).Return([]repository.Order{calculatedOrder, createdOrder}, nil).Once()
+		repositoryMock.On("RetrieveOrderPacksByOrders", mock.Anything, mock.Anything).Return([]repository.OrderPack{}, nil).Once()
+		writeErr := errors.New("client went away")
+		calls := 0
+
+		// Act
+		exportErr := orderMediator.ExportOrders(context.Background(), func(order domain_model.OrderExport) error {
+			calls++
+			return writeErr
+		})
+
+		// Assert
+		require.ErrorIs(t, exportErr, writeErr)
+		require.Equal(t, 1, calls)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		// Arrange
+		repositoryMock.On("RetrieveOrdersPage", mock.Anything, mock.Anything).Return(nil, errors.New("connection reset")).Once()
+
+		// Act
+		exportErr := orderMediator.ExportOrders(context.Background(), func(order domain_model.OrderExport) error {
+			return nil
+		})
+
+		// Assert
+		require.Error(t, exportErr)
+
+		// Clean up
+		repositoryMock.ExpectedCalls = make([]*mock.Call, 0)
+	})
+}
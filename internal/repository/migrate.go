@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+const createSchemaMigrationsTable = `
+CREATE TABLE IF NOT EXISTS public.schema_migrations (
+    version int NOT NULL,
+    applied_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY(version)
+)
+`
+
+// Key of the advisory lock held while migrating, so replicas starting together apply the migrations one at a time
+const migrationLockKey = 7_268_354_102
+
+// Values the migrations read with current_setting, given from the config of the service running them
+type MigrationSettings struct {
+	// Currency of the pack prices set before prices carried their own, read as app.currency
+	Currency string
+}
+
+type migration struct {
+	version int
+	name    string
+	script  string
+}
+
+// LatestSchemaVersion returns the version of the newest migration shipped with the binary.
+func LatestSchemaVersion() int {
+	migrations, loadErr := loadMigrations()
+	if loadErr != nil || len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].version
+}
+
+// Migrate applies, in order, every embedded migration that has not been recorded in schema_migrations yet.
+// Each migration runs in its own transaction together with the insert of its version. A session advisory lock is held
+// throughout, so concurrent callers wait and then find the migrations already applied.
+func Migrate(ctx context.Context, db *sql.DB, settings MigrationSettings) error {
+	// Session locks belong to a connection, so every statement runs on the same one
+	conn, connErr := db.Conn(ctx)
+	if connErr != nil {
+		return errors.Wrap(connErr, "could not get a connection to migrate")
+	}
+	defer conn.Close()
+
+	if _, lockErr := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); lockErr != nil {
+		return errors.Wrap(lockErr, "could not take the migration lock")
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", migrationLockKey)
+
+	if _, settingErr := conn.ExecContext(ctx, "SELECT set_config('app.currency', $1, false)", settings.Currency); settingErr != nil {
+		return errors.Wrap(settingErr, "could not set the migration settings")
+	}
+
+	if _, createErr := conn.ExecContext(ctx, createSchemaMigrationsTable); createErr != nil {
+		return errors.Wrap(createErr, "could not create schema_migrations table")
+	}
+
+	migrations, loadErr := loadMigrations()
+	if loadErr != nil {
+		return loadErr
+	}
+
+	currentVersion, versionErr := New(conn).RetrieveSchemaVersion(ctx)
+	if versionErr != nil {
+		return errors.Wrap(versionErr, "could not retrieve current schema version")
+	}
+
+	for _, m := range migrations {
+		if m.version <= int(currentVersion) {
+			continue
+		}
+		if applyErr := applyMigration(ctx, conn, m); applyErr != nil {
+			return errors.Wrap(applyErr, fmt.Sprintf("could not apply migration [%v]", m.name))
+		}
+	}
+	return nil
+}
+
+func applyMigration(ctx context.Context, conn *sql.Conn, m migration) error {
+	tx, txErr := conn.BeginTx(ctx, nil)
+	if txErr != nil {
+		return txErr
+	}
+	defer tx.Rollback()
+
+	if _, execErr := tx.ExecContext(ctx, m.script); execErr != nil {
+		return execErr
+	}
+	if _, insertErr := tx.ExecContext(ctx, "INSERT INTO public.schema_migrations (version) VALUES ($1)", m.version); insertErr != nil {
+		return insertErr
+	}
+	return tx.Commit()
+}
+
+// Migration files are named <version>_<description>.sql, e.g. 0001_initial_schema.sql
+func loadMigrations() ([]migration, error) {
+	entries, readErr := fs.ReadDir(migrationFiles, "migrations")
+	if readErr != nil {
+		return nil, errors.Wrap(readErr, "could not read embedded migrations")
+	}
+
+	var migrations []migration
+	for _, entry := range entries {
+		prefix, _, found := strings.Cut(entry.Name(), "_")
+		if !found {
+			return nil, errors.New(fmt.Sprintf("migration [%v] does not follow the <version>_<description>.sql convention", entry.Name()))
+		}
+		version, parseErr := strconv.Atoi(prefix)
+		if parseErr != nil {
+			return nil, errors.Wrap(parseErr, fmt.Sprintf("migration [%v] has an invalid version", entry.Name()))
+		}
+		script, scriptErr := fs.ReadFile(migrationFiles, path.Join("migrations", entry.Name()))
+		if scriptErr != nil {
+			return nil, errors.Wrap(scriptErr, fmt.Sprintf("could not read migration [%v]", entry.Name()))
+		}
+		migrations = append(migrations, migration{version: version, name: entry.Name(), script: string(script)})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].version < migrations[j].version })
+	return migrations, nil
+}
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS public.pack (
+    pack_size int NOT NULL,
+    PRIMARY KEY(pack_size)
+);
+
+CREATE TABLE IF NOT EXISTS public.order (
+    order_id uuid NOT NULL,
+    order_quantity int NOT NULL,
+    PRIMARY KEY(order_id)
+);
+
+CREATE TABLE IF NOT EXISTS public.order_packs (
+    order_packs_id uuid NOT NULL,
+    order_id uuid REFERENCES public.order(order_id),
+    pack_size int NOT NULL,
+    pack_quantity int NOT NULL,
+    PRIMARY KEY(order_packs_id, order_id, pack_size)
+);
+
+-- Databases created before the migrations already hold their packs, the defaults only seed a new pack table
+INSERT INTO public.pack (pack_size)
+SELECT pack_size FROM unnest(ARRAY[250, 500, 1000, 2000, 5000]) AS default_pack(pack_size)
+WHERE NOT EXISTS (SELECT 1 FROM public.pack);
@@ -0,0 +1,9 @@
+CREATE TABLE public.api_key (
+    api_key_id uuid NOT NULL,
+    name text NOT NULL,
+    key_hash text NOT NULL UNIQUE,
+    role text NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    revoked_at timestamptz,
+    PRIMARY KEY(api_key_id)
+);
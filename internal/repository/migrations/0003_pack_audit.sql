@@ -0,0 +1,22 @@
+CREATE TABLE public.pack_audit (
+    audit_id uuid NOT NULL,
+    actor text NOT NULL,
+    action text NOT NULL,
+    pack_size int NOT NULL,
+    request_id text NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY(audit_id)
+);
+
+CREATE INDEX pack_audit_created_at_idx ON public.pack_audit (created_at);
+
+-- The audit is append-only, rows can never be changed or removed
+CREATE FUNCTION public.prevent_pack_audit_changes() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'pack_audit is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER pack_audit_append_only
+    BEFORE UPDATE OR DELETE OR TRUNCATE ON public.pack_audit
+    FOR EACH STATEMENT EXECUTE FUNCTION public.prevent_pack_audit_changes();
@@ -0,0 +1,16 @@
+ALTER TABLE public.order
+    ADD COLUMN status text NOT NULL DEFAULT 'created'
+        CHECK (status IN ('created', 'calculated', 'picked', 'packed', 'shipped', 'cancelled')),
+    ADD COLUMN created_at timestamptz NOT NULL DEFAULT now(),
+    ADD COLUMN updated_at timestamptz NOT NULL DEFAULT now(),
+    ADD COLUMN calculated_at timestamptz,
+    ADD COLUMN picked_at timestamptz,
+    ADD COLUMN packed_at timestamptz,
+    ADD COLUMN shipped_at timestamptz,
+    ADD COLUMN cancelled_at timestamptz;
+
+-- Orders created before the lifecycle existed were calculated right away
+UPDATE public.order o SET status = 'calculated', calculated_at = now()
+WHERE EXISTS (SELECT 1 FROM public.order_packs s WHERE s.order_id = o.order_id);
+
+CREATE INDEX order_status_idx ON public.order (status);
@@ -0,0 +1,9 @@
+-- Pack sizes available when the order was calculated, so amendments re-plan with the same set
+ALTER TABLE public.order ADD COLUMN pack_set int[];
+
+-- Calculated orders predate the column, so they take the pack sizes available now rather than the sizes they used,
+-- biggest first like the pack sets saved by calculations
+UPDATE public.order o SET pack_set = (
+    SELECT array_agg(p.pack_size ORDER BY p.pack_size DESC) FROM public.pack p
+)
+WHERE EXISTS (SELECT 1 FROM public.order_packs s WHERE s.order_id = o.order_id);
@@ -0,0 +1,3 @@
+-- Analytics aggregate the orders of a date range and join them with their packs
+CREATE INDEX order_created_at_idx ON public.order (created_at);
+CREATE INDEX order_packs_order_id_idx ON public.order_packs (order_id);
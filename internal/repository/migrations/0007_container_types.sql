@@ -0,0 +1,11 @@
+-- Containers packs are shipped in, each type holding either packs of one size or containers of another type, so
+-- every pack size has a chain of containers, e.g. packs into cartons into pallets
+CREATE TABLE public.container_type (
+    name text NOT NULL,
+    pack_size int UNIQUE REFERENCES public.pack(pack_size) ON DELETE CASCADE,
+    child_name text UNIQUE REFERENCES public.container_type(name) ON DELETE CASCADE,
+    capacities int[] NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY(name),
+    CHECK ((pack_size IS NULL) <> (child_name IS NULL))
+);
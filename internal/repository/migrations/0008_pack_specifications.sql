@@ -0,0 +1,5 @@
+-- Maximum weight a pack can carry and its outer dimensions, unknown when null
+ALTER TABLE public.pack ADD COLUMN max_weight_grams int;
+ALTER TABLE public.pack ADD COLUMN length_mm int;
+ALTER TABLE public.pack ADD COLUMN width_mm int;
+ALTER TABLE public.pack ADD COLUMN height_mm int;
@@ -0,0 +1,8 @@
+-- Shipments an order is split into, one row per pack size in each shipment
+CREATE TABLE public.order_shipment (
+    order_id uuid NOT NULL REFERENCES public.order(order_id) ON DELETE CASCADE,
+    shipment_number int NOT NULL,
+    pack_size int NOT NULL,
+    pack_quantity int NOT NULL,
+    PRIMARY KEY(order_id, shipment_number, pack_size)
+);
@@ -0,0 +1,30 @@
+-- Price of each pack, in the service currency, unknown when null
+ALTER TABLE public.pack ADD COLUMN price numeric(12, 2) CHECK (price >= 0);
+
+-- Discount applied to orders of at least min_quantity items, the biggest tier reached applying
+CREATE TABLE public.discount_tier (
+    min_quantity int NOT NULL CHECK (min_quantity > 0),
+    percent numeric(5, 2) NOT NULL CHECK (percent > 0 AND percent <= 100),
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY(min_quantity)
+);
+
+-- Prices the order was calculated with, so later price changes leave it as it was
+CREATE TABLE public.order_price (
+    order_id uuid NOT NULL REFERENCES public.order(order_id) ON DELETE CASCADE,
+    currency char(3) NOT NULL,
+    subtotal numeric(14, 2) NOT NULL,
+    discount_percent numeric(5, 2) NOT NULL,
+    discount numeric(14, 2) NOT NULL,
+    total numeric(14, 2) NOT NULL,
+    PRIMARY KEY(order_id)
+);
+
+CREATE TABLE public.order_price_line (
+    order_id uuid NOT NULL REFERENCES public.order_price(order_id) ON DELETE CASCADE,
+    pack_size int NOT NULL,
+    pack_quantity int NOT NULL,
+    unit_price numeric(12, 2) NOT NULL,
+    line_total numeric(14, 2) NOT NULL,
+    PRIMARY KEY(order_id, pack_size)
+);
@@ -0,0 +1,26 @@
+-- Packs and price offered for a quantity until the quote expires, accepting it creates an order with those packs
+CREATE TABLE public.quote (
+    quote_id uuid NOT NULL,
+    quote_quantity int NOT NULL,
+    pack_set int[] NOT NULL,
+    currency char(3),
+    subtotal numeric(14, 2),
+    discount_percent numeric(5, 2),
+    discount numeric(14, 2),
+    total numeric(14, 2),
+    order_id uuid REFERENCES public.order(order_id) ON DELETE SET NULL,
+    expires_at timestamptz NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY(quote_id)
+);
+
+CREATE INDEX quote_expires_at_idx ON public.quote (expires_at);
+
+CREATE TABLE public.quote_pack (
+    quote_id uuid NOT NULL REFERENCES public.quote(quote_id) ON DELETE CASCADE,
+    pack_size int NOT NULL,
+    pack_quantity int NOT NULL,
+    unit_price numeric(12, 2),
+    line_total numeric(14, 2),
+    PRIMARY KEY(quote_id, pack_size)
+);
@@ -0,0 +1,40 @@
+-- Named pack sets, e.g. per warehouse or sales channel, each with its own pack sizes
+CREATE TABLE public.pack_profile (
+    name text NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY(name)
+);
+
+INSERT INTO public.pack_profile (name) VALUES ('default');
+
+-- Container types hold a pack size in every profile, so they can no longer reference a single pack. The size must
+-- still be stocked by some profile when the container type is added.
+ALTER TABLE public.container_type DROP CONSTRAINT container_type_pack_size_fkey;
+
+CREATE FUNCTION public.check_container_type_pack_size() RETURNS trigger AS $$
+BEGIN
+    IF NEW.pack_size IS NOT NULL AND NOT EXISTS (SELECT 1 FROM public.pack WHERE pack_size = NEW.pack_size) THEN
+        RAISE foreign_key_violation USING MESSAGE = format('pack size %s is in no pack profile', NEW.pack_size);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER container_type_pack_size_exists
+    BEFORE INSERT OR UPDATE ON public.container_type
+    FOR EACH ROW EXECUTE FUNCTION public.check_container_type_pack_size();
+
+-- Existing packs, orders and quotes belong to the default profile
+ALTER TABLE public.pack ADD COLUMN profile text NOT NULL DEFAULT 'default' REFERENCES public.pack_profile(name) ON DELETE CASCADE;
+ALTER TABLE public.pack ALTER COLUMN profile DROP DEFAULT;
+ALTER TABLE public.pack DROP CONSTRAINT pack_pkey;
+ALTER TABLE public.pack ADD PRIMARY KEY(profile, pack_size);
+
+ALTER TABLE public.pack_audit ADD COLUMN profile text NOT NULL DEFAULT 'default';
+ALTER TABLE public.pack_audit ALTER COLUMN profile DROP DEFAULT;
+
+ALTER TABLE public.order ADD COLUMN profile text NOT NULL DEFAULT 'default' REFERENCES public.pack_profile(name);
+ALTER TABLE public.order ALTER COLUMN profile DROP DEFAULT;
+
+ALTER TABLE public.quote ADD COLUMN profile text NOT NULL DEFAULT 'default' REFERENCES public.pack_profile(name);
+ALTER TABLE public.quote ALTER COLUMN profile DROP DEFAULT;
@@ -0,0 +1,78 @@
+-- Business units sharing the deployment each own their packs, orders and settings. Existing rows belong to the
+-- default tenant, and tenants can reuse the same profile names, pack sizes, container types and discount tiers.
+
+-- Keys bound to a tenant only act on it, keys bound to none pick the tenant of each request. Existing client keys are
+-- bound to the default tenant, existing admin keys keep acting on every tenant.
+ALTER TABLE public.api_key ADD COLUMN tenant_id text;
+UPDATE public.api_key SET tenant_id = 'default' WHERE role = 'client';
+
+-- Profiles are named per tenant, so the keys referencing them now include it
+ALTER TABLE public.pack DROP CONSTRAINT pack_profile_fkey;
+ALTER TABLE public.order DROP CONSTRAINT order_profile_fkey;
+ALTER TABLE public.quote DROP CONSTRAINT quote_profile_fkey;
+
+ALTER TABLE public.pack_profile ADD COLUMN tenant_id text NOT NULL DEFAULT 'default';
+ALTER TABLE public.pack_profile ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE public.pack_profile DROP CONSTRAINT pack_profile_pkey;
+ALTER TABLE public.pack_profile ADD PRIMARY KEY(tenant_id, name);
+
+ALTER TABLE public.pack ADD COLUMN tenant_id text NOT NULL DEFAULT 'default';
+ALTER TABLE public.pack ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE public.pack DROP CONSTRAINT pack_pkey;
+ALTER TABLE public.pack ADD PRIMARY KEY(tenant_id, profile, pack_size);
+ALTER TABLE public.pack ADD FOREIGN KEY(tenant_id, profile) REFERENCES public.pack_profile(tenant_id, name) ON DELETE CASCADE;
+
+ALTER TABLE public.pack_audit ADD COLUMN tenant_id text NOT NULL DEFAULT 'default';
+ALTER TABLE public.pack_audit ALTER COLUMN tenant_id DROP DEFAULT;
+
+ALTER TABLE public.order ADD COLUMN tenant_id text NOT NULL DEFAULT 'default';
+ALTER TABLE public.order ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE public.order ADD FOREIGN KEY(tenant_id, profile) REFERENCES public.pack_profile(tenant_id, name);
+CREATE INDEX order_tenant_id_idx ON public.order (tenant_id, order_id);
+
+ALTER TABLE public.order_packs ADD COLUMN tenant_id text NOT NULL DEFAULT 'default';
+ALTER TABLE public.order_packs ALTER COLUMN tenant_id DROP DEFAULT;
+
+ALTER TABLE public.order_shipment ADD COLUMN tenant_id text NOT NULL DEFAULT 'default';
+ALTER TABLE public.order_shipment ALTER COLUMN tenant_id DROP DEFAULT;
+
+ALTER TABLE public.order_price ADD COLUMN tenant_id text NOT NULL DEFAULT 'default';
+ALTER TABLE public.order_price ALTER COLUMN tenant_id DROP DEFAULT;
+
+ALTER TABLE public.order_price_line ADD COLUMN tenant_id text NOT NULL DEFAULT 'default';
+ALTER TABLE public.order_price_line ALTER COLUMN tenant_id DROP DEFAULT;
+
+ALTER TABLE public.quote ADD COLUMN tenant_id text NOT NULL DEFAULT 'default';
+ALTER TABLE public.quote ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE public.quote ADD FOREIGN KEY(tenant_id, profile) REFERENCES public.pack_profile(tenant_id, name);
+
+ALTER TABLE public.quote_pack ADD COLUMN tenant_id text NOT NULL DEFAULT 'default';
+ALTER TABLE public.quote_pack ALTER COLUMN tenant_id DROP DEFAULT;
+
+ALTER TABLE public.discount_tier ADD COLUMN tenant_id text NOT NULL DEFAULT 'default';
+ALTER TABLE public.discount_tier ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE public.discount_tier DROP CONSTRAINT discount_tier_pkey;
+ALTER TABLE public.discount_tier ADD PRIMARY KEY(tenant_id, min_quantity);
+
+-- Container types hold the packs or containers of their own tenant
+ALTER TABLE public.container_type DROP CONSTRAINT container_type_child_name_fkey;
+ALTER TABLE public.container_type DROP CONSTRAINT container_type_child_name_key;
+ALTER TABLE public.container_type DROP CONSTRAINT container_type_pack_size_key;
+ALTER TABLE public.container_type DROP CONSTRAINT container_type_pkey;
+ALTER TABLE public.container_type ADD COLUMN tenant_id text NOT NULL DEFAULT 'default';
+ALTER TABLE public.container_type ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE public.container_type ADD PRIMARY KEY(tenant_id, name);
+ALTER TABLE public.container_type ADD UNIQUE(tenant_id, pack_size);
+ALTER TABLE public.container_type ADD UNIQUE(tenant_id, child_name);
+ALTER TABLE public.container_type ADD FOREIGN KEY(tenant_id, child_name) REFERENCES public.container_type(tenant_id, name) ON DELETE CASCADE;
+
+CREATE OR REPLACE FUNCTION public.check_container_type_pack_size() RETURNS trigger AS $$
+BEGIN
+    IF NEW.pack_size IS NOT NULL AND NOT EXISTS (
+        SELECT 1 FROM public.pack WHERE tenant_id = NEW.tenant_id AND pack_size = NEW.pack_size
+    ) THEN
+        RAISE foreign_key_violation USING MESSAGE = format('pack size %s is in no pack profile', NEW.pack_size);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
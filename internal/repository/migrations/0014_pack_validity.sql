@@ -0,0 +1,10 @@
+-- Packs are only used while valid, from valid_from included to valid_to excluded. Null bounds leave the window open,
+-- so existing packs stay valid.
+ALTER TABLE public.pack ADD COLUMN valid_from timestamptz;
+ALTER TABLE public.pack ADD COLUMN valid_to timestamptz;
+ALTER TABLE public.pack ADD CONSTRAINT pack_validity_check CHECK (valid_from < valid_to);
+
+-- Orders are calculated with the packs valid at their date, which is when they were created unless backdated
+ALTER TABLE public.order ADD COLUMN order_date timestamptz;
+UPDATE public.order SET order_date = created_at;
+ALTER TABLE public.order ALTER COLUMN order_date SET NOT NULL;
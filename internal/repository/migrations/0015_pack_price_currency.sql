@@ -0,0 +1,7 @@
+-- Currency of each pack price, so changing the service currency does not relabel the prices already set. Prices set
+-- before were set in the configured APP_CURRENCY, which the service migrating gives as app.currency.
+ALTER TABLE public.pack ADD COLUMN price_currency char(3);
+ALTER TABLE public.pack ADD CONSTRAINT pack_price_currency_check CHECK (price_currency ~ '^[A-Z]{3}$');
+
+UPDATE public.pack SET price_currency = current_setting('app.currency')
+WHERE price IS NOT NULL AND price_currency IS NULL;
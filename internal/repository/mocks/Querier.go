@@ -4,9 +4,9 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	repository "github.com/felipevillarrealdaza/go-service-template/internal/repository"
-	uuid "github.com/google/uuid"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -15,6 +15,118 @@ type Querier struct {
 	mock.Mock
 }
 
+// AcceptQuote provides a mock function with given fields: ctx, arg
+func (_m *Querier) AcceptQuote(ctx context.Context, arg repository.AcceptQuoteParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptQuote")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.AcceptQuoteParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.AcceptQuoteParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.AcceptQuoteParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddApiKey provides a mock function with given fields: ctx, arg
+func (_m *Querier) AddApiKey(ctx context.Context, arg repository.AddApiKeyParams) (repository.ApiKey, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddApiKey")
+	}
+
+	var r0 repository.ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.AddApiKeyParams) (repository.ApiKey, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.AddApiKeyParams) repository.ApiKey); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.ApiKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.AddApiKeyParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddContainerType provides a mock function with given fields: ctx, arg
+func (_m *Querier) AddContainerType(ctx context.Context, arg repository.AddContainerTypeParams) (repository.ContainerType, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddContainerType")
+	}
+
+	var r0 repository.ContainerType
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.AddContainerTypeParams) (repository.ContainerType, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.AddContainerTypeParams) repository.ContainerType); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.ContainerType)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.AddContainerTypeParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddDiscountTier provides a mock function with given fields: ctx, arg
+func (_m *Querier) AddDiscountTier(ctx context.Context, arg repository.AddDiscountTierParams) (repository.DiscountTier, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddDiscountTier")
+	}
+
+	var r0 repository.DiscountTier
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.AddDiscountTierParams) (repository.DiscountTier, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.AddDiscountTierParams) repository.DiscountTier); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.DiscountTier)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.AddDiscountTierParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AddOrder provides a mock function with given fields: ctx, arg
 func (_m *Querier) AddOrder(ctx context.Context, arg repository.AddOrderParams) error {
 	ret := _m.Called(ctx, arg)
@@ -51,17 +163,17 @@ func (_m *Querier) AddOrderPack(ctx context.Context, arg repository.AddOrderPack
 	return r0
 }
 
-// AddPack provides a mock function with given fields: ctx, packSize
-func (_m *Querier) AddPack(ctx context.Context, packSize int32) error {
-	ret := _m.Called(ctx, packSize)
+// AddOrderPrice provides a mock function with given fields: ctx, arg
+func (_m *Querier) AddOrderPrice(ctx context.Context, arg repository.AddOrderPriceParams) error {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AddPack")
+		panic("no return value specified for AddOrderPrice")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int32) error); ok {
-		r0 = rf(ctx, packSize)
+	if rf, ok := ret.Get(0).(func(context.Context, repository.AddOrderPriceParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -69,17 +181,17 @@ func (_m *Querier) AddPack(ctx context.Context, packSize int32) error {
 	return r0
 }
 
-// RemovePackBySize provides a mock function with given fields: ctx, packSize
-func (_m *Querier) RemovePackBySize(ctx context.Context, packSize int32) error {
-	ret := _m.Called(ctx, packSize)
+// AddOrderPriceLine provides a mock function with given fields: ctx, arg
+func (_m *Querier) AddOrderPriceLine(ctx context.Context, arg repository.AddOrderPriceLineParams) error {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RemovePackBySize")
+		panic("no return value specified for AddOrderPriceLine")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int32) error); ok {
-		r0 = rf(ctx, packSize)
+	if rf, ok := ret.Get(0).(func(context.Context, repository.AddOrderPriceLineParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -87,57 +199,81 @@ func (_m *Querier) RemovePackBySize(ctx context.Context, packSize int32) error {
 	return r0
 }
 
-// RetrieveOrderById provides a mock function with given fields: ctx, orderID
-func (_m *Querier) RetrieveOrderById(ctx context.Context, orderID uuid.UUID) (repository.Order, error) {
-	ret := _m.Called(ctx, orderID)
+// AddOrderShipment provides a mock function with given fields: ctx, arg
+func (_m *Querier) AddOrderShipment(ctx context.Context, arg repository.AddOrderShipmentParams) error {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RetrieveOrderById")
+		panic("no return value specified for AddOrderShipment")
 	}
 
-	var r0 repository.Order
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (repository.Order, error)); ok {
-		return rf(ctx, orderID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.AddOrderShipmentParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddPack provides a mock function with given fields: ctx, arg
+func (_m *Querier) AddPack(ctx context.Context, arg repository.AddPackParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddPack")
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) repository.Order); ok {
-		r0 = rf(ctx, orderID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.AddPackParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(repository.Order)
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddPackAudit provides a mock function with given fields: ctx, arg
+func (_m *Querier) AddPackAudit(ctx context.Context, arg repository.AddPackAuditParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddPackAudit")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, orderID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.AddPackAuditParams) error); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// RetrieveOrderPacksByOrder provides a mock function with given fields: ctx, orderID
-func (_m *Querier) RetrieveOrderPacksByOrder(ctx context.Context, orderID uuid.UUID) ([]repository.RetrieveOrderPacksByOrderRow, error) {
-	ret := _m.Called(ctx, orderID)
+// AddPackProfile provides a mock function with given fields: ctx, arg
+func (_m *Querier) AddPackProfile(ctx context.Context, arg repository.AddPackProfileParams) (repository.PackProfile, error) {
+	ret := _m.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RetrieveOrderPacksByOrder")
+		panic("no return value specified for AddPackProfile")
 	}
 
-	var r0 []repository.RetrieveOrderPacksByOrderRow
+	var r0 repository.PackProfile
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]repository.RetrieveOrderPacksByOrderRow, error)); ok {
-		return rf(ctx, orderID)
+	if rf, ok := ret.Get(0).(func(context.Context, repository.AddPackProfileParams) (repository.PackProfile, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []repository.RetrieveOrderPacksByOrderRow); ok {
-		r0 = rf(ctx, orderID)
+	if rf, ok := ret.Get(0).(func(context.Context, repository.AddPackProfileParams) repository.PackProfile); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]repository.RetrieveOrderPacksByOrderRow)
-		}
+		r0 = ret.Get(0).(repository.PackProfile)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, orderID)
+	if rf, ok := ret.Get(1).(func(context.Context, repository.AddPackProfileParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -145,25 +281,59 @@ func (_m *Querier) RetrieveOrderPacksByOrder(ctx context.Context, orderID uuid.U
 	return r0, r1
 }
 
-// RetrieveOrders provides a mock function with given fields: ctx
-func (_m *Querier) RetrieveOrders(ctx context.Context) ([]repository.Order, error) {
+// AddQuote provides a mock function with given fields: ctx, arg
+func (_m *Querier) AddQuote(ctx context.Context, arg repository.AddQuoteParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddQuote")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.AddQuoteParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddQuotePack provides a mock function with given fields: ctx, arg
+func (_m *Querier) AddQuotePack(ctx context.Context, arg repository.AddQuotePackParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddQuotePack")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.AddQuotePackParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CountPacks provides a mock function with given fields: ctx
+func (_m *Querier) CountPacks(ctx context.Context) (int64, error) {
 	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RetrieveOrders")
+		panic("no return value specified for CountPacks")
 	}
 
-	var r0 []repository.Order
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]repository.Order, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
 		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []repository.Order); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
 		r0 = rf(ctx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]repository.Order)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
@@ -175,29 +345,1047 @@ func (_m *Querier) RetrieveOrders(ctx context.Context) ([]repository.Order, erro
 	return r0, r1
 }
 
-// RetrievePacks provides a mock function with given fields: ctx
-func (_m *Querier) RetrievePacks(ctx context.Context) ([]int32, error) {
-	ret := _m.Called(ctx)
+// ExistsApiKeyByHash provides a mock function with given fields: ctx, keyHash
+func (_m *Querier) ExistsApiKeyByHash(ctx context.Context, keyHash string) (bool, error) {
+	ret := _m.Called(ctx, keyHash)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RetrievePacks")
+		panic("no return value specified for ExistsApiKeyByHash")
 	}
 
-	var r0 []int32
+	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]int32, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, keyHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, keyHash)
+	} else {
+		r0 = ret.Get(0).(bool)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []int32); ok {
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, keyHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyPackSetChanged provides a mock function with given fields: ctx
+func (_m *Querier) NotifyPackSetChanged(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotifyPackSetChanged")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
 		r0 = rf(ctx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]int32)
-		}
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	return r0
+}
+
+// RemoveContainerTypeByName provides a mock function with given fields: ctx, arg
+func (_m *Querier) RemoveContainerTypeByName(ctx context.Context, arg repository.RemoveContainerTypeByNameParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveContainerTypeByName")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RemoveContainerTypeByNameParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RemoveContainerTypeByNameParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.RemoveContainerTypeByNameParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveDiscountTierByMinQuantity provides a mock function with given fields: ctx, arg
+func (_m *Querier) RemoveDiscountTierByMinQuantity(ctx context.Context, arg repository.RemoveDiscountTierByMinQuantityParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveDiscountTierByMinQuantity")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RemoveDiscountTierByMinQuantityParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RemoveDiscountTierByMinQuantityParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.RemoveDiscountTierByMinQuantityParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveExpiredQuotes provides a mock function with given fields: ctx, expiredBefore
+func (_m *Querier) RemoveExpiredQuotes(ctx context.Context, expiredBefore time.Time) (int64, error) {
+	ret := _m.Called(ctx, expiredBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveExpiredQuotes")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, expiredBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, expiredBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, expiredBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveOrderPacksByOrder provides a mock function with given fields: ctx, arg
+func (_m *Querier) RemoveOrderPacksByOrder(ctx context.Context, arg repository.RemoveOrderPacksByOrderParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveOrderPacksByOrder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RemoveOrderPacksByOrderParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveOrderPriceByOrder provides a mock function with given fields: ctx, arg
+func (_m *Querier) RemoveOrderPriceByOrder(ctx context.Context, arg repository.RemoveOrderPriceByOrderParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveOrderPriceByOrder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RemoveOrderPriceByOrderParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveOrderShipmentsByOrder provides a mock function with given fields: ctx, arg
+func (_m *Querier) RemoveOrderShipmentsByOrder(ctx context.Context, arg repository.RemoveOrderShipmentsByOrderParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveOrderShipmentsByOrder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RemoveOrderShipmentsByOrderParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemovePackBySize provides a mock function with given fields: ctx, arg
+func (_m *Querier) RemovePackBySize(ctx context.Context, arg repository.RemovePackBySizeParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemovePackBySize")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RemovePackBySizeParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RemovePackBySizeParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.RemovePackBySizeParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveActiveApiKeyByHash provides a mock function with given fields: ctx, keyHash
+func (_m *Querier) RetrieveActiveApiKeyByHash(ctx context.Context, keyHash string) (repository.ApiKey, error) {
+	ret := _m.Called(ctx, keyHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveActiveApiKeyByHash")
+	}
+
+	var r0 repository.ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (repository.ApiKey, error)); ok {
+		return rf(ctx, keyHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) repository.ApiKey); ok {
+		r0 = rf(ctx, keyHash)
+	} else {
+		r0 = ret.Get(0).(repository.ApiKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, keyHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveApiKeys provides a mock function with given fields: ctx, arg
+func (_m *Querier) RetrieveApiKeys(ctx context.Context, arg repository.RetrieveApiKeysParams) ([]repository.ApiKey, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveApiKeys")
+	}
+
+	var r0 []repository.ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrieveApiKeysParams) ([]repository.ApiKey, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrieveApiKeysParams) []repository.ApiKey); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.ApiKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.RetrieveApiKeysParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveContainerTypes provides a mock function with given fields: ctx, tenantID
+func (_m *Querier) RetrieveContainerTypes(ctx context.Context, tenantID string) ([]repository.ContainerType, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveContainerTypes")
+	}
+
+	var r0 []repository.ContainerType
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]repository.ContainerType, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []repository.ContainerType); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.ContainerType)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveDiscountTiers provides a mock function with given fields: ctx, tenantID
+func (_m *Querier) RetrieveDiscountTiers(ctx context.Context, tenantID string) ([]repository.DiscountTier, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveDiscountTiers")
+	}
+
+	var r0 []repository.DiscountTier
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]repository.DiscountTier, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []repository.DiscountTier); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.DiscountTier)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveOrderById provides a mock function with given fields: ctx, arg
+func (_m *Querier) RetrieveOrderById(ctx context.Context, arg repository.RetrieveOrderByIdParams) (repository.Order, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveOrderById")
+	}
+
+	var r0 repository.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrieveOrderByIdParams) (repository.Order, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrieveOrderByIdParams) repository.Order); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.Order)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.RetrieveOrderByIdParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveOrderPacksByOrder provides a mock function with given fields: ctx, arg
+func (_m *Querier) RetrieveOrderPacksByOrder(ctx context.Context, arg repository.RetrieveOrderPacksByOrderParams) ([]repository.RetrieveOrderPacksByOrderRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveOrderPacksByOrder")
+	}
+
+	var r0 []repository.RetrieveOrderPacksByOrderRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrieveOrderPacksByOrderParams) ([]repository.RetrieveOrderPacksByOrderRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrieveOrderPacksByOrderParams) []repository.RetrieveOrderPacksByOrderRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.RetrieveOrderPacksByOrderRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.RetrieveOrderPacksByOrderParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveOrderPacksByOrders provides a mock function with given fields: ctx, arg
+func (_m *Querier) RetrieveOrderPacksByOrders(ctx context.Context, arg repository.RetrieveOrderPacksByOrdersParams) ([]repository.OrderPack, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveOrderPacksByOrders")
+	}
+
+	var r0 []repository.OrderPack
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrieveOrderPacksByOrdersParams) ([]repository.OrderPack, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrieveOrderPacksByOrdersParams) []repository.OrderPack); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.OrderPack)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.RetrieveOrderPacksByOrdersParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveOrderQuantityHistogram provides a mock function with given fields: ctx, arg
+func (_m *Querier) RetrieveOrderQuantityHistogram(ctx context.Context, arg repository.RetrieveOrderQuantityHistogramParams) ([]repository.RetrieveOrderQuantityHistogramRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveOrderQuantityHistogram")
+	}
+
+	var r0 []repository.RetrieveOrderQuantityHistogramRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrieveOrderQuantityHistogramParams) ([]repository.RetrieveOrderQuantityHistogramRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrieveOrderQuantityHistogramParams) []repository.RetrieveOrderQuantityHistogramRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.RetrieveOrderQuantityHistogramRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.RetrieveOrderQuantityHistogramParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveOrderTotals provides a mock function with given fields: ctx, arg
+func (_m *Querier) RetrieveOrderTotals(ctx context.Context, arg repository.RetrieveOrderTotalsParams) (repository.RetrieveOrderTotalsRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveOrderTotals")
+	}
+
+	var r0 repository.RetrieveOrderTotalsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrieveOrderTotalsParams) (repository.RetrieveOrderTotalsRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrieveOrderTotalsParams) repository.RetrieveOrderTotalsRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.RetrieveOrderTotalsRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.RetrieveOrderTotalsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveOrders provides a mock function with given fields: ctx, tenantID
+func (_m *Querier) RetrieveOrders(ctx context.Context, tenantID string) ([]repository.Order, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveOrders")
+	}
+
+	var r0 []repository.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]repository.Order, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []repository.Order); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveOrdersForRecalculation provides a mock function with given fields: ctx, arg
+func (_m *Querier) RetrieveOrdersForRecalculation(ctx context.Context, arg repository.RetrieveOrdersForRecalculationParams) ([]repository.Order, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveOrdersForRecalculation")
+	}
+
+	var r0 []repository.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrieveOrdersForRecalculationParams) ([]repository.Order, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrieveOrdersForRecalculationParams) []repository.Order); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.RetrieveOrdersForRecalculationParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveOrdersPage provides a mock function with given fields: ctx, arg
+func (_m *Querier) RetrieveOrdersPage(ctx context.Context, arg repository.RetrieveOrdersPageParams) ([]repository.Order, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveOrdersPage")
+	}
+
+	var r0 []repository.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrieveOrdersPageParams) ([]repository.Order, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrieveOrdersPageParams) []repository.Order); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.RetrieveOrdersPageParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrievePackAudits provides a mock function with given fields: ctx, arg
+func (_m *Querier) RetrievePackAudits(ctx context.Context, arg repository.RetrievePackAuditsParams) ([]repository.PackAudit, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrievePackAudits")
+	}
+
+	var r0 []repository.PackAudit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrievePackAuditsParams) ([]repository.PackAudit, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrievePackAuditsParams) []repository.PackAudit); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.PackAudit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.RetrievePackAuditsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrievePackPrices provides a mock function with given fields: ctx, arg
+func (_m *Querier) RetrievePackPrices(ctx context.Context, arg repository.RetrievePackPricesParams) ([]repository.RetrievePackPricesRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrievePackPrices")
+	}
+
+	var r0 []repository.RetrievePackPricesRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrievePackPricesParams) ([]repository.RetrievePackPricesRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrievePackPricesParams) []repository.RetrievePackPricesRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.RetrievePackPricesRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.RetrievePackPricesParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrievePackProfiles provides a mock function with given fields: ctx, tenantID
+func (_m *Querier) RetrievePackProfiles(ctx context.Context, tenantID string) ([]repository.PackProfile, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrievePackProfiles")
+	}
+
+	var r0 []repository.PackProfile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]repository.PackProfile, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []repository.PackProfile); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.PackProfile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrievePackSchedule provides a mock function with given fields: ctx, arg
+func (_m *Querier) RetrievePackSchedule(ctx context.Context, arg repository.RetrievePackScheduleParams) ([]repository.Pack, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrievePackSchedule")
+	}
+
+	var r0 []repository.Pack
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrievePackScheduleParams) ([]repository.Pack, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrievePackScheduleParams) []repository.Pack); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.Pack)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.RetrievePackScheduleParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrievePackSpecifications provides a mock function with given fields: ctx, arg
+func (_m *Querier) RetrievePackSpecifications(ctx context.Context, arg repository.RetrievePackSpecificationsParams) ([]repository.Pack, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrievePackSpecifications")
+	}
+
+	var r0 []repository.Pack
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrievePackSpecificationsParams) ([]repository.Pack, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrievePackSpecificationsParams) []repository.Pack); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.Pack)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.RetrievePackSpecificationsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrievePackUsage provides a mock function with given fields: ctx, arg
+func (_m *Querier) RetrievePackUsage(ctx context.Context, arg repository.RetrievePackUsageParams) ([]repository.RetrievePackUsageRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrievePackUsage")
+	}
+
+	var r0 []repository.RetrievePackUsageRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrievePackUsageParams) ([]repository.RetrievePackUsageRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrievePackUsageParams) []repository.RetrievePackUsageRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.RetrievePackUsageRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.RetrievePackUsageParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrievePacks provides a mock function with given fields: ctx, arg
+func (_m *Querier) RetrievePacks(ctx context.Context, arg repository.RetrievePacksParams) ([]int32, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrievePacks")
+	}
+
+	var r0 []int32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrievePacksParams) ([]int32, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrievePacksParams) []int32); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int32)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.RetrievePacksParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveQuoteById provides a mock function with given fields: ctx, arg
+func (_m *Querier) RetrieveQuoteById(ctx context.Context, arg repository.RetrieveQuoteByIdParams) (repository.Quote, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveQuoteById")
+	}
+
+	var r0 repository.Quote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrieveQuoteByIdParams) (repository.Quote, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrieveQuoteByIdParams) repository.Quote); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.Quote)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.RetrieveQuoteByIdParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveQuotePacksByQuote provides a mock function with given fields: ctx, arg
+func (_m *Querier) RetrieveQuotePacksByQuote(ctx context.Context, arg repository.RetrieveQuotePacksByQuoteParams) ([]repository.QuotePack, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveQuotePacksByQuote")
+	}
+
+	var r0 []repository.QuotePack
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrieveQuotePacksByQuoteParams) ([]repository.QuotePack, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RetrieveQuotePacksByQuoteParams) []repository.QuotePack); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.QuotePack)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.RetrieveQuotePacksByQuoteParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrieveSchemaVersion provides a mock function with given fields: ctx
+func (_m *Querier) RetrieveSchemaVersion(ctx context.Context) (int32, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveSchemaVersion")
+	}
+
+	var r0 int32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int32, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int32); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeApiKey provides a mock function with given fields: ctx, arg
+func (_m *Querier) RevokeApiKey(ctx context.Context, arg repository.RevokeApiKeyParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeApiKey")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RevokeApiKeyParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.RevokeApiKeyParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.RevokeApiKeyParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SchedulePack provides a mock function with given fields: ctx, arg
+func (_m *Querier) SchedulePack(ctx context.Context, arg repository.SchedulePackParams) (repository.Pack, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SchedulePack")
+	}
+
+	var r0 repository.Pack
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.SchedulePackParams) (repository.Pack, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.SchedulePackParams) repository.Pack); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.Pack)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.SchedulePackParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateOrderPackSet provides a mock function with given fields: ctx, arg
+func (_m *Querier) UpdateOrderPackSet(ctx context.Context, arg repository.UpdateOrderPackSetParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOrderPackSet")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.UpdateOrderPackSetParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateOrderQuantity provides a mock function with given fields: ctx, arg
+func (_m *Querier) UpdateOrderQuantity(ctx context.Context, arg repository.UpdateOrderQuantityParams) (repository.Order, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOrderQuantity")
+	}
+
+	var r0 repository.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.UpdateOrderQuantityParams) (repository.Order, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.UpdateOrderQuantityParams) repository.Order); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.Order)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.UpdateOrderQuantityParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateOrderStatus provides a mock function with given fields: ctx, arg
+func (_m *Querier) UpdateOrderStatus(ctx context.Context, arg repository.UpdateOrderStatusParams) (repository.Order, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOrderStatus")
+	}
+
+	var r0 repository.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.UpdateOrderStatusParams) (repository.Order, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.UpdateOrderStatusParams) repository.Order); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.Order)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.UpdateOrderStatusParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePackPrice provides a mock function with given fields: ctx, arg
+func (_m *Querier) UpdatePackPrice(ctx context.Context, arg repository.UpdatePackPriceParams) (repository.Pack, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePackPrice")
+	}
+
+	var r0 repository.Pack
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.UpdatePackPriceParams) (repository.Pack, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.UpdatePackPriceParams) repository.Pack); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.Pack)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.UpdatePackPriceParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePackSpecification provides a mock function with given fields: ctx, arg
+func (_m *Querier) UpdatePackSpecification(ctx context.Context, arg repository.UpdatePackSpecificationParams) (repository.Pack, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePackSpecification")
+	}
+
+	var r0 repository.Pack
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.UpdatePackSpecificationParams) (repository.Pack, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.UpdatePackSpecificationParams) repository.Pack); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.Pack)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.UpdatePackSpecificationParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
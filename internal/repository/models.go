@@ -5,12 +5,54 @@
 package repository
 
 import (
+	"database/sql"
+	"time"
+
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
+type ApiKey struct {
+	ApiKeyID  uuid.UUID
+	Name      string
+	KeyHash   string
+	Role      string
+	CreatedAt time.Time
+	RevokedAt sql.NullTime
+	TenantID  sql.NullString
+}
+
+type ContainerType struct {
+	Name       string
+	PackSize   sql.NullInt32
+	ChildName  sql.NullString
+	Capacities []int32
+	CreatedAt  time.Time
+	TenantID   string
+}
+
+type DiscountTier struct {
+	MinQuantity int32
+	Percent     decimal.Decimal
+	CreatedAt   time.Time
+	TenantID    string
+}
+
 type Order struct {
 	OrderID       uuid.UUID
 	OrderQuantity int32
+	Status        string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	CalculatedAt  sql.NullTime
+	PickedAt      sql.NullTime
+	PackedAt      sql.NullTime
+	ShippedAt     sql.NullTime
+	CancelledAt   sql.NullTime
+	PackSet       []int32
+	Profile       string
+	TenantID      string
+	OrderDate     time.Time
 }
 
 type OrderPack struct {
@@ -18,8 +60,93 @@ type OrderPack struct {
 	OrderID      uuid.UUID
 	PackSize     int32
 	PackQuantity int32
+	TenantID     string
+}
+
+type OrderPrice struct {
+	OrderID         uuid.UUID
+	Currency        string
+	Subtotal        decimal.Decimal
+	DiscountPercent decimal.Decimal
+	Discount        decimal.Decimal
+	Total           decimal.Decimal
+	TenantID        string
+}
+
+type OrderPriceLine struct {
+	OrderID      uuid.UUID
+	PackSize     int32
+	PackQuantity int32
+	UnitPrice    decimal.Decimal
+	LineTotal    decimal.Decimal
+	TenantID     string
+}
+
+type OrderShipment struct {
+	OrderID        uuid.UUID
+	ShipmentNumber int32
+	PackSize       int32
+	PackQuantity   int32
+	TenantID       string
 }
 
 type Pack struct {
-	PackSize int32
+	PackSize       int32
+	MaxWeightGrams sql.NullInt32
+	LengthMm       sql.NullInt32
+	WidthMm        sql.NullInt32
+	HeightMm       sql.NullInt32
+	Price          decimal.NullDecimal
+	Profile        string
+	TenantID       string
+	ValidFrom      sql.NullTime
+	ValidTo        sql.NullTime
+	PriceCurrency  sql.NullString
+}
+
+type PackAudit struct {
+	AuditID   uuid.UUID
+	Actor     string
+	Action    string
+	PackSize  int32
+	RequestID string
+	CreatedAt time.Time
+	Profile   string
+	TenantID  string
+}
+
+type PackProfile struct {
+	Name      string
+	CreatedAt time.Time
+	TenantID  string
+}
+
+type Quote struct {
+	QuoteID         uuid.UUID
+	QuoteQuantity   int32
+	PackSet         []int32
+	Currency        sql.NullString
+	Subtotal        decimal.NullDecimal
+	DiscountPercent decimal.NullDecimal
+	Discount        decimal.NullDecimal
+	Total           decimal.NullDecimal
+	OrderID         uuid.NullUUID
+	ExpiresAt       time.Time
+	CreatedAt       time.Time
+	Profile         string
+	TenantID        string
+}
+
+type QuotePack struct {
+	QuoteID      uuid.UUID
+	PackSize     int32
+	PackQuantity int32
+	UnitPrice    decimal.NullDecimal
+	LineTotal    decimal.NullDecimal
+	TenantID     string
+}
+
+type SchemaMigration struct {
+	Version   int32
+	AppliedAt time.Time
 }
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// Channel notified, on commit, by the transactions that change the pack set
+const PackSetChangedChannel = "pack_set_changed"
+
+const (
+	listenerMinReconnectInterval = time.Second
+	listenerMaxReconnectInterval = time.Minute
+	listenerPingInterval         = 90 * time.Second
+)
+
+// Listen on the channel with a dedicated connection and call onNotification for every notification, until ctx is
+// done. Notifications sent while the connection is down are lost, so onNotification is also called after
+// reconnecting.
+func ListenForNotifications(ctx context.Context, connectionString string, channel string, onNotification func()) error {
+	listener := pq.NewListener(connectionString, listenerMinReconnectInterval, listenerMaxReconnectInterval, nil)
+	defer listener.Close()
+
+	if listenErr := listener.Listen(channel); listenErr != nil {
+		return errors.Wrap(listenErr, fmt.Sprintf("could not listen on channel [%v]", channel))
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		// A nil notification is sent once the connection is re-established
+		case <-listener.Notify:
+			onNotification()
+		// Ping now and then so a dead connection is noticed and re-established
+		case <-time.After(listenerPingInterval):
+			go listener.Ping()
+		}
+	}
+}
@@ -6,19 +6,63 @@ package repository
 
 import (
 	"context"
-
-	"github.com/google/uuid"
+	"time"
 )
 
 type Querier interface {
+	AcceptQuote(ctx context.Context, arg AcceptQuoteParams) (int64, error)
+	AddApiKey(ctx context.Context, arg AddApiKeyParams) (ApiKey, error)
+	AddContainerType(ctx context.Context, arg AddContainerTypeParams) (ContainerType, error)
+	AddDiscountTier(ctx context.Context, arg AddDiscountTierParams) (DiscountTier, error)
 	AddOrder(ctx context.Context, arg AddOrderParams) error
 	AddOrderPack(ctx context.Context, arg AddOrderPackParams) error
-	AddPack(ctx context.Context, packSize int32) error
-	RemovePackBySize(ctx context.Context, packSize int32) error
-	RetrieveOrderById(ctx context.Context, orderID uuid.UUID) (Order, error)
-	RetrieveOrderPacksByOrder(ctx context.Context, orderID uuid.UUID) ([]RetrieveOrderPacksByOrderRow, error)
-	RetrieveOrders(ctx context.Context) ([]Order, error)
-	RetrievePacks(ctx context.Context) ([]int32, error)
+	AddOrderPrice(ctx context.Context, arg AddOrderPriceParams) error
+	AddOrderPriceLine(ctx context.Context, arg AddOrderPriceLineParams) error
+	AddOrderShipment(ctx context.Context, arg AddOrderShipmentParams) error
+	AddPack(ctx context.Context, arg AddPackParams) error
+	AddPackAudit(ctx context.Context, arg AddPackAuditParams) error
+	AddPackProfile(ctx context.Context, arg AddPackProfileParams) (PackProfile, error)
+	AddQuote(ctx context.Context, arg AddQuoteParams) error
+	AddQuotePack(ctx context.Context, arg AddQuotePackParams) error
+	CountPacks(ctx context.Context) (int64, error)
+	ExistsApiKeyByHash(ctx context.Context, keyHash string) (bool, error)
+	NotifyPackSetChanged(ctx context.Context) error
+	RemoveContainerTypeByName(ctx context.Context, arg RemoveContainerTypeByNameParams) (int64, error)
+	RemoveDiscountTierByMinQuantity(ctx context.Context, arg RemoveDiscountTierByMinQuantityParams) (int64, error)
+	RemoveExpiredQuotes(ctx context.Context, expiredBefore time.Time) (int64, error)
+	RemoveOrderPacksByOrder(ctx context.Context, arg RemoveOrderPacksByOrderParams) error
+	RemoveOrderPriceByOrder(ctx context.Context, arg RemoveOrderPriceByOrderParams) error
+	RemoveOrderShipmentsByOrder(ctx context.Context, arg RemoveOrderShipmentsByOrderParams) error
+	RemovePackBySize(ctx context.Context, arg RemovePackBySizeParams) (int64, error)
+	RetrieveActiveApiKeyByHash(ctx context.Context, keyHash string) (ApiKey, error)
+	RetrieveApiKeys(ctx context.Context, arg RetrieveApiKeysParams) ([]ApiKey, error)
+	RetrieveContainerTypes(ctx context.Context, tenantID string) ([]ContainerType, error)
+	RetrieveDiscountTiers(ctx context.Context, tenantID string) ([]DiscountTier, error)
+	RetrieveOrderById(ctx context.Context, arg RetrieveOrderByIdParams) (Order, error)
+	RetrieveOrderPacksByOrder(ctx context.Context, arg RetrieveOrderPacksByOrderParams) ([]RetrieveOrderPacksByOrderRow, error)
+	RetrieveOrderPacksByOrders(ctx context.Context, arg RetrieveOrderPacksByOrdersParams) ([]OrderPack, error)
+	RetrieveOrderQuantityHistogram(ctx context.Context, arg RetrieveOrderQuantityHistogramParams) ([]RetrieveOrderQuantityHistogramRow, error)
+	RetrieveOrderTotals(ctx context.Context, arg RetrieveOrderTotalsParams) (RetrieveOrderTotalsRow, error)
+	RetrieveOrders(ctx context.Context, tenantID string) ([]Order, error)
+	RetrieveOrdersForRecalculation(ctx context.Context, arg RetrieveOrdersForRecalculationParams) ([]Order, error)
+	RetrieveOrdersPage(ctx context.Context, arg RetrieveOrdersPageParams) ([]Order, error)
+	RetrievePackAudits(ctx context.Context, arg RetrievePackAuditsParams) ([]PackAudit, error)
+	RetrievePackPrices(ctx context.Context, arg RetrievePackPricesParams) ([]RetrievePackPricesRow, error)
+	RetrievePackProfiles(ctx context.Context, tenantID string) ([]PackProfile, error)
+	RetrievePackSchedule(ctx context.Context, arg RetrievePackScheduleParams) ([]Pack, error)
+	RetrievePackSpecifications(ctx context.Context, arg RetrievePackSpecificationsParams) ([]Pack, error)
+	RetrievePackUsage(ctx context.Context, arg RetrievePackUsageParams) ([]RetrievePackUsageRow, error)
+	RetrievePacks(ctx context.Context, arg RetrievePacksParams) ([]int32, error)
+	RetrieveQuoteById(ctx context.Context, arg RetrieveQuoteByIdParams) (Quote, error)
+	RetrieveQuotePacksByQuote(ctx context.Context, arg RetrieveQuotePacksByQuoteParams) ([]QuotePack, error)
+	RetrieveSchemaVersion(ctx context.Context) (int32, error)
+	RevokeApiKey(ctx context.Context, arg RevokeApiKeyParams) (int64, error)
+	SchedulePack(ctx context.Context, arg SchedulePackParams) (Pack, error)
+	UpdateOrderPackSet(ctx context.Context, arg UpdateOrderPackSetParams) error
+	UpdateOrderQuantity(ctx context.Context, arg UpdateOrderQuantityParams) (Order, error)
+	UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) (Order, error)
+	UpdatePackPrice(ctx context.Context, arg UpdatePackPriceParams) (Pack, error)
+	UpdatePackSpecification(ctx context.Context, arg UpdatePackSpecificationParams) (Pack, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -7,26 +7,148 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
 )
 
+const acceptQuote = `-- name: AcceptQuote :execrows
+update public.quote set order_id = $2
+where public.quote.quote_id = $1 and public.quote.tenant_id = $3 and public.quote.order_id is null and public.quote.expires_at > now()
+`
+
+type AcceptQuoteParams struct {
+	QuoteID  uuid.UUID
+	OrderID  uuid.NullUUID
+	TenantID string
+}
+
+func (q *Queries) AcceptQuote(ctx context.Context, arg AcceptQuoteParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, acceptQuote, arg.QuoteID, arg.OrderID, arg.TenantID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const addApiKey = `-- name: AddApiKey :one
+insert into public.api_key (api_key_id, name, key_hash, role, tenant_id) values ($1, $2, $3, $4, $5)
+returning api_key_id, name, key_hash, role, created_at, revoked_at, tenant_id
+`
+
+type AddApiKeyParams struct {
+	ApiKeyID uuid.UUID
+	Name     string
+	KeyHash  string
+	Role     string
+	TenantID sql.NullString
+}
+
+func (q *Queries) AddApiKey(ctx context.Context, arg AddApiKeyParams) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, addApiKey,
+		arg.ApiKeyID,
+		arg.Name,
+		arg.KeyHash,
+		arg.Role,
+		arg.TenantID,
+	)
+	var i ApiKey
+	err := row.Scan(
+		&i.ApiKeyID,
+		&i.Name,
+		&i.KeyHash,
+		&i.Role,
+		&i.CreatedAt,
+		&i.RevokedAt,
+		&i.TenantID,
+	)
+	return i, err
+}
+
+const addContainerType = `-- name: AddContainerType :one
+insert into public.container_type (name, pack_size, child_name, capacities, tenant_id) values ($1, $2, $3, $4, $5)
+returning name, pack_size, child_name, capacities, created_at, tenant_id
+`
+
+type AddContainerTypeParams struct {
+	Name       string
+	PackSize   sql.NullInt32
+	ChildName  sql.NullString
+	Capacities []int32
+	TenantID   string
+}
+
+func (q *Queries) AddContainerType(ctx context.Context, arg AddContainerTypeParams) (ContainerType, error) {
+	row := q.db.QueryRowContext(ctx, addContainerType,
+		arg.Name,
+		arg.PackSize,
+		arg.ChildName,
+		pq.Array(arg.Capacities),
+		arg.TenantID,
+	)
+	var i ContainerType
+	err := row.Scan(
+		&i.Name,
+		&i.PackSize,
+		&i.ChildName,
+		pq.Array(&i.Capacities),
+		&i.CreatedAt,
+		&i.TenantID,
+	)
+	return i, err
+}
+
+const addDiscountTier = `-- name: AddDiscountTier :one
+insert into public.discount_tier (min_quantity, percent, tenant_id) values ($1, $2, $3)
+returning min_quantity, percent, created_at, tenant_id
+`
+
+type AddDiscountTierParams struct {
+	MinQuantity int32
+	Percent     decimal.Decimal
+	TenantID    string
+}
+
+func (q *Queries) AddDiscountTier(ctx context.Context, arg AddDiscountTierParams) (DiscountTier, error) {
+	row := q.db.QueryRowContext(ctx, addDiscountTier, arg.MinQuantity, arg.Percent, arg.TenantID)
+	var i DiscountTier
+	err := row.Scan(
+		&i.MinQuantity,
+		&i.Percent,
+		&i.CreatedAt,
+		&i.TenantID,
+	)
+	return i, err
+}
+
 const addOrder = `-- name: AddOrder :exec
-insert into public.order (order_id, order_quantity) values ($1, $2)
+insert into public.order (order_id, order_quantity, profile, tenant_id, order_date) values ($1, $2, $3, $4, coalesce($5::timestamptz, now()))
 `
 
 type AddOrderParams struct {
 	OrderID       uuid.UUID
 	OrderQuantity int32
+	Profile       string
+	TenantID      string
+	OrderDate     sql.NullTime
 }
 
 func (q *Queries) AddOrder(ctx context.Context, arg AddOrderParams) error {
-	_, err := q.db.ExecContext(ctx, addOrder, arg.OrderID, arg.OrderQuantity)
+	_, err := q.db.ExecContext(ctx, addOrder,
+		arg.OrderID,
+		arg.OrderQuantity,
+		arg.Profile,
+		arg.TenantID,
+		arg.OrderDate,
+	)
 	return err
 }
 
 const addOrderPack = `-- name: AddOrderPack :exec
-insert into public.order_packs (order_packs_id, order_id, pack_size, pack_quantity) values ($1, $2, $3, $4)
+insert into public.order_packs (order_packs_id, order_id, pack_size, pack_quantity, tenant_id) values ($1, $2, $3, $4, $5)
 `
 
 type AddOrderPackParams struct {
@@ -34,6 +156,7 @@ type AddOrderPackParams struct {
 	OrderID      uuid.UUID
 	PackSize     int32
 	PackQuantity int32
+	TenantID     string
 }
 
 func (q *Queries) AddOrderPack(ctx context.Context, arg AddOrderPackParams) error {
@@ -42,72 +165,425 @@ func (q *Queries) AddOrderPack(ctx context.Context, arg AddOrderPackParams) erro
 		arg.OrderID,
 		arg.PackSize,
 		arg.PackQuantity,
+		arg.TenantID,
+	)
+	return err
+}
+
+const addOrderPrice = `-- name: AddOrderPrice :exec
+insert into public.order_price (order_id, currency, subtotal, discount_percent, discount, total, tenant_id) values ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type AddOrderPriceParams struct {
+	OrderID         uuid.UUID
+	Currency        string
+	Subtotal        decimal.Decimal
+	DiscountPercent decimal.Decimal
+	Discount        decimal.Decimal
+	Total           decimal.Decimal
+	TenantID        string
+}
+
+func (q *Queries) AddOrderPrice(ctx context.Context, arg AddOrderPriceParams) error {
+	_, err := q.db.ExecContext(ctx, addOrderPrice,
+		arg.OrderID,
+		arg.Currency,
+		arg.Subtotal,
+		arg.DiscountPercent,
+		arg.Discount,
+		arg.Total,
+		arg.TenantID,
+	)
+	return err
+}
+
+const addOrderPriceLine = `-- name: AddOrderPriceLine :exec
+insert into public.order_price_line (order_id, pack_size, pack_quantity, unit_price, line_total, tenant_id) values ($1, $2, $3, $4, $5, $6)
+`
+
+type AddOrderPriceLineParams struct {
+	OrderID      uuid.UUID
+	PackSize     int32
+	PackQuantity int32
+	UnitPrice    decimal.Decimal
+	LineTotal    decimal.Decimal
+	TenantID     string
+}
+
+func (q *Queries) AddOrderPriceLine(ctx context.Context, arg AddOrderPriceLineParams) error {
+	_, err := q.db.ExecContext(ctx, addOrderPriceLine,
+		arg.OrderID,
+		arg.PackSize,
+		arg.PackQuantity,
+		arg.UnitPrice,
+		arg.LineTotal,
+		arg.TenantID,
+	)
+	return err
+}
+
+const addOrderShipment = `-- name: AddOrderShipment :exec
+insert into public.order_shipment (order_id, shipment_number, pack_size, pack_quantity, tenant_id) values ($1, $2, $3, $4, $5)
+`
+
+type AddOrderShipmentParams struct {
+	OrderID        uuid.UUID
+	ShipmentNumber int32
+	PackSize       int32
+	PackQuantity   int32
+	TenantID       string
+}
+
+func (q *Queries) AddOrderShipment(ctx context.Context, arg AddOrderShipmentParams) error {
+	_, err := q.db.ExecContext(ctx, addOrderShipment,
+		arg.OrderID,
+		arg.ShipmentNumber,
+		arg.PackSize,
+		arg.PackQuantity,
+		arg.TenantID,
 	)
 	return err
 }
 
 const addPack = `-- name: AddPack :exec
-insert into pack (pack_size) values ($1)
+insert into pack (profile, pack_size, tenant_id) values ($1, $2, $3)
 `
 
-func (q *Queries) AddPack(ctx context.Context, packSize int32) error {
-	_, err := q.db.ExecContext(ctx, addPack, packSize)
+type AddPackParams struct {
+	Profile  string
+	PackSize int32
+	TenantID string
+}
+
+func (q *Queries) AddPack(ctx context.Context, arg AddPackParams) error {
+	_, err := q.db.ExecContext(ctx, addPack, arg.Profile, arg.PackSize, arg.TenantID)
 	return err
 }
 
-const removePackBySize = `-- name: RemovePackBySize :exec
-delete from public.pack where public.pack.pack_size = $1
+const addPackAudit = `-- name: AddPackAudit :exec
+insert into public.pack_audit (audit_id, actor, action, pack_size, request_id, profile, tenant_id) values ($1, $2, $3, $4, $5, $6, $7)
 `
 
-func (q *Queries) RemovePackBySize(ctx context.Context, packSize int32) error {
-	_, err := q.db.ExecContext(ctx, removePackBySize, packSize)
+type AddPackAuditParams struct {
+	AuditID   uuid.UUID
+	Actor     string
+	Action    string
+	PackSize  int32
+	RequestID string
+	Profile   string
+	TenantID  string
+}
+
+func (q *Queries) AddPackAudit(ctx context.Context, arg AddPackAuditParams) error {
+	_, err := q.db.ExecContext(ctx, addPackAudit,
+		arg.AuditID,
+		arg.Actor,
+		arg.Action,
+		arg.PackSize,
+		arg.RequestID,
+		arg.Profile,
+		arg.TenantID,
+	)
 	return err
 }
 
-const retrieveOrderById = `-- name: RetrieveOrderById :one
-select order_id, order_quantity from public.order where public.order.order_id = $1
+const addPackProfile = `-- name: AddPackProfile :one
+insert into public.pack_profile (name, tenant_id) values ($1, $2)
+returning name, created_at, tenant_id
 `
 
-func (q *Queries) RetrieveOrderById(ctx context.Context, orderID uuid.UUID) (Order, error) {
-	row := q.db.QueryRowContext(ctx, retrieveOrderById, orderID)
-	var i Order
-	err := row.Scan(&i.OrderID, &i.OrderQuantity)
+type AddPackProfileParams struct {
+	Name     string
+	TenantID string
+}
+
+func (q *Queries) AddPackProfile(ctx context.Context, arg AddPackProfileParams) (PackProfile, error) {
+	row := q.db.QueryRowContext(ctx, addPackProfile, arg.Name, arg.TenantID)
+	var i PackProfile
+	err := row.Scan(&i.Name, &i.CreatedAt, &i.TenantID)
 	return i, err
 }
 
-const retrieveOrderPacksByOrder = `-- name: RetrieveOrderPacksByOrder :many
-select
-s.order_packs_id,
-o.order_quantity,
-p.pack_size,
-s.pack_quantity
-from public.order_packs s
-inner join public.order o on s.order_id = o.order_id
-inner join public.pack p on s.pack_id = p.pack_id
-where o.order_id = $1
+const addQuote = `-- name: AddQuote :exec
+insert into public.quote (quote_id, quote_quantity, pack_set, currency, subtotal, discount_percent, discount, total, expires_at, profile, tenant_id)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 `
 
-type RetrieveOrderPacksByOrderRow struct {
-	OrderPacksID  uuid.UUID
-	OrderQuantity int32
-	PackSize      int32
-	PackQuantity  int32
+type AddQuoteParams struct {
+	QuoteID         uuid.UUID
+	QuoteQuantity   int32
+	PackSet         []int32
+	Currency        sql.NullString
+	Subtotal        decimal.NullDecimal
+	DiscountPercent decimal.NullDecimal
+	Discount        decimal.NullDecimal
+	Total           decimal.NullDecimal
+	ExpiresAt       time.Time
+	Profile         string
+	TenantID        string
+}
+
+func (q *Queries) AddQuote(ctx context.Context, arg AddQuoteParams) error {
+	_, err := q.db.ExecContext(ctx, addQuote,
+		arg.QuoteID,
+		arg.QuoteQuantity,
+		pq.Array(arg.PackSet),
+		arg.Currency,
+		arg.Subtotal,
+		arg.DiscountPercent,
+		arg.Discount,
+		arg.Total,
+		arg.ExpiresAt,
+		arg.Profile,
+		arg.TenantID,
+	)
+	return err
+}
+
+const addQuotePack = `-- name: AddQuotePack :exec
+insert into public.quote_pack (quote_id, pack_size, pack_quantity, unit_price, line_total, tenant_id) values ($1, $2, $3, $4, $5, $6)
+`
+
+type AddQuotePackParams struct {
+	QuoteID      uuid.UUID
+	PackSize     int32
+	PackQuantity int32
+	UnitPrice    decimal.NullDecimal
+	LineTotal    decimal.NullDecimal
+	TenantID     string
+}
+
+func (q *Queries) AddQuotePack(ctx context.Context, arg AddQuotePackParams) error {
+	_, err := q.db.ExecContext(ctx, addQuotePack,
+		arg.QuoteID,
+		arg.PackSize,
+		arg.PackQuantity,
+		arg.UnitPrice,
+		arg.LineTotal,
+		arg.TenantID,
+	)
+	return err
+}
+
+const countPacks = `-- name: CountPacks :one
+select count(*) from public.pack
+`
+
+func (q *Queries) CountPacks(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countPacks)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const existsApiKeyByHash = `-- name: ExistsApiKeyByHash :one
+select exists(select 1 from public.api_key where public.api_key.key_hash = $1)
+`
+
+func (q *Queries) ExistsApiKeyByHash(ctx context.Context, keyHash string) (bool, error) {
+	row := q.db.QueryRowContext(ctx, existsApiKeyByHash, keyHash)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const notifyPackSetChanged = `-- name: NotifyPackSetChanged :exec
+select pg_notify('pack_set_changed', '')
+`
+
+func (q *Queries) NotifyPackSetChanged(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, notifyPackSetChanged)
+	return err
+}
+
+const removeContainerTypeByName = `-- name: RemoveContainerTypeByName :execrows
+delete from public.container_type where public.container_type.name = $1 and public.container_type.tenant_id = $2
+`
+
+type RemoveContainerTypeByNameParams struct {
+	Name     string
+	TenantID string
+}
+
+func (q *Queries) RemoveContainerTypeByName(ctx context.Context, arg RemoveContainerTypeByNameParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, removeContainerTypeByName, arg.Name, arg.TenantID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const removeDiscountTierByMinQuantity = `-- name: RemoveDiscountTierByMinQuantity :execrows
+delete from public.discount_tier where public.discount_tier.min_quantity = $1 and public.discount_tier.tenant_id = $2
+`
+
+type RemoveDiscountTierByMinQuantityParams struct {
+	MinQuantity int32
+	TenantID    string
+}
+
+func (q *Queries) RemoveDiscountTierByMinQuantity(ctx context.Context, arg RemoveDiscountTierByMinQuantityParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, removeDiscountTierByMinQuantity, arg.MinQuantity, arg.TenantID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const removeExpiredQuotes = `-- name: RemoveExpiredQuotes :execrows
+delete from public.quote where public.quote.expires_at <= $1
+`
+
+func (q *Queries) RemoveExpiredQuotes(ctx context.Context, expiredBefore time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, removeExpiredQuotes, expiredBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const removeOrderPacksByOrder = `-- name: RemoveOrderPacksByOrder :exec
+delete from public.order_packs where public.order_packs.order_id = $1 and public.order_packs.tenant_id = $2
+`
+
+type RemoveOrderPacksByOrderParams struct {
+	OrderID  uuid.UUID
+	TenantID string
+}
+
+func (q *Queries) RemoveOrderPacksByOrder(ctx context.Context, arg RemoveOrderPacksByOrderParams) error {
+	_, err := q.db.ExecContext(ctx, removeOrderPacksByOrder, arg.OrderID, arg.TenantID)
+	return err
+}
+
+const removeOrderPriceByOrder = `-- name: RemoveOrderPriceByOrder :exec
+delete from public.order_price where public.order_price.order_id = $1 and public.order_price.tenant_id = $2
+`
+
+type RemoveOrderPriceByOrderParams struct {
+	OrderID  uuid.UUID
+	TenantID string
+}
+
+func (q *Queries) RemoveOrderPriceByOrder(ctx context.Context, arg RemoveOrderPriceByOrderParams) error {
+	_, err := q.db.ExecContext(ctx, removeOrderPriceByOrder, arg.OrderID, arg.TenantID)
+	return err
+}
+
+const removeOrderShipmentsByOrder = `-- name: RemoveOrderShipmentsByOrder :exec
+delete from public.order_shipment where public.order_shipment.order_id = $1 and public.order_shipment.tenant_id = $2
+`
+
+type RemoveOrderShipmentsByOrderParams struct {
+	OrderID  uuid.UUID
+	TenantID string
+}
+
+func (q *Queries) RemoveOrderShipmentsByOrder(ctx context.Context, arg RemoveOrderShipmentsByOrderParams) error {
+	_, err := q.db.ExecContext(ctx, removeOrderShipmentsByOrder, arg.OrderID, arg.TenantID)
+	return err
+}
+
+const removePackBySize = `-- name: RemovePackBySize :execrows
+delete from public.pack where public.pack.profile = $1 and public.pack.pack_size = $2 and public.pack.tenant_id = $3
+`
+
+type RemovePackBySizeParams struct {
+	Profile  string
+	PackSize int32
+	TenantID string
+}
+
+func (q *Queries) RemovePackBySize(ctx context.Context, arg RemovePackBySizeParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, removePackBySize, arg.Profile, arg.PackSize, arg.TenantID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const retrieveActiveApiKeyByHash = `-- name: RetrieveActiveApiKeyByHash :one
+select api_key_id, name, key_hash, role, created_at, revoked_at, tenant_id from public.api_key
+where public.api_key.key_hash = $1 and public.api_key.revoked_at is null
+`
+
+func (q *Queries) RetrieveActiveApiKeyByHash(ctx context.Context, keyHash string) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, retrieveActiveApiKeyByHash, keyHash)
+	var i ApiKey
+	err := row.Scan(
+		&i.ApiKeyID,
+		&i.Name,
+		&i.KeyHash,
+		&i.Role,
+		&i.CreatedAt,
+		&i.RevokedAt,
+		&i.TenantID,
+	)
+	return i, err
+}
+
+const retrieveApiKeys = `-- name: RetrieveApiKeys :many
+select api_key_id, name, key_hash, role, created_at, revoked_at, tenant_id from public.api_key
+where public.api_key.tenant_id = $1 or ($2::boolean and public.api_key.tenant_id is null) ORDER BY created_at
+`
+
+type RetrieveApiKeysParams struct {
+	TenantID       string
+	IncludeUnbound bool
 }
 
-func (q *Queries) RetrieveOrderPacksByOrder(ctx context.Context, orderID uuid.UUID) ([]RetrieveOrderPacksByOrderRow, error) {
-	rows, err := q.db.QueryContext(ctx, retrieveOrderPacksByOrder, orderID)
+func (q *Queries) RetrieveApiKeys(ctx context.Context, arg RetrieveApiKeysParams) ([]ApiKey, error) {
+	rows, err := q.db.QueryContext(ctx, retrieveApiKeys, arg.TenantID, arg.IncludeUnbound)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []RetrieveOrderPacksByOrderRow
+	var items []ApiKey
 	for rows.Next() {
-		var i RetrieveOrderPacksByOrderRow
+		var i ApiKey
 		if err := rows.Scan(
-			&i.OrderPacksID,
-			&i.OrderQuantity,
+			&i.ApiKeyID,
+			&i.Name,
+			&i.KeyHash,
+			&i.Role,
+			&i.CreatedAt,
+			&i.RevokedAt,
+			&i.TenantID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retrieveContainerTypes = `-- name: RetrieveContainerTypes :many
+select name, pack_size, child_name, capacities, created_at, tenant_id from public.container_type
+where public.container_type.tenant_id = $1 ORDER BY name
+`
+
+func (q *Queries) RetrieveContainerTypes(ctx context.Context, tenantID string) ([]ContainerType, error) {
+	rows, err := q.db.QueryContext(ctx, retrieveContainerTypes, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ContainerType
+	for rows.Next() {
+		var i ContainerType
+		if err := rows.Scan(
+			&i.Name,
 			&i.PackSize,
-			&i.PackQuantity,
+			&i.ChildName,
+			pq.Array(&i.Capacities),
+			&i.CreatedAt,
+			&i.TenantID,
 		); err != nil {
 			return nil, err
 		}
@@ -122,20 +598,26 @@ func (q *Queries) RetrieveOrderPacksByOrder(ctx context.Context, orderID uuid.UU
 	return items, nil
 }
 
-const retrieveOrders = `-- name: RetrieveOrders :many
-select order_id, order_quantity from public.order
+const retrieveDiscountTiers = `-- name: RetrieveDiscountTiers :many
+select min_quantity, percent, created_at, tenant_id from public.discount_tier
+where public.discount_tier.tenant_id = $1 ORDER BY min_quantity
 `
 
-func (q *Queries) RetrieveOrders(ctx context.Context) ([]Order, error) {
-	rows, err := q.db.QueryContext(ctx, retrieveOrders)
+func (q *Queries) RetrieveDiscountTiers(ctx context.Context, tenantID string) ([]DiscountTier, error) {
+	rows, err := q.db.QueryContext(ctx, retrieveDiscountTiers, tenantID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Order
+	var items []DiscountTier
 	for rows.Next() {
-		var i Order
-		if err := rows.Scan(&i.OrderID, &i.OrderQuantity); err != nil {
+		var i DiscountTier
+		if err := rows.Scan(
+			&i.MinQuantity,
+			&i.Percent,
+			&i.CreatedAt,
+			&i.TenantID,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -149,23 +631,79 @@ func (q *Queries) RetrieveOrders(ctx context.Context) ([]Order, error) {
 	return items, nil
 }
 
-const retrievePacks = `-- name: RetrievePacks :many
-select pack_size from public.pack ORDER BY pack_size DESC
+const retrieveOrderById = `-- name: RetrieveOrderById :one
+select order_id, order_quantity, status, created_at, updated_at, calculated_at, picked_at, packed_at, shipped_at, cancelled_at, pack_set, profile, tenant_id, order_date from public.order
+where public.order.order_id = $1 and public.order.tenant_id = $2
+`
+
+type RetrieveOrderByIdParams struct {
+	OrderID  uuid.UUID
+	TenantID string
+}
+
+func (q *Queries) RetrieveOrderById(ctx context.Context, arg RetrieveOrderByIdParams) (Order, error) {
+	row := q.db.QueryRowContext(ctx, retrieveOrderById, arg.OrderID, arg.TenantID)
+	var i Order
+	err := row.Scan(
+		&i.OrderID,
+		&i.OrderQuantity,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CalculatedAt,
+		&i.PickedAt,
+		&i.PackedAt,
+		&i.ShippedAt,
+		&i.CancelledAt,
+		pq.Array(&i.PackSet),
+		&i.Profile,
+		&i.TenantID,
+		&i.OrderDate,
+	)
+	return i, err
+}
+
+const retrieveOrderPacksByOrder = `-- name: RetrieveOrderPacksByOrder :many
+select
+s.order_packs_id,
+o.order_quantity,
+s.pack_size,
+s.pack_quantity
+from public.order_packs s
+inner join public.order o on s.order_id = o.order_id
+where o.order_id = $1 and o.tenant_id = $2
 `
 
-func (q *Queries) RetrievePacks(ctx context.Context) ([]int32, error) {
-	rows, err := q.db.QueryContext(ctx, retrievePacks)
+type RetrieveOrderPacksByOrderParams struct {
+	OrderID  uuid.UUID
+	TenantID string
+}
+
+type RetrieveOrderPacksByOrderRow struct {
+	OrderPacksID  uuid.UUID
+	OrderQuantity int32
+	PackSize      int32
+	PackQuantity  int32
+}
+
+func (q *Queries) RetrieveOrderPacksByOrder(ctx context.Context, arg RetrieveOrderPacksByOrderParams) ([]RetrieveOrderPacksByOrderRow, error) {
+	rows, err := q.db.QueryContext(ctx, retrieveOrderPacksByOrder, arg.OrderID, arg.TenantID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []int32
+	var items []RetrieveOrderPacksByOrderRow
 	for rows.Next() {
-		var pack_size int32
-		if err := rows.Scan(&pack_size); err != nil {
+		var i RetrieveOrderPacksByOrderRow
+		if err := rows.Scan(
+			&i.OrderPacksID,
+			&i.OrderQuantity,
+			&i.PackSize,
+			&i.PackQuantity,
+		); err != nil {
 			return nil, err
 		}
-		items = append(items, pack_size)
+		items = append(items, i)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -175,3 +713,946 @@ func (q *Queries) RetrievePacks(ctx context.Context) ([]int32, error) {
 	}
 	return items, nil
 }
+
+const retrieveOrderPacksByOrders = `-- name: RetrieveOrderPacksByOrders :many
+select order_packs_id, order_id, pack_size, pack_quantity, tenant_id from public.order_packs
+where order_id = any($1::uuid[]) and tenant_id = $2
+ORDER BY order_id, pack_size DESC
+`
+
+type RetrieveOrderPacksByOrdersParams struct {
+	OrderIds []uuid.UUID
+	TenantID string
+}
+
+func (q *Queries) RetrieveOrderPacksByOrders(ctx context.Context, arg RetrieveOrderPacksByOrdersParams) ([]OrderPack, error) {
+	rows, err := q.db.QueryContext(ctx, retrieveOrderPacksByOrders, pq.Array(arg.OrderIds), arg.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OrderPack
+	for rows.Next() {
+		var i OrderPack
+		if err := rows.Scan(
+			&i.OrderPacksID,
+			&i.OrderID,
+			&i.PackSize,
+			&i.PackQuantity,
+			&i.TenantID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retrieveOrderQuantityHistogram = `-- name: RetrieveOrderQuantityHistogram :many
+select (o.order_quantity / $3::int) * $3::int as bucket_start, count(*) as order_count
+from public.order o
+where o.tenant_id = $4
+and o.status <> 'cancelled'
+and ($1::timestamptz is null or o.created_at >= $1)
+and ($2::timestamptz is null or o.created_at < $2)
+GROUP BY bucket_start
+ORDER BY bucket_start
+`
+
+type RetrieveOrderQuantityHistogramParams struct {
+	From       sql.NullTime
+	To         sql.NullTime
+	BucketSize int32
+	TenantID   string
+}
+
+type RetrieveOrderQuantityHistogramRow struct {
+	BucketStart int32
+	OrderCount  int64
+}
+
+func (q *Queries) RetrieveOrderQuantityHistogram(ctx context.Context, arg RetrieveOrderQuantityHistogramParams) ([]RetrieveOrderQuantityHistogramRow, error) {
+	rows, err := q.db.QueryContext(ctx, retrieveOrderQuantityHistogram,
+		arg.From,
+		arg.To,
+		arg.BucketSize,
+		arg.TenantID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RetrieveOrderQuantityHistogramRow
+	for rows.Next() {
+		var i RetrieveOrderQuantityHistogramRow
+		if err := rows.Scan(
+			&i.BucketStart,
+			&i.OrderCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retrieveOrderTotals = `-- name: RetrieveOrderTotals :one
+select count(*) as order_count,
+    coalesce(sum(o.order_quantity), 0)::bigint as items_ordered,
+    coalesce(sum(s.items_shipped), 0)::bigint as items_shipped,
+    coalesce(sum(s.pack_count), 0)::bigint as pack_count
+from public.order o
+join (
+    select order_id, sum(pack_size * pack_quantity) as items_shipped, sum(pack_quantity) as pack_count
+    from public.order_packs
+    GROUP BY order_id
+) s on s.order_id = o.order_id
+where o.tenant_id = $3
+and o.status <> 'cancelled'
+and ($1::timestamptz is null or o.created_at >= $1)
+and ($2::timestamptz is null or o.created_at < $2)
+`
+
+type RetrieveOrderTotalsParams struct {
+	From     sql.NullTime
+	To       sql.NullTime
+	TenantID string
+}
+
+type RetrieveOrderTotalsRow struct {
+	OrderCount   int64
+	ItemsOrdered int64
+	ItemsShipped int64
+	PackCount    int64
+}
+
+func (q *Queries) RetrieveOrderTotals(ctx context.Context, arg RetrieveOrderTotalsParams) (RetrieveOrderTotalsRow, error) {
+	row := q.db.QueryRowContext(ctx, retrieveOrderTotals, arg.From, arg.To, arg.TenantID)
+	var i RetrieveOrderTotalsRow
+	err := row.Scan(
+		&i.OrderCount,
+		&i.ItemsOrdered,
+		&i.ItemsShipped,
+		&i.PackCount,
+	)
+	return i, err
+}
+
+const retrieveOrders = `-- name: RetrieveOrders :many
+select order_id, order_quantity, status, created_at, updated_at, calculated_at, picked_at, packed_at, shipped_at, cancelled_at, pack_set, profile, tenant_id, order_date from public.order
+where public.order.tenant_id = $1
+`
+
+func (q *Queries) RetrieveOrders(ctx context.Context, tenantID string) ([]Order, error) {
+	rows, err := q.db.QueryContext(ctx, retrieveOrders, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Order
+	for rows.Next() {
+		var i Order
+		if err := rows.Scan(
+			&i.OrderID,
+			&i.OrderQuantity,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.CalculatedAt,
+			&i.PickedAt,
+			&i.PackedAt,
+			&i.ShippedAt,
+			&i.CancelledAt,
+			pq.Array(&i.PackSet),
+			&i.Profile,
+			&i.TenantID,
+			&i.OrderDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retrieveOrdersForRecalculation = `-- name: RetrieveOrdersForRecalculation :many
+select order_id, order_quantity, status, created_at, updated_at, calculated_at, picked_at, packed_at, shipped_at, cancelled_at, pack_set, profile, tenant_id, order_date from public.order
+where status = any($1::text[])
+and ($2::timestamptz is null or created_at >= $2)
+and ($3::timestamptz is null or created_at < $3)
+and order_id > $4
+and profile = $6
+and tenant_id = $7
+ORDER BY order_id
+limit $5
+for update
+`
+
+type RetrieveOrdersForRecalculationParams struct {
+	Statuses     []string
+	CreatedFrom  sql.NullTime
+	CreatedTo    sql.NullTime
+	AfterOrderID uuid.UUID
+	BatchSize    int32
+	Profile      string
+	TenantID     string
+}
+
+func (q *Queries) RetrieveOrdersForRecalculation(ctx context.Context, arg RetrieveOrdersForRecalculationParams) ([]Order, error) {
+	rows, err := q.db.QueryContext(ctx, retrieveOrdersForRecalculation,
+		pq.Array(arg.Statuses),
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.AfterOrderID,
+		arg.BatchSize,
+		arg.Profile,
+		arg.TenantID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Order
+	for rows.Next() {
+		var i Order
+		if err := rows.Scan(
+			&i.OrderID,
+			&i.OrderQuantity,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.CalculatedAt,
+			&i.PickedAt,
+			&i.PackedAt,
+			&i.ShippedAt,
+			&i.CancelledAt,
+			pq.Array(&i.PackSet),
+			&i.Profile,
+			&i.TenantID,
+			&i.OrderDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retrieveOrdersPage = `-- name: RetrieveOrdersPage :many
+select order_id, order_quantity, status, created_at, updated_at, calculated_at, picked_at, packed_at, shipped_at, cancelled_at, pack_set, profile, tenant_id, order_date from public.order
+where order_id > $1
+and tenant_id = $3
+ORDER BY order_id
+limit $2
+`
+
+type RetrieveOrdersPageParams struct {
+	AfterOrderID uuid.UUID
+	PageSize     int32
+	TenantID     string
+}
+
+func (q *Queries) RetrieveOrdersPage(ctx context.Context, arg RetrieveOrdersPageParams) ([]Order, error) {
+	rows, err := q.db.QueryContext(ctx, retrieveOrdersPage, arg.AfterOrderID, arg.PageSize, arg.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Order
+	for rows.Next() {
+		var i Order
+		if err := rows.Scan(
+			&i.OrderID,
+			&i.OrderQuantity,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.CalculatedAt,
+			&i.PickedAt,
+			&i.PackedAt,
+			&i.ShippedAt,
+			&i.CancelledAt,
+			pq.Array(&i.PackSet),
+			&i.Profile,
+			&i.TenantID,
+			&i.OrderDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retrievePackAudits = `-- name: RetrievePackAudits :many
+select audit_id, actor, action, pack_size, request_id, created_at, profile, tenant_id from public.pack_audit
+where tenant_id = $8
+and ($1::text is null or actor = $1)
+and ($2::text is null or action = $2)
+and ($3::int is null or pack_size = $3)
+and ($4::timestamptz is null or created_at >= $4)
+and ($5::timestamptz is null or created_at < $5)
+ORDER BY created_at DESC, audit_id
+limit $6 offset $7
+`
+
+type RetrievePackAuditsParams struct {
+	Actor    sql.NullString
+	Action   sql.NullString
+	PackSize sql.NullInt32
+	From     sql.NullTime
+	To       sql.NullTime
+	Limit    int32
+	Offset   int32
+	TenantID string
+}
+
+func (q *Queries) RetrievePackAudits(ctx context.Context, arg RetrievePackAuditsParams) ([]PackAudit, error) {
+	rows, err := q.db.QueryContext(ctx, retrievePackAudits,
+		arg.Actor,
+		arg.Action,
+		arg.PackSize,
+		arg.From,
+		arg.To,
+		arg.Limit,
+		arg.Offset,
+		arg.TenantID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PackAudit
+	for rows.Next() {
+		var i PackAudit
+		if err := rows.Scan(
+			&i.AuditID,
+			&i.Actor,
+			&i.Action,
+			&i.PackSize,
+			&i.RequestID,
+			&i.CreatedAt,
+			&i.Profile,
+			&i.TenantID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retrievePackPrices = `-- name: RetrievePackPrices :many
+select pack_size, price, price_currency from public.pack
+where public.pack.profile = $1 and public.pack.tenant_id = $2 and price is not null ORDER BY pack_size DESC
+`
+
+type RetrievePackPricesParams struct {
+	Profile  string
+	TenantID string
+}
+
+type RetrievePackPricesRow struct {
+	PackSize      int32
+	Price         decimal.Decimal
+	PriceCurrency sql.NullString
+}
+
+func (q *Queries) RetrievePackPrices(ctx context.Context, arg RetrievePackPricesParams) ([]RetrievePackPricesRow, error) {
+	rows, err := q.db.QueryContext(ctx, retrievePackPrices, arg.Profile, arg.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RetrievePackPricesRow
+	for rows.Next() {
+		var i RetrievePackPricesRow
+		if err := rows.Scan(
+			&i.PackSize,
+			&i.Price,
+			&i.PriceCurrency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retrievePackProfiles = `-- name: RetrievePackProfiles :many
+select name, created_at, tenant_id from public.pack_profile
+where public.pack_profile.tenant_id = $1 ORDER BY name
+`
+
+func (q *Queries) RetrievePackProfiles(ctx context.Context, tenantID string) ([]PackProfile, error) {
+	rows, err := q.db.QueryContext(ctx, retrievePackProfiles, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PackProfile
+	for rows.Next() {
+		var i PackProfile
+		if err := rows.Scan(&i.Name, &i.CreatedAt, &i.TenantID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retrievePackSchedule = `-- name: RetrievePackSchedule :many
+select pack_size, max_weight_grams, length_mm, width_mm, height_mm, price, profile, tenant_id, valid_from, valid_to, price_currency from public.pack
+where public.pack.profile = $1 and public.pack.tenant_id = $2 ORDER BY pack_size DESC
+`
+
+type RetrievePackScheduleParams struct {
+	Profile  string
+	TenantID string
+}
+
+func (q *Queries) RetrievePackSchedule(ctx context.Context, arg RetrievePackScheduleParams) ([]Pack, error) {
+	rows, err := q.db.QueryContext(ctx, retrievePackSchedule, arg.Profile, arg.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Pack
+	for rows.Next() {
+		var i Pack
+		if err := rows.Scan(
+			&i.PackSize,
+			&i.MaxWeightGrams,
+			&i.LengthMm,
+			&i.WidthMm,
+			&i.HeightMm,
+			&i.Price,
+			&i.Profile,
+			&i.TenantID,
+			&i.ValidFrom,
+			&i.ValidTo,
+			&i.PriceCurrency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retrievePackSpecifications = `-- name: RetrievePackSpecifications :many
+select pack_size, max_weight_grams, length_mm, width_mm, height_mm, price, profile, tenant_id, valid_from, valid_to, price_currency from public.pack
+where public.pack.profile = $1 and public.pack.tenant_id = $2
+and (public.pack.valid_from is null or public.pack.valid_from <= coalesce($3::timestamptz, now()))
+and (public.pack.valid_to is null or public.pack.valid_to > coalesce($3::timestamptz, now()))
+ORDER BY pack_size DESC
+`
+
+type RetrievePackSpecificationsParams struct {
+	Profile  string
+	TenantID string
+	ValidAt  sql.NullTime
+}
+
+func (q *Queries) RetrievePackSpecifications(ctx context.Context, arg RetrievePackSpecificationsParams) ([]Pack, error) {
+	rows, err := q.db.QueryContext(ctx, retrievePackSpecifications, arg.Profile, arg.TenantID, arg.ValidAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Pack
+	for rows.Next() {
+		var i Pack
+		if err := rows.Scan(
+			&i.PackSize,
+			&i.MaxWeightGrams,
+			&i.LengthMm,
+			&i.WidthMm,
+			&i.HeightMm,
+			&i.Price,
+			&i.Profile,
+			&i.TenantID,
+			&i.ValidFrom,
+			&i.ValidTo,
+			&i.PriceCurrency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retrievePackUsage = `-- name: RetrievePackUsage :many
+select s.pack_size, sum(s.pack_quantity)::bigint as pack_count, count(distinct s.order_id) as order_count
+from public.order_packs s
+join public.order o on o.order_id = s.order_id
+where o.tenant_id = $3
+and o.status <> 'cancelled'
+and s.pack_quantity > 0
+and ($1::timestamptz is null or o.created_at >= $1)
+and ($2::timestamptz is null or o.created_at < $2)
+GROUP BY s.pack_size
+ORDER BY s.pack_size
+`
+
+type RetrievePackUsageParams struct {
+	From     sql.NullTime
+	To       sql.NullTime
+	TenantID string
+}
+
+type RetrievePackUsageRow struct {
+	PackSize   int32
+	PackCount  int64
+	OrderCount int64
+}
+
+func (q *Queries) RetrievePackUsage(ctx context.Context, arg RetrievePackUsageParams) ([]RetrievePackUsageRow, error) {
+	rows, err := q.db.QueryContext(ctx, retrievePackUsage, arg.From, arg.To, arg.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RetrievePackUsageRow
+	for rows.Next() {
+		var i RetrievePackUsageRow
+		if err := rows.Scan(
+			&i.PackSize,
+			&i.PackCount,
+			&i.OrderCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retrievePacks = `-- name: RetrievePacks :many
+select pack_size from public.pack
+where public.pack.profile = $1 and public.pack.tenant_id = $2
+and (public.pack.valid_from is null or public.pack.valid_from <= coalesce($3::timestamptz, now()))
+and (public.pack.valid_to is null or public.pack.valid_to > coalesce($3::timestamptz, now()))
+ORDER BY pack_size DESC
+`
+
+type RetrievePacksParams struct {
+	Profile  string
+	TenantID string
+	ValidAt  sql.NullTime
+}
+
+func (q *Queries) RetrievePacks(ctx context.Context, arg RetrievePacksParams) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, retrievePacks, arg.Profile, arg.TenantID, arg.ValidAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var pack_size int32
+		if err := rows.Scan(&pack_size); err != nil {
+			return nil, err
+		}
+		items = append(items, pack_size)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retrieveQuoteById = `-- name: RetrieveQuoteById :one
+select quote_id, quote_quantity, pack_set, currency, subtotal, discount_percent, discount, total, order_id, expires_at, created_at, profile, tenant_id
+from public.quote where public.quote.quote_id = $1 and public.quote.tenant_id = $2
+`
+
+type RetrieveQuoteByIdParams struct {
+	QuoteID  uuid.UUID
+	TenantID string
+}
+
+func (q *Queries) RetrieveQuoteById(ctx context.Context, arg RetrieveQuoteByIdParams) (Quote, error) {
+	row := q.db.QueryRowContext(ctx, retrieveQuoteById, arg.QuoteID, arg.TenantID)
+	var i Quote
+	err := row.Scan(
+		&i.QuoteID,
+		&i.QuoteQuantity,
+		pq.Array(&i.PackSet),
+		&i.Currency,
+		&i.Subtotal,
+		&i.DiscountPercent,
+		&i.Discount,
+		&i.Total,
+		&i.OrderID,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.Profile,
+		&i.TenantID,
+	)
+	return i, err
+}
+
+const retrieveQuotePacksByQuote = `-- name: RetrieveQuotePacksByQuote :many
+select quote_id, pack_size, pack_quantity, unit_price, line_total, tenant_id from public.quote_pack
+where public.quote_pack.quote_id = $1 and public.quote_pack.tenant_id = $2 ORDER BY pack_size DESC
+`
+
+type RetrieveQuotePacksByQuoteParams struct {
+	QuoteID  uuid.UUID
+	TenantID string
+}
+
+func (q *Queries) RetrieveQuotePacksByQuote(ctx context.Context, arg RetrieveQuotePacksByQuoteParams) ([]QuotePack, error) {
+	rows, err := q.db.QueryContext(ctx, retrieveQuotePacksByQuote, arg.QuoteID, arg.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []QuotePack
+	for rows.Next() {
+		var i QuotePack
+		if err := rows.Scan(
+			&i.QuoteID,
+			&i.PackSize,
+			&i.PackQuantity,
+			&i.UnitPrice,
+			&i.LineTotal,
+			&i.TenantID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retrieveSchemaVersion = `-- name: RetrieveSchemaVersion :one
+select coalesce(max(version), 0)::int as version from public.schema_migrations
+`
+
+func (q *Queries) RetrieveSchemaVersion(ctx context.Context) (int32, error) {
+	row := q.db.QueryRowContext(ctx, retrieveSchemaVersion)
+	var version int32
+	err := row.Scan(&version)
+	return version, err
+}
+
+const revokeApiKey = `-- name: RevokeApiKey :execrows
+update public.api_key set revoked_at = now()
+where public.api_key.api_key_id = $1 and public.api_key.revoked_at is null
+and (public.api_key.tenant_id = $2 or ($3::boolean and public.api_key.tenant_id is null))
+`
+
+type RevokeApiKeyParams struct {
+	ApiKeyID       uuid.UUID
+	TenantID       string
+	IncludeUnbound bool
+}
+
+func (q *Queries) RevokeApiKey(ctx context.Context, arg RevokeApiKeyParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, revokeApiKey, arg.ApiKeyID, arg.TenantID, arg.IncludeUnbound)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const schedulePack = `-- name: SchedulePack :one
+insert into public.pack (profile, pack_size, tenant_id, valid_from, valid_to) values ($1, $2, $3, $4, $5)
+on conflict (tenant_id, profile, pack_size) do update set valid_from = excluded.valid_from, valid_to = excluded.valid_to
+returning pack_size, max_weight_grams, length_mm, width_mm, height_mm, price, profile, tenant_id, valid_from, valid_to, price_currency
+`
+
+type SchedulePackParams struct {
+	Profile   string
+	PackSize  int32
+	TenantID  string
+	ValidFrom sql.NullTime
+	ValidTo   sql.NullTime
+}
+
+func (q *Queries) SchedulePack(ctx context.Context, arg SchedulePackParams) (Pack, error) {
+	row := q.db.QueryRowContext(ctx, schedulePack,
+		arg.Profile,
+		arg.PackSize,
+		arg.TenantID,
+		arg.ValidFrom,
+		arg.ValidTo,
+	)
+	var i Pack
+	err := row.Scan(
+		&i.PackSize,
+		&i.MaxWeightGrams,
+		&i.LengthMm,
+		&i.WidthMm,
+		&i.HeightMm,
+		&i.Price,
+		&i.Profile,
+		&i.TenantID,
+		&i.ValidFrom,
+		&i.ValidTo,
+		&i.PriceCurrency,
+	)
+	return i, err
+}
+
+const updateOrderPackSet = `-- name: UpdateOrderPackSet :exec
+update public.order set pack_set = $2, updated_at = now() where order_id = $1 and tenant_id = $3
+`
+
+type UpdateOrderPackSetParams struct {
+	OrderID  uuid.UUID
+	PackSet  []int32
+	TenantID string
+}
+
+func (q *Queries) UpdateOrderPackSet(ctx context.Context, arg UpdateOrderPackSetParams) error {
+	_, err := q.db.ExecContext(ctx, updateOrderPackSet, arg.OrderID, pq.Array(arg.PackSet), arg.TenantID)
+	return err
+}
+
+const updateOrderQuantity = `-- name: UpdateOrderQuantity :one
+update public.order set order_quantity = $2, updated_at = now()
+where order_id = $1 and status = $3 and tenant_id = $4
+returning order_id, order_quantity, status, created_at, updated_at, calculated_at, picked_at, packed_at, shipped_at, cancelled_at, pack_set, profile, tenant_id, order_date
+`
+
+type UpdateOrderQuantityParams struct {
+	OrderID       uuid.UUID
+	OrderQuantity int32
+	Status        string
+	TenantID      string
+}
+
+func (q *Queries) UpdateOrderQuantity(ctx context.Context, arg UpdateOrderQuantityParams) (Order, error) {
+	row := q.db.QueryRowContext(ctx, updateOrderQuantity,
+		arg.OrderID,
+		arg.OrderQuantity,
+		arg.Status,
+		arg.TenantID,
+	)
+	var i Order
+	err := row.Scan(
+		&i.OrderID,
+		&i.OrderQuantity,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CalculatedAt,
+		&i.PickedAt,
+		&i.PackedAt,
+		&i.ShippedAt,
+		&i.CancelledAt,
+		pq.Array(&i.PackSet),
+		&i.Profile,
+		&i.TenantID,
+		&i.OrderDate,
+	)
+	return i, err
+}
+
+const updateOrderStatus = `-- name: UpdateOrderStatus :one
+update public.order set
+status = $1::text,
+updated_at = now(),
+calculated_at = case when $1::text = 'calculated' then now() else calculated_at end,
+picked_at = case when $1::text = 'picked' then now() else picked_at end,
+packed_at = case when $1::text = 'packed' then now() else packed_at end,
+shipped_at = case when $1::text = 'shipped' then now() else shipped_at end,
+cancelled_at = case when $1::text = 'cancelled' then now() else cancelled_at end
+where order_id = $2 and status = $3::text and tenant_id = $4
+returning order_id, order_quantity, status, created_at, updated_at, calculated_at, picked_at, packed_at, shipped_at, cancelled_at, pack_set, profile, tenant_id, order_date
+`
+
+type UpdateOrderStatusParams struct {
+	ToStatus   string
+	OrderID    uuid.UUID
+	FromStatus string
+	TenantID   string
+}
+
+func (q *Queries) UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) (Order, error) {
+	row := q.db.QueryRowContext(ctx, updateOrderStatus,
+		arg.ToStatus,
+		arg.OrderID,
+		arg.FromStatus,
+		arg.TenantID,
+	)
+	var i Order
+	err := row.Scan(
+		&i.OrderID,
+		&i.OrderQuantity,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CalculatedAt,
+		&i.PickedAt,
+		&i.PackedAt,
+		&i.ShippedAt,
+		&i.CancelledAt,
+		pq.Array(&i.PackSet),
+		&i.Profile,
+		&i.TenantID,
+		&i.OrderDate,
+	)
+	return i, err
+}
+
+const updatePackPrice = `-- name: UpdatePackPrice :one
+update public.pack set price = $2, price_currency = $5
+where public.pack.pack_size = $1 and public.pack.profile = $3 and public.pack.tenant_id = $4
+returning pack_size, max_weight_grams, length_mm, width_mm, height_mm, price, profile, tenant_id, valid_from, valid_to, price_currency
+`
+
+type UpdatePackPriceParams struct {
+	PackSize      int32
+	Price         decimal.NullDecimal
+	Profile       string
+	TenantID      string
+	PriceCurrency sql.NullString
+}
+
+func (q *Queries) UpdatePackPrice(ctx context.Context, arg UpdatePackPriceParams) (Pack, error) {
+	row := q.db.QueryRowContext(ctx, updatePackPrice,
+		arg.PackSize,
+		arg.Price,
+		arg.Profile,
+		arg.TenantID,
+		arg.PriceCurrency,
+	)
+	var i Pack
+	err := row.Scan(
+		&i.PackSize,
+		&i.MaxWeightGrams,
+		&i.LengthMm,
+		&i.WidthMm,
+		&i.HeightMm,
+		&i.Price,
+		&i.Profile,
+		&i.TenantID,
+		&i.ValidFrom,
+		&i.ValidTo,
+		&i.PriceCurrency,
+	)
+	return i, err
+}
+
+const updatePackSpecification = `-- name: UpdatePackSpecification :one
+update public.pack set max_weight_grams = $2, length_mm = $3, width_mm = $4, height_mm = $5
+where public.pack.pack_size = $1 and public.pack.profile = $6 and public.pack.tenant_id = $7
+returning pack_size, max_weight_grams, length_mm, width_mm, height_mm, price, profile, tenant_id, valid_from, valid_to, price_currency
+`
+
+type UpdatePackSpecificationParams struct {
+	PackSize       int32
+	MaxWeightGrams sql.NullInt32
+	LengthMm       sql.NullInt32
+	WidthMm        sql.NullInt32
+	HeightMm       sql.NullInt32
+	Profile        string
+	TenantID       string
+}
+
+func (q *Queries) UpdatePackSpecification(ctx context.Context, arg UpdatePackSpecificationParams) (Pack, error) {
+	row := q.db.QueryRowContext(ctx, updatePackSpecification,
+		arg.PackSize,
+		arg.MaxWeightGrams,
+		arg.LengthMm,
+		arg.WidthMm,
+		arg.HeightMm,
+		arg.Profile,
+		arg.TenantID,
+	)
+	var i Pack
+	err := row.Scan(
+		&i.PackSize,
+		&i.MaxWeightGrams,
+		&i.LengthMm,
+		&i.WidthMm,
+		&i.HeightMm,
+		&i.Price,
+		&i.Profile,
+		&i.TenantID,
+		&i.ValidFrom,
+		&i.ValidTo,
+		&i.PriceCurrency,
+	)
+	return i, err
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+)
+
+// Transactor runs a function inside a DB transaction, handing it a Querier bound to the transaction.
+// The transaction is committed when the function succeeds and rolled back otherwise.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(querier Querier) error) error
+}
+
+type transactor struct {
+	db *sql.DB
+}
+
+func NewTransactor(db *sql.DB) Transactor {
+	return transactor{db: db}
+}
+
+func (t transactor) WithinTransaction(ctx context.Context, fn func(querier Querier) error) error {
+	tx, txErr := t.db.BeginTx(ctx, nil)
+	if txErr != nil {
+		return errors.Wrap(txErr, "could not begin transaction")
+	}
+	defer tx.Rollback()
+
+	if fnErr := fn(New(t.db).WithTx(tx)); fnErr != nil {
+		return fnErr
+	}
+	if commitErr := tx.Commit(); commitErr != nil {
+		return errors.Wrap(commitErr, "could not commit transaction")
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+// Package transfer encodes and decodes the files used to export and import packs and orders, as CSV or JSON.
+// Records are written as they come, so large tables can be streamed.
+package transfer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+type Format string
+
+const (
+	FormatCsv  Format = "csv"
+	FormatJson Format = "json"
+)
+
+// Parse the format name, defaulting to JSON when empty
+func ParseFormat(value string) (Format, error) {
+	switch Format(value) {
+	case "", FormatJson:
+		return FormatJson, nil
+	case FormatCsv:
+		return FormatCsv, nil
+	default:
+		return "", fmt.Errorf("format [%v] must be one of [csv json]", value)
+	}
+}
+
+func (f Format) ContentType() string {
+	if f == FormatCsv {
+		return "text/csv"
+	}
+	return "application/json"
+}
+
+// Writes records one by one. Close must be called once all of them are written.
+type RecordWriter[T any] interface {
+	Write(record T) error
+	Close() error
+}
+
+func newRecordWriter[T any](w io.Writer, format Format, header []string, toRows func(T) [][]string) RecordWriter[T] {
+	if format == FormatCsv {
+		return &csvRecordWriter[T]{writer: csv.NewWriter(w), header: header, toRows: toRows}
+	}
+	return &jsonRecordWriter[T]{writer: w}
+}
+
+// Writes the records as the rows of a CSV with a header, a record may span several rows
+type csvRecordWriter[T any] struct {
+	writer        *csv.Writer
+	header        []string
+	toRows        func(T) [][]string
+	headerWritten bool
+}
+
+func (cw *csvRecordWriter[T]) Write(record T) error {
+	if !cw.headerWritten {
+		if writeErr := cw.writer.Write(cw.header); writeErr != nil {
+			return writeErr
+		}
+		cw.headerWritten = true
+	}
+	return cw.writer.WriteAll(cw.toRows(record))
+}
+
+func (cw *csvRecordWriter[T]) Close() error {
+	if !cw.headerWritten {
+		if writeErr := cw.writer.Write(cw.header); writeErr != nil {
+			return writeErr
+		}
+	}
+	cw.writer.Flush()
+	return cw.writer.Error()
+}
+
+// Writes the records as the elements of a JSON array, one per line
+type jsonRecordWriter[T any] struct {
+	writer io.Writer
+	count  int
+}
+
+func (jw *jsonRecordWriter[T]) Write(record T) error {
+	encoded, marshalErr := json.Marshal(record)
+	if marshalErr != nil {
+		return marshalErr
+	}
+	separator := ",\n"
+	if jw.count == 0 {
+		separator = "[\n"
+	}
+	if _, writeErr := io.WriteString(jw.writer, separator); writeErr != nil {
+		return writeErr
+	}
+	jw.count++
+	_, writeErr := jw.writer.Write(encoded)
+	return writeErr
+}
+
+func (jw *jsonRecordWriter[T]) Close() error {
+	closing := "\n]\n"
+	if jw.count == 0 {
+		closing = "[]\n"
+	}
+	_, writeErr := io.WriteString(jw.writer, closing)
+	return writeErr
+}
@@ -0,0 +1,88 @@
+package transfer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+	"github.com/google/uuid"
+)
+
+// Read a CSV of order quantities, calling fn with each of them and their row number, starting at 1. The quantities
+// are read from the quantity column when there is a header naming it, or else from the first column. Rows that are
+// not a number are passed on with their parse error, malformed CSVs stop the reading.
+func ReadQuantities(r io.Reader, fn func(row int, quantity int, parseErr error) error) error {
+	csvReader := csv.NewReader(r)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	quantityColumn := 0
+	for row := 1; ; row++ {
+		record, readErr := csvReader.Read()
+		if errors.Is(readErr, io.EOF) {
+			return nil
+		}
+		if readErr != nil {
+			return readErr
+		}
+
+		// A first row without numbers is a header
+		if row == 1 {
+			if column, isHeader := headerQuantityColumn(record); isHeader {
+				quantityColumn = column
+				row--
+				continue
+			}
+		}
+
+		if quantityColumn >= len(record) {
+			if fnErr := fn(row, 0, fmt.Errorf("row has no column [%v]", quantityColumn+1)); fnErr != nil {
+				return fnErr
+			}
+			continue
+		}
+		quantity, parseErr := strconv.Atoi(strings.TrimSpace(record[quantityColumn]))
+		if fnErr := fn(row, quantity, parseErr); fnErr != nil {
+			return fnErr
+		}
+	}
+}
+
+func headerQuantityColumn(record []string) (int, bool) {
+	isHeader, quantityColumn := true, 0
+	for column, field := range record {
+		if _, parseErr := strconv.Atoi(strings.TrimSpace(field)); parseErr == nil {
+			isHeader = false
+		}
+		if strings.EqualFold(strings.TrimSpace(field), "quantity") {
+			quantityColumn = column
+		}
+	}
+	return quantityColumn, isHeader
+}
+
+// Import an order per quantity read from r with importOrder, writing the result of each row as it goes. Rows that
+// fail are reported in their result and do not stop the import. Returns how many rows failed.
+func ImportOrders(r io.Reader, resultWriter RecordWriter[viewmodel.OrderImportResult], importOrder func(quantity int) (uuid.UUID, []viewmodel.OrderPack, error)) (int, error) {
+	failedRows := 0
+	readErr := ReadQuantities(r, func(row int, quantity int, parseErr error) error {
+		result := viewmodel.OrderImportResult{Row: row, Quantity: quantity}
+		if parseErr != nil {
+			result.Error = parseErr.Error()
+		} else if orderId, packs, importErr := importOrder(quantity); importErr != nil {
+			result.Error = importErr.Error()
+		} else {
+			result.OrderId = &orderId
+			result.Packs = packs
+		}
+		if result.Error != "" {
+			failedRows++
+		}
+		return resultWriter.Write(result)
+	})
+	return failedRows, readErr
+}
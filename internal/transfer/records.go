@@ -0,0 +1,75 @@
+package transfer
+
+import (
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+)
+
+// Write the pack sizes, one per row or element
+func WritePacks(w io.Writer, format Format, packs []viewmodel.PackExport) error {
+	packWriter := newRecordWriter(w, format, []string{"size"}, func(pack viewmodel.PackExport) [][]string {
+		return [][]string{{strconv.Itoa(pack.Size)}}
+	})
+	for _, pack := range packs {
+		if writeErr := packWriter.Write(pack); writeErr != nil {
+			return writeErr
+		}
+	}
+	return packWriter.Close()
+}
+
+// Orders are written as one CSV row per pack, repeating the order columns, so they can be filtered in a spreadsheet.
+// Orders without packs take a single row with empty pack columns.
+func NewOrderWriter(w io.Writer, format Format) RecordWriter[viewmodel.OrderExport] {
+	header := []string{"id", "quantity", "status", "created_at", "pack_size", "pack_quantity"}
+	return newRecordWriter(w, format, header, func(order viewmodel.OrderExport) [][]string {
+		orderColumns := []string{
+			order.OrderId.String(),
+			strconv.Itoa(order.Quantity),
+			order.Status,
+			order.CreatedAt.UTC().Format(time.RFC3339Nano),
+		}
+		if len(order.Packs) == 0 {
+			return [][]string{append(orderColumns, "", "")}
+		}
+		rows := make([][]string, 0, len(order.Packs))
+		for _, pack := range order.Packs {
+			rows = append(rows, append(append([]string{}, orderColumns...), strconv.Itoa(pack.Size), strconv.Itoa(pack.Quantity)))
+		}
+		return rows
+	})
+}
+
+// Import results are written as one CSV row per imported row, with the packs as size x quantity pairs
+func NewImportResultWriter(w io.Writer, format Format) RecordWriter[viewmodel.OrderImportResult] {
+	header := []string{"row", "quantity", "id", "packs", "error"}
+	return newRecordWriter(w, format, header, func(result viewmodel.OrderImportResult) [][]string {
+		orderId := ""
+		if result.OrderId != nil {
+			orderId = result.OrderId.String()
+		}
+		return [][]string{{strconv.Itoa(result.Row), strconv.Itoa(result.Quantity), orderId, formatPacks(result.Packs), result.Error}}
+	})
+}
+
+// Chart rows are written as one CSV row per quantity, with the packs as size x quantity pairs
+func NewPackChartWriter(w io.Writer, format Format) RecordWriter[viewmodel.PackChartRow] {
+	header := []string{"quantity", "items", "overage", "pack_count", "packs"}
+	return newRecordWriter(w, format, header, func(row viewmodel.PackChartRow) [][]string {
+		return [][]string{{strconv.Itoa(row.Quantity), strconv.Itoa(row.Items), strconv.Itoa(row.Overage), strconv.Itoa(row.PackCount), formatPacks(row.Packs)}}
+	})
+}
+
+func formatPacks(packs []viewmodel.OrderPack) string {
+	formatted := ""
+	for i, pack := range packs {
+		if i > 0 {
+			formatted += " "
+		}
+		formatted += strconv.Itoa(pack.Size) + "x" + strconv.Itoa(pack.Quantity)
+	}
+	return formatted
+}
@@ -0,0 +1,140 @@
+package transfer_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/felipevillarrealdaza/go-service-template/internal/controller/viewmodel"
+	"github.com/felipevillarrealdaza/go-service-template/internal/transfer"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewOrderWriter(t *testing.T) {
+	// Set Up
+	orderId := uuid.MustParse("7b4f4f4e-8a53-4d8a-9d54-4c4d8c6b1f00")
+	orders := []viewmodel.OrderExport{
+		{
+			OrderDetailsResponse: viewmodel.OrderDetailsResponse{OrderId: orderId, Quantity: 12, Status: "calculated", CreatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+			Packs:                []viewmodel.OrderPack{{Size: 2, Quantity: 1}, {Size: 5, Quantity: 2}},
+		},
+		{
+			OrderDetailsResponse: viewmodel.OrderDetailsResponse{OrderId: orderId, Quantity: 3, Status: "created", CreatedAt: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	t.Run("CSV with a row per pack", func(t *testing.T) {
+		// Arrange
+		var output bytes.Buffer
+		orderWriter := transfer.NewOrderWriter(&output, transfer.FormatCsv)
+
+		// Act
+		for _, order := range orders {
+			require.NoError(t, orderWriter.Write(order))
+		}
+		require.NoError(t, orderWriter.Close())
+
+		// Assert
+		require.Equal(t, "id,quantity,status,created_at,pack_size,pack_quantity\n"+
+			orderId.String()+",12,calculated,2024-01-02T00:00:00Z,2,1\n"+
+			orderId.String()+",12,calculated,2024-01-02T00:00:00Z,5,2\n"+
+			orderId.String()+",3,created,2024-01-03T00:00:00Z,,\n", output.String())
+	})
+
+	t.Run("JSON array", func(t *testing.T) {
+		// Arrange
+		var output bytes.Buffer
+		orderWriter := transfer.NewOrderWriter(&output, transfer.FormatJson)
+
+		// Act
+		for _, order := range orders {
+			require.NoError(t, orderWriter.Write(order))
+		}
+		require.NoError(t, orderWriter.Close())
+
+		// Assert
+		var decoded []viewmodel.OrderExport
+		require.NoError(t, json.Unmarshal(output.Bytes(), &decoded))
+		require.Len(t, decoded, 2)
+		require.Equal(t, orders[0].Packs, decoded[0].Packs)
+	})
+
+	t.Run("Empty exports are still valid", func(t *testing.T) {
+		for format, expected := range map[transfer.Format]string{transfer.FormatCsv: "id,quantity,status,created_at,pack_size,pack_quantity\n", transfer.FormatJson: "[]\n"} {
+			// Arrange
+			var output bytes.Buffer
+
+			// Act
+			require.NoError(t, transfer.NewOrderWriter(&output, format).Close())
+
+			// Assert
+			require.Equal(t, expected, output.String())
+		}
+	})
+}
+
+func Test_ImportOrders(t *testing.T) {
+	// Set Up
+	orderId := uuid.New()
+	importOrder := func(quantity int) (uuid.UUID, []viewmodel.OrderPack, error) {
+		if quantity <= 0 {
+			return uuid.Nil, nil, errors.New("quantity must be greater than 0")
+		}
+		return orderId, []viewmodel.OrderPack{{Size: quantity, Quantity: 1}}, nil
+	}
+
+	t.Run("Quantity column found by header", func(t *testing.T) {
+		// Arrange
+		var output bytes.Buffer
+		resultWriter := transfer.NewImportResultWriter(&output, transfer.FormatCsv)
+		input := strings.NewReader("customer,quantity\nacme,250\nglobex,abc\ninitech,0\n")
+
+		// Act
+		failedRows, importErr := transfer.ImportOrders(input, resultWriter, importOrder)
+		require.NoError(t, resultWriter.Close())
+
+		// Assert
+		require.NoError(t, importErr)
+		require.Equal(t, 2, failedRows)
+		lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+		require.Equal(t, []string{
+			"row,quantity,id,packs,error",
+			"1,250," + orderId.String() + ",250x1,",
+			`2,0,,,"strconv.Atoi: parsing ""abc"": invalid syntax"`,
+			"3,0,,,quantity must be greater than 0",
+		}, lines)
+	})
+
+	t.Run("Quantities without header", func(t *testing.T) {
+		// Arrange
+		var output bytes.Buffer
+		resultWriter := transfer.NewImportResultWriter(&output, transfer.FormatJson)
+
+		// Act
+		failedRows, importErr := transfer.ImportOrders(strings.NewReader("10\n20\n"), resultWriter, importOrder)
+		require.NoError(t, resultWriter.Close())
+
+		// Assert
+		require.NoError(t, importErr)
+		require.Zero(t, failedRows)
+		var results []viewmodel.OrderImportResult
+		require.NoError(t, json.Unmarshal(output.Bytes(), &results))
+		require.Len(t, results, 2)
+		require.Equal(t, 2, results[1].Row)
+		require.Equal(t, 20, results[1].Quantity)
+	})
+}
+
+func Test_ParseFormat(t *testing.T) {
+	for value, expected := range map[string]transfer.Format{"": transfer.FormatJson, "json": transfer.FormatJson, "csv": transfer.FormatCsv} {
+		format, parseErr := transfer.ParseFormat(value)
+		require.NoError(t, parseErr)
+		require.Equal(t, expected, format)
+	}
+	_, parseErr := transfer.ParseFormat("xml")
+	require.Error(t, parseErr)
+}
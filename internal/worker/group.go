@@ -0,0 +1,46 @@
+package worker
+
+import (
+	"context"
+	"sync"
+)
+
+// Group runs background workers until it is stopped. Workers receive a context that is cancelled on Stop and
+// must return once it is done.
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewGroup() *Group {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Group{ctx: ctx, cancel: cancel}
+}
+
+// Spawn a goroutine running the worker
+func (g *Group) Go(run func(ctx context.Context)) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		run(g.ctx)
+	}()
+}
+
+// Cancel every worker and wait for them to return, or for ctx to be done, whatever happens first.
+func (g *Group) Stop(ctx context.Context) error {
+	g.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}